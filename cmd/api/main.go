@@ -3,13 +3,17 @@ package main
 import (
 	"context"
 	"log"
+	"net/http"
 
 	"github.com/AhmadMuj/books-api-go/internal/cache"
 	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/dto"
 	"github.com/AhmadMuj/books-api-go/internal/events"
 	"github.com/AhmadMuj/books-api-go/internal/handlers"
+	"github.com/AhmadMuj/books-api-go/internal/metrics"
 	"github.com/AhmadMuj/books-api-go/internal/repository"
 	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/AhmadMuj/books-api-go/internal/storage"
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,55 +28,138 @@ func main() {
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
 
+	metricsRegistry := metrics.NewRegistry()
+
 	// Initialize database
 	db, err := repository.NewDatabase(cfg)
 	if err != nil {
 		log.Fatal("Failed to initialize database:", err)
 	}
+	if err := db.DB.Use(metrics.NewGormPlugin(metricsRegistry)); err != nil {
+		log.Fatal("Failed to initialize database metrics:", err)
+	}
 
-	cacheInstance, err := cache.NewRedisCache(cfg)
-
+	// The API serves without Redis and Kafka when they are down, so only
+	// configuration errors stop it from starting
+	fallbackCache, err := cache.NewFallbackCache(cfg)
 	if err != nil {
 		log.Fatal("Failed to initialize cache:", err)
 	}
+	guardedCache := cache.NewGuardedCache(fallbackCache, cfg.Cache)
+	cacheInstance := cache.NewInstrumentedCache(guardedCache, cfg.Cache.Driver)
 	defer cacheInstance.Close()
+	metricsRegistry.MustRegister(metrics.NewCacheCollector(cacheInstance))
 
-	kafkaProducer, err := events.NewKafkaProducer(cfg)
+	producer, err := events.NewKafkaProducer(cfg)
 	if err != nil {
-		log.Fatal("Failed to initialize Kafka producer:", err)
+		log.Printf("Failed to initialize Kafka producer, events will be dropped: %v\n", err)
+		producer = events.NoopProducer{}
 	}
-	defer kafkaProducer.Close()
+	if kafkaProducer, ok := producer.(*events.KafkaProducer); ok {
+		metricsRegistry.MustRegister(metrics.NewKafkaWriterCollector(kafkaProducer.Stats))
+	}
+	guardedProducer := events.NewGuardedProducer(producer, cfg.Kafka)
+	defer guardedProducer.Close()
 
 	// Initialize event service
-	eventService := events.NewEventService(kafkaProducer)
+	eventService := events.NewEventService(guardedProducer)
 
 	// Optionally initialize consumer
 	kafkaConsumer, err := events.NewKafkaConsumer(cfg)
 	if err != nil {
-		log.Fatal("Failed to initialize Kafka consumer:", err)
+		log.Printf("Failed to initialize Kafka consumer: %v\n", err)
+	} else {
+		defer kafkaConsumer.Close()
+		metricsRegistry.MustRegister(metrics.NewKafkaReaderCollector(kafkaConsumer.Stats))
+
+		if err := kafkaConsumer.Start(context.Background()); err != nil {
+			log.Printf("Failed to start Kafka consumer: %v\n", err)
+		} else {
+			log.Println("Kafka consumer started")
+		}
 	}
-	defer kafkaConsumer.Close()
 
-	if err := kafkaConsumer.Start(context.Background()); err != nil {
-		log.Fatal("Failed to start Kafka consumer:", err)
-	} else {
-		log.Println("Kafka consumer started")
+	blobStore, err := storage.NewBlobStore(context.Background(), cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize blob storage:", err)
 	}
+	dto.RegisterBlobURLs(blobStore.URL)
 
-	// Initialize repository
+	// Initialize repositories
 	bookRepo := repository.NewBookRepository(db.DB)
+	authorRepo := repository.NewAuthorRepository(db.DB)
+	genreRepo := repository.NewGenreRepository(db.DB)
+	tagRepo := repository.NewTagRepository(db.DB)
+	workRepo := repository.NewWorkRepository(db.DB)
+	seriesRepo := repository.NewSeriesRepository(db.DB)
+	copyRepo := repository.NewCopyRepository(db.DB)
+	loanRepo := repository.NewLoanRepository(db.DB)
+	holdRepo := repository.NewHoldRepository(db.DB)
+	reviewRepo := repository.NewReviewRepository(db.DB)
+	readingListRepo := repository.NewReadingListRepository(db.DB)
+
+	// Initialize services
+	bookService := service.NewBookService(bookRepo, copyRepo, cacheInstance, eventService, blobStore, cfg.Covers, cfg.Cache)
+	authorService := service.NewAuthorService(authorRepo, copyRepo, cacheInstance)
+	genreService := service.NewGenreService(genreRepo, cacheInstance)
+	tagService := service.NewTagService(tagRepo, cacheInstance)
+	workService := service.NewWorkService(workRepo, copyRepo, cacheInstance)
+	seriesService := service.NewSeriesService(seriesRepo, eventService)
+	copyService := service.NewCopyService(copyRepo, cacheInstance, eventService, cfg.Holds)
+	loanService := service.NewLoanService(loanRepo, cacheInstance, eventService, cfg.Loans, cfg.Holds)
+	holdService := service.NewHoldService(holdRepo, cacheInstance, eventService, cfg.Holds)
+	reviewService := service.NewReviewService(reviewRepo, cacheInstance, eventService, cfg.Reviews)
+	readingListService := service.NewReadingListService(readingListRepo)
+	cacheService := service.NewCacheService(cacheInstance)
+	healthService := service.NewHealthService(
+		service.HealthCheck{Name: "database", Critical: true, Check: db.Ping},
+		service.HealthCheck{Name: "cache", Check: guardedCache.Check},
+		service.HealthCheck{Name: "events", Check: guardedProducer.Check},
+	)
+
+	// Background jobs
+	service.NewPeriodicTask("mark overdue loans", cfg.Loans.OverdueCheckInterval, loanService.MarkOverdueLoans).Start(context.Background())
+	service.NewPeriodicTask("expire holds", cfg.Holds.ExpiryCheckInterval, holdService.ExpireHolds).Start(context.Background())
+	service.NewPeriodicTask("remove stale list cache keys", cfg.Cache.CleanupInterval, cacheInstance.RemoveStaleLists).Start(context.Background())
+
+	// Initialize handlers
+	apiHandlers := &handlers.Handlers{
+		Book:   handlers.NewBookHandler(bookService, cfg.Covers.MaxSize),
+		Author: handlers.NewAuthorHandler(authorService),
+		Genre:  handlers.NewGenreHandler(genreService),
+		Tag:    handlers.NewTagHandler(tagService),
+		Work:   handlers.NewWorkHandler(workService),
+		Series: handlers.NewSeriesHandler(seriesService),
+		Copy:   handlers.NewCopyHandler(copyService),
+		Loan:   handlers.NewLoanHandler(loanService),
+		Hold:   handlers.NewHoldHandler(holdService),
+		Review: handlers.NewReviewHandler(reviewService),
+
+		ReadingList: handlers.NewReadingListHandler(readingListService),
+		Cache:       handlers.NewCacheHandler(cacheService, bookService),
+		Health:      handlers.NewHealthHandler(healthService),
+	}
 
-	// Initialize service
-	bookService := service.NewBookService(bookRepo, cacheInstance, eventService)
-
-	// Initialize handler
-	bookHandler := handlers.NewBookHandler(bookService)
+	idempotencyStore := cache.NewIdempotencyStore(cacheInstance, cfg)
 
 	// Initialize Gin router
 	r := gin.Default()
 
 	// Setup routes
-	handlers.SetupRoutes(r, bookHandler)
+	handlers.SetupRoutes(r, apiHandlers, idempotencyStore, cfg.Admin.Token, metricsRegistry)
+	if cfg.Storage.Driver == "filesystem" {
+		r.Static(storage.FilesystemRoute, cfg.Storage.Path)
+	}
+
+	// Serve metrics on their own port so they are not exposed with the API
+	if cfg.Server.MetricsPort != "" {
+		go func() {
+			log.Printf("Metrics server starting on port %s", cfg.Server.MetricsPort)
+			if err := http.ListenAndServe(":"+cfg.Server.MetricsPort, metrics.Handler(metricsRegistry)); err != nil {
+				log.Printf("Failed to start metrics server: %v\n", err)
+			}
+		}()
+	}
 
 	// Start server
 	log.Printf("Server starting on port %s", cfg.Server.Port)
@@ -0,0 +1,152 @@
+package breaker
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned instead of calling a dependency whose breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	// Closed lets every call through
+	Closed State = iota
+	// Open rejects every call until the cooldown has passed
+	Open
+	// HalfOpen lets a single probe through to decide whether to close again
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Breaker stops calls to a failing dependency. It opens after a number of
+// consecutive failures, rejects calls for a cooldown, then lets one probe
+// through: the breaker closes if it succeeds and opens again if it fails.
+// It is safe for concurrent use.
+type Breaker struct {
+	name     string
+	failures int
+	cooldown time.Duration
+	onChange func(from, to State)
+	now      func() time.Time
+
+	mu          sync.Mutex
+	state       State
+	consecutive int
+	openedAt    time.Time
+	probing     bool
+}
+
+// New returns a closed breaker that opens after failures consecutive
+// failures and stays open for cooldown
+func New(name string, failures int, cooldown time.Duration) *Breaker {
+	if failures < 1 {
+		failures = 1
+	}
+	return &Breaker{
+		name:     name,
+		failures: failures,
+		cooldown: cooldown,
+		now:      time.Now,
+	}
+}
+
+// OnStateChange registers fn to be called, in its own goroutine, whenever
+// the breaker changes state. It must be set before the breaker is used.
+func (b *Breaker) OnStateChange(fn func(from, to State)) {
+	b.onChange = fn
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == Open && !b.now().Before(b.openedAt.Add(b.cooldown)) {
+		return HalfOpen
+	}
+	return b.state
+}
+
+// Allow reports whether a call may go ahead. Every allowed call must be
+// followed by Success, Failure or Ignore.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Closed:
+		return true
+	case Open:
+		if b.now().Before(b.openedAt.Add(b.cooldown)) {
+			return false
+		}
+		b.setState(HalfOpen)
+	}
+
+	if b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutive = 0
+	if b.state == HalfOpen {
+		b.probing = false
+		b.setState(Closed)
+	}
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutive++
+	if b.state == HalfOpen || (b.state == Closed && b.consecutive >= b.failures) {
+		b.probing = false
+		b.openedAt = b.now()
+		b.setState(Open)
+	}
+}
+
+// Ignore ends an allowed call whose outcome says nothing about the
+// dependency, such as one the caller cancelled
+func (b *Breaker) Ignore() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// setState moves to state; the caller must hold mu
+func (b *Breaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+	log.Printf("Circuit breaker %s is %s\n", b.name, state)
+	if b.onChange != nil {
+		go b.onChange(from, state)
+	}
+}
@@ -0,0 +1,165 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+)
+
+const testCooldown = 10 * time.Second
+
+// step is one call made on a breaker under test. Allow steps check whether
+// the call was let through; every step checks the state it leaves behind.
+type step struct {
+	action string
+	wait   time.Duration
+	allow  bool
+	state  State
+}
+
+func allowed(state State) step  { return step{action: "allow", allow: true, state: state} }
+func rejected(state State) step { return step{action: "allow", allow: false, state: state} }
+func success(state State) step  { return step{action: "success", state: state} }
+func failure(state State) step  { return step{action: "failure", state: state} }
+func ignore(state State) step   { return step{action: "ignore", state: state} }
+func wait(d time.Duration, state State) step {
+	return step{action: "wait", wait: d, state: state}
+}
+
+// tripped opens a breaker that trips after three failures
+var tripped = []step{
+	allowed(Closed), failure(Closed),
+	allowed(Closed), failure(Closed),
+	allowed(Closed), failure(Open),
+}
+
+func TestBreaker(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		steps    []step
+	}{
+		{
+			name:     "opens after consecutive failures",
+			failures: 3,
+			steps:    append(tripped, rejected(Open), rejected(Open)),
+		},
+		{
+			name:     "a success resets the failure count",
+			failures: 3,
+			steps: []step{
+				allowed(Closed), failure(Closed),
+				allowed(Closed), failure(Closed),
+				allowed(Closed), success(Closed),
+				allowed(Closed), failure(Closed),
+				allowed(Closed), failure(Closed),
+				allowed(Closed),
+			},
+		},
+		{
+			name:     "stays open for the cooldown",
+			failures: 3,
+			steps: append(tripped,
+				wait(testCooldown-time.Nanosecond, Open), rejected(Open),
+				wait(time.Nanosecond, HalfOpen),
+			),
+		},
+		{
+			name:     "lets a single probe through when half-open",
+			failures: 3,
+			steps: append(tripped,
+				wait(testCooldown, HalfOpen),
+				allowed(HalfOpen), rejected(HalfOpen), rejected(HalfOpen),
+			),
+		},
+		{
+			name:     "closes after a successful probe",
+			failures: 3,
+			steps: append(tripped,
+				wait(testCooldown, HalfOpen),
+				allowed(HalfOpen), success(Closed),
+				allowed(Closed), allowed(Closed),
+			),
+		},
+		{
+			name:     "reopens after a failed probe",
+			failures: 3,
+			steps: append(tripped,
+				wait(testCooldown, HalfOpen),
+				allowed(HalfOpen), failure(Open),
+				rejected(Open),
+				wait(testCooldown, HalfOpen), allowed(HalfOpen),
+			),
+		},
+		{
+			name:     "an ignored probe lets another through",
+			failures: 3,
+			steps: append(tripped,
+				wait(testCooldown, HalfOpen),
+				allowed(HalfOpen), ignore(HalfOpen),
+				allowed(HalfOpen), rejected(HalfOpen),
+			),
+		},
+		{
+			name:     "fewer than one failure is treated as one",
+			failures: 0,
+			steps:    []step{allowed(Closed), failure(Open), rejected(Open)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+			b := New("test", tt.failures, testCooldown)
+			b.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				switch s.action {
+				case "allow":
+					if got := b.Allow(); got != s.allow {
+						t.Fatalf("step %d: Allow() = %v, want %v", i, got, s.allow)
+					}
+				case "success":
+					b.Success()
+				case "failure":
+					b.Failure()
+				case "ignore":
+					b.Ignore()
+				case "wait":
+					now = now.Add(s.wait)
+				}
+				if got := b.State(); got != s.state {
+					t.Fatalf("step %d (%s): state = %s, want %s", i, s.action, got, s.state)
+				}
+			}
+		})
+	}
+}
+
+func TestBreakerOnStateChange(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	b := New("test", 1, testCooldown)
+	b.now = func() time.Time { return now }
+
+	changes := make(chan [2]State, 3)
+	b.OnStateChange(func(from, to State) { changes <- [2]State{from, to} })
+
+	b.Allow()
+	b.Failure()
+	now = now.Add(testCooldown)
+	b.Allow()
+	b.Success()
+
+	// Each change is reported in its own goroutine, so in any order
+	want := map[[2]State]bool{{Closed, Open}: true, {Open, HalfOpen}: true, {HalfOpen, Closed}: true}
+	for len(want) > 0 {
+		select {
+		case change := <-changes:
+			if !want[change] {
+				t.Errorf("unexpected change %s -> %s", change[0], change[1])
+			}
+			delete(want, change)
+		case <-time.After(time.Second):
+			t.Fatalf("missing changes %v", want)
+		}
+	}
+}
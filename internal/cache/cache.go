@@ -2,22 +2,146 @@ package cache
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
 
 	"github.com/AhmadMuj/books-api-go/internal/models"
 )
 
 type Cache interface {
-	// Single book operations
-	GetBook(ctx context.Context, id uint) (*models.Book, error)
-	SetBook(ctx context.Context, book *models.Book) error
+	// Single book operations. GetBook returns nil when nothing is cached for
+	// the ID and an entry without a book when it is known not to exist.
+	GetBook(ctx context.Context, id uint) (*BookEntry, error)
+	// SetBook caches a book along with how long it took to load, unless a
+	// newer version of the book is already cached
+	SetBook(ctx context.Context, book *models.Book, loadTime time.Duration) error
+	// SetBookMissing briefly records that no book has the ID, unless the
+	// book is already cached
+	SetBookMissing(ctx context.Context, id uint) error
 	DeleteBook(ctx context.Context, id uint) error
 
-	// Book list operations
-	GetBooksList(ctx context.Context, page, pageSize int) ([]models.Book, int64, error)
-	SetBooksList(ctx context.Context, books []models.Book, total int64, page, pageSize int) error
+	// ISBN to book ID lookups
+	GetBookIDByISBN(ctx context.Context, isbn string) (uint, error)
+	SetBookISBN(ctx context.Context, isbn string, id uint) error
+
+	// Book list operations, keyed by filter and page. Get returns the list
+	// generation it looked in, to be passed to Set when the page is loaded
+	// from the database instead.
+	GetBooksList(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, ListGeneration, error)
+	SetBooksList(ctx context.Context, generation ListGeneration, filter models.BookFilter, page, pageSize int, list *models.BookList) error
+	// InvalidateBooksList drops every cached list page along with the
+	// catalogue stats, which are derived from the same data
 	InvalidateBooksList(ctx context.Context) error
 
+	// InvalidateBookPages drops only the cached list pages the book appears
+	// on, for changes that cannot move it to other pages
+	InvalidateBookPages(ctx context.Context, bookID uint) error
+
+	// Catalogue stats, keyed by filter, with generations as for book lists
+	GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, ListGeneration, error)
+	SetStats(ctx context.Context, generation ListGeneration, filter models.StatsFilter, stats *models.CatalogueStats) error
+
+	// Available copy counts per book. Get only returns the books it has a
+	// count for.
+	GetAvailableCopies(ctx context.Context, bookIDs []uint) (map[uint]int, error)
+	SetAvailableCopies(ctx context.Context, counts map[uint]int) error
+	DeleteAvailableCopies(ctx context.Context, bookID uint) error
+
+	// TryLock takes a short-lived lock shared by every instance, so only one
+	// of them reloads an entry. It returns a token for Unlock, or an empty
+	// token if the lock is held elsewhere.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (string, error)
+	// Unlock releases the lock if it is still held with token
+	Unlock(ctx context.Context, key, token string) error
+
+	// RemoveStaleLists deletes list and stats entries left behind by
+	// InvalidateBooksList and reports how many it deleted. It is meant to
+	// run in the background.
+	RemoveStaleLists(ctx context.Context) (int, error)
+
+	// InspectBook returns the cached entry for a book as stored, with how
+	// long it has left to live, or a nil entry when nothing is cached
+	InspectBook(ctx context.Context, id uint) (*BookEntry, time.Duration, error)
+	// DeleteKey evicts a single entry by its full key
+	DeleteKey(ctx context.Context, key string) error
+
 	// Optional: General cache operations
 	Clear(ctx context.Context) error
 	Close() error
 }
+
+// NewCache returns the cache selected by the CACHE_DRIVER setting
+func NewCache(cfg *config.Config) (Cache, error) {
+	switch cfg.Cache.Driver {
+	case "redis":
+		return NewRedisCache(cfg)
+	case "memory":
+		return NewMemoryCache(cfg), nil
+	case "tiered":
+		return NewTieredCache(cfg)
+	default:
+		return nil, fmt.Errorf("unknown cache driver %q", cfg.Cache.Driver)
+	}
+}
+
+// ListGeneration identifies the list and stats entries that were current
+// when a lookup ran. A page loaded after a missed lookup is stored under
+// it, so a page read from the database while the lists were invalidated is
+// filed under the old generation and never served. The zero value is the
+// first generation.
+type ListGeneration struct {
+	// shared is the generation in Redis and local the one kept in process
+	shared int64
+	local  int64
+}
+
+// BookKey returns the key a book is cached under, as accepted by DeleteKey
+func BookKey(id uint) string {
+	return fmt.Sprintf("%s%d", bookKeyPrefix, id)
+}
+
+// BookEntry is a cached book lookup. A nil Book is a negative entry
+// recording that no book has the ID.
+type BookEntry struct {
+	Book *models.Book `json:"book,omitempty"`
+	// LoadTime is how long loading the book took
+	LoadTime  time.Duration `json:"load_time"`
+	ExpiresAt time.Time     `json:"expires_at"`
+}
+
+// ShouldRefresh decides whether to reload the book before the entry
+// expires. The chance grows as expiry nears and with how slow the book was
+// to load, so a hot entry is usually refreshed by a single request ahead of
+// time instead of by every request at once when it expires. Beta scales
+// how early refreshes happen; zero disables them.
+func (e *BookEntry) ShouldRefresh(now time.Time, beta float64) bool {
+	if e.Book == nil || beta <= 0 {
+		return false
+	}
+	early := time.Duration(float64(e.LoadTime) * beta * -math.Log(rand.Float64()))
+	return !now.Add(early).Before(e.ExpiresAt)
+}
+
+// decodeBookEntry reads a cached book entry with decode. Entries without
+// an expiry, such as books cached before entries were introduced, count as
+// a miss.
+func decodeBookEntry(data []byte, decode func(data []byte, v interface{}) error) (*BookEntry, error) {
+	var entry BookEntry
+	if err := decode(data, &entry); err != nil {
+		return nil, err
+	}
+	if entry.ExpiresAt.IsZero() {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+// newLockToken returns a random token identifying one holder of a lock
+func newLockToken() string {
+	return strconv.FormatUint(rand.Uint64(), 36)
+}
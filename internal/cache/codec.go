@@ -0,0 +1,231 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ugorji/go/codec"
+)
+
+// Codec turns cached values into bytes and back
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// msgpackCodec encodes MessagePack. Struct fields keep the names and
+// omissions of their json tags.
+type msgpackCodec struct {
+	handle *codec.MsgpackHandle
+}
+
+func newMsgpackCodec() *msgpackCodec {
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	handle.TypeInfos = codec.NewTypeInfos([]string{"json"})
+	return &msgpackCodec{handle: handle}
+}
+
+func (c *msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var data []byte
+	err := codec.NewEncoderBytes(&data, c.handle).Encode(v)
+	return data, err
+}
+
+func (c *msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return codec.NewDecoderBytes(data, c.handle).Decode(v)
+}
+
+// Compressor shrinks encoded values
+type Compressor interface {
+	Compress(data []byte) []byte
+	Decompress(data []byte) ([]byte, error)
+}
+
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdCompressor() (*zstdCompressor, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdCompressor{encoder: encoder, decoder: decoder}, nil
+}
+
+func (c *zstdCompressor) Compress(data []byte) []byte {
+	return c.encoder.EncodeAll(data, nil)
+}
+
+func (c *zstdCompressor) Decompress(data []byte) ([]byte, error) {
+	return c.decoder.DecodeAll(data, nil)
+}
+
+type snappyCompressor struct{}
+
+func (snappyCompressor) Compress(data []byte) []byte {
+	return snappy.Encode(nil, data)
+}
+
+func (snappyCompressor) Decompress(data []byte) ([]byte, error) {
+	return snappy.Decode(nil, data)
+}
+
+// The format byte in front of every serialized value names the codec in
+// bits 2-3 and the compression in bits 0-1. Bit 4 marks a value that
+// carries a version. Format bytes stay below 0x20, so they are never
+// mistaken for the JSON written before values had one, which always starts
+// with a printable character.
+const (
+	codecJSON     byte = 1 << 2
+	codecMsgpack  byte = 2 << 2
+	codecProtobuf byte = 3 << 2
+	codecMask     byte = 3 << 2
+
+	compressNone   byte = 0
+	compressZstd   byte = 1
+	compressSnappy byte = 2
+	compressMask   byte = 3
+
+	formatVersioned byte = 1 << 4
+)
+
+// serializer frames cached values as a format byte, an optional decimal
+// version followed by ':', and the encoded, possibly compressed, payload.
+// Values are written with the configured codec and compression but read
+// with whichever the format byte names, so changing either takes effect
+// without flushing the cache, as long as every instance can read both.
+type serializer struct {
+	codec       byte
+	compression byte
+	threshold   int
+
+	codecs      map[byte]Codec
+	compressors map[byte]Compressor
+}
+
+func newSerializer(cfg config.CacheConfig) (*serializer, error) {
+	zstdCompressor, err := newZstdCompressor()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &serializer{
+		threshold: cfg.CompressionThreshold,
+		codecs: map[byte]Codec{
+			codecJSON:     jsonCodec{},
+			codecMsgpack:  newMsgpackCodec(),
+			codecProtobuf: protobufCodec{},
+		},
+		compressors: map[byte]Compressor{
+			compressZstd:   zstdCompressor,
+			compressSnappy: snappyCompressor{},
+		},
+	}
+
+	switch cfg.Codec {
+	case "json":
+		s.codec = codecJSON
+	case "msgpack":
+		s.codec = codecMsgpack
+	case "protobuf":
+		s.codec = codecProtobuf
+	default:
+		return nil, fmt.Errorf("unknown cache codec %q", cfg.Codec)
+	}
+
+	switch cfg.Compression {
+	case "none":
+		s.compression = compressNone
+	case "zstd":
+		s.compression = compressZstd
+	case "snappy":
+		s.compression = compressSnappy
+	default:
+		return nil, fmt.Errorf("unknown cache compression %q", cfg.Compression)
+	}
+
+	return s, nil
+}
+
+func (s *serializer) encode(v interface{}) ([]byte, error) {
+	return s.encodeVersioned(v, 0)
+}
+
+// encodeVersioned records version in plain text ahead of the payload, where
+// a Redis script can read it without decoding the value. Versions below one
+// are left out.
+func (s *serializer) encodeVersioned(v interface{}, version int64) ([]byte, error) {
+	payload, err := s.codecs[s.codec].Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	format := s.codec
+	if s.compression != compressNone && len(payload) >= s.threshold {
+		payload = s.compressors[s.compression].Compress(payload)
+		format |= s.compression
+	}
+
+	data := make([]byte, 0, len(payload)+21)
+	if version > 0 {
+		data = append(data, format|formatVersioned)
+		data = strconv.AppendInt(data, version, 10)
+		data = append(data, ':')
+	} else {
+		data = append(data, format)
+	}
+	return append(data, payload...), nil
+}
+
+func (s *serializer) decode(data []byte, v interface{}) error {
+	if len(data) == 0 || data[0] >= 0x20 {
+		return json.Unmarshal(data, v)
+	}
+
+	format, payload := data[0], data[1:]
+	if format&formatVersioned != 0 {
+		end := bytes.IndexByte(payload, ':')
+		if end < 0 {
+			return fmt.Errorf("cached value has no end to its version")
+		}
+		payload = payload[end+1:]
+	}
+
+	if compression := format & compressMask; compression != compressNone {
+		compressor, ok := s.compressors[compression]
+		if !ok {
+			return fmt.Errorf("unknown cache compression %d", compression)
+		}
+		var err error
+		if payload, err = compressor.Decompress(payload); err != nil {
+			return err
+		}
+	}
+
+	c, ok := s.codecs[format&codecMask]
+	if !ok {
+		return fmt.Errorf("unknown cache codec %d", (format&codecMask)>>2)
+	}
+	return c.Unmarshal(payload, v)
+}
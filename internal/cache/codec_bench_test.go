@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+var (
+	benchCodecs       = []string{"json", "msgpack", "protobuf"}
+	benchCompressions = []string{"none", "zstd", "snappy"}
+)
+
+// BenchmarkEncodeBookList measures serializing a 100-book listing page with
+// each codec and compression, reporting the encoded size alongside
+func BenchmarkEncodeBookList(b *testing.B) {
+	list := benchBookList(100)
+
+	for _, codec := range benchCodecs {
+		for _, compression := range benchCompressions {
+			b.Run(codec+"/"+compression, func(b *testing.B) {
+				s := newBenchSerializer(b, codec, compression)
+
+				var data []byte
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					var err error
+					if data, err = s.encode(list); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(len(data)), "bytes")
+			})
+		}
+	}
+}
+
+// BenchmarkDecodeBookList measures reading back the page encoded by
+// BenchmarkEncodeBookList
+func BenchmarkDecodeBookList(b *testing.B) {
+	list := benchBookList(100)
+
+	for _, codec := range benchCodecs {
+		for _, compression := range benchCompressions {
+			b.Run(codec+"/"+compression, func(b *testing.B) {
+				s := newBenchSerializer(b, codec, compression)
+				data, err := s.encode(list)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					var decoded models.BookList
+					if err := s.decode(data, &decoded); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// newBenchSerializer compresses every value regardless of its size
+func newBenchSerializer(b *testing.B, codec, compression string) *serializer {
+	b.Helper()
+
+	s, err := newSerializer(config.CacheConfig{Codec: codec, Compression: compression})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return s
+}
+
+// benchBookList builds a listing page of n books filled in the way the
+// repository loads them, with their publisher, authors, genres and tags
+func benchBookList(n int) *models.BookList {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	publisher := &models.Publisher{ID: 7, Name: "Penguin Books", Slug: "penguin-books", CreatedAt: created, UpdatedAt: created}
+	genres := []models.Genre{
+		{ID: 1, Name: "Fiction", Slug: "fiction", CreatedAt: created, UpdatedAt: created},
+		{ID: 4, Name: "Science Fiction", Slug: "science-fiction", ParentID: uintPtr(1), CreatedAt: created, UpdatedAt: created},
+	}
+	tags := []models.Tag{
+		{ID: 3, Name: "Classic", Slug: "classic", CreatedAt: created, UpdatedAt: created},
+		{ID: 9, Name: "Award Winner", Slug: "award-winner", CreatedAt: created, UpdatedAt: created},
+	}
+
+	list := &models.BookList{
+		Books: make([]models.Book, n),
+		Total: int64(n * 12),
+		Facets: &models.BookFacets{
+			Genres:  []models.FacetCount{{Value: "fiction", Name: "Fiction", Count: 840}, {Value: "science-fiction", Name: "Science Fiction", Count: 212}},
+			Tags:    []models.FacetCount{{Value: "classic", Name: "Classic", Count: 97}, {Value: "award-winner", Name: "Award Winner", Count: 41}},
+			Decades: []models.FacetCount{{Value: "1960", Count: 120}, {Value: "1970", Count: 181}, {Value: "1980", Count: 233}},
+		},
+	}
+	for i := range list.Books {
+		id := uint(i + 1)
+		isbn13 := fmt.Sprintf("978%010d", 140000000+i)
+		pageCount := 200 + i
+		publishedOn := created.AddDate(-i, 0, 0)
+		author := &models.Author{ID: id, Name: fmt.Sprintf("Author Number %d", i), CreatedAt: created, UpdatedAt: created}
+
+		list.Books[i] = models.Book{
+			ID:            id,
+			WorkID:        uintPtr(id),
+			Title:         fmt.Sprintf("The Collected Stories, Volume %d", i),
+			Author:        author.Name,
+			Year:          1960 + i%60,
+			ISBN13:        &isbn13,
+			PublisherID:   uintPtr(publisher.ID),
+			Format:        models.FormatPaperback,
+			Language:      "en",
+			PageCount:     &pageCount,
+			PublishedOn:   &publishedOn,
+			RatingCount:   i * 3,
+			AverageRating: 3.5 + float64(i%15)/10,
+			Version:       int64(i%4 + 1),
+			CreatedAt:     created,
+			UpdatedAt:     created.Add(time.Duration(i) * time.Hour),
+			Publisher:     publisher,
+			Authors:       []models.BookAuthor{{BookID: id, AuthorID: id, Role: models.AuthorRoleAuthor, Author: author}},
+			Genres:        genres,
+			Tags:          tags,
+		}
+	}
+	return list
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
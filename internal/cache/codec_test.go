@@ -0,0 +1,189 @@
+package cache
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func TestSerializerRoundTrip(t *testing.T) {
+	codecs := map[string]byte{"json": codecJSON, "msgpack": codecMsgpack, "protobuf": codecProtobuf}
+	compressions := map[string]byte{"none": compressNone, "zstd": compressZstd, "snappy": compressSnappy}
+
+	list := benchBookList(3)
+	entry := &BookEntry{
+		Book:      &list.Books[0],
+		LoadTime:  12 * time.Millisecond,
+		ExpiresAt: time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		version int64
+		decoded func() interface{}
+	}{
+		{name: "book entry", value: entry, version: 4, decoded: func() interface{} { return &BookEntry{} }},
+		{name: "missing book entry", value: &BookEntry{ExpiresAt: entry.ExpiresAt}, decoded: func() interface{} { return &BookEntry{} }},
+		{name: "book list", value: list, decoded: func() interface{} { return &models.BookList{} }},
+	}
+
+	for _, codec := range benchCodecs {
+		for _, compression := range benchCompressions {
+			for _, tt := range tests {
+				t.Run(codec+"/"+compression+"/"+tt.name, func(t *testing.T) {
+					s, err := newSerializer(config.CacheConfig{Codec: codec, Compression: compression})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					data, err := s.encodeVersioned(tt.value, tt.version)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					format := data[0]
+					if got := format & codecMask; got != codecs[codec] {
+						t.Errorf("codec bits = %#x, want %#x", got, codecs[codec])
+					}
+					if got := format & compressMask; got != compressions[compression] {
+						t.Errorf("compression bits = %#x, want %#x", got, compressions[compression])
+					}
+					if versioned := format&formatVersioned != 0; versioned != (tt.version > 0) {
+						t.Errorf("versioned = %v with version %d", versioned, tt.version)
+					}
+					if format >= 0x20 {
+						t.Errorf("format byte %#x could be mistaken for JSON", format)
+					}
+
+					decoded := tt.decoded()
+					if err := s.decode(data, decoded); err != nil {
+						t.Fatal(err)
+					}
+					if !reflect.DeepEqual(decoded, tt.value) {
+						t.Errorf("decoded %+v, want %+v", decoded, tt.value)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSerializerVersionFraming(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int64
+		prefix  string
+	}{
+		{name: "no version", version: 0, prefix: ""},
+		{name: "negative version", version: -3, prefix: ""},
+		{name: "version", version: 7, prefix: "7:"},
+		{name: "large version", version: 1234567890123, prefix: "1234567890123:"},
+	}
+
+	s, err := newSerializer(config.CacheConfig{Codec: "json", Compression: "none"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := s.encodeVersioned(map[string]int{"id": 1}, tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []byte{codecJSON}
+			if tt.prefix != "" {
+				want[0] |= formatVersioned
+			}
+			want = append(want, tt.prefix+`{"id":1}`...)
+			if !bytes.Equal(data, want) {
+				t.Errorf("encoded %q, want %q", data, want)
+			}
+		})
+	}
+}
+
+func TestSerializerCompressionThreshold(t *testing.T) {
+	tests := []struct {
+		name       string
+		threshold  int
+		compressed bool
+	}{
+		{name: "below threshold", threshold: 1 << 20, compressed: false},
+		{name: "at threshold", threshold: len(`{"id":1}`), compressed: true},
+		{name: "no threshold", threshold: 0, compressed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSerializer(config.CacheConfig{Codec: "json", Compression: "zstd", CompressionThreshold: tt.threshold})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := s.encode(map[string]int{"id": 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if compressed := data[0]&compressMask == compressZstd; compressed != tt.compressed {
+				t.Errorf("compressed = %v, want %v", compressed, tt.compressed)
+			}
+
+			var decoded map[string]int
+			if err := s.decode(data, &decoded); err != nil || decoded["id"] != 1 {
+				t.Errorf("decoded %v, %v", decoded, err)
+			}
+		})
+	}
+}
+
+func TestSerializerDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    int
+		wantErr bool
+	}{
+		{name: "legacy json", data: []byte(`{"id":1}`), want: 1},
+		{name: "legacy json with leading space", data: []byte(` {"id":2}`), want: 2},
+		{name: "json", data: append([]byte{codecJSON}, `{"id":3}`...), want: 3},
+		{name: "versioned json", data: append([]byte{codecJSON | formatVersioned}, `12:{"id":4}`...), want: 4},
+		{name: "version without end", data: append([]byte{codecJSON | formatVersioned}, `12`...), wantErr: true},
+		{name: "unknown codec", data: append([]byte{0}, `{"id":5}`...), wantErr: true},
+		{name: "unknown compression", data: append([]byte{codecJSON | compressMask}, `{"id":6}`...), wantErr: true},
+		{name: "corrupt compressed payload", data: append([]byte{codecJSON | compressZstd}, `{"id":7}`...), wantErr: true},
+		{name: "empty", data: nil, wantErr: true},
+	}
+
+	s, err := newSerializer(config.CacheConfig{Codec: "msgpack", Compression: "snappy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded struct {
+				ID int `json:"id"`
+			}
+			err := s.decode(tt.data, &decoded)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decoded %+v, want an error", decoded)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded.ID != tt.want {
+				t.Errorf("decoded id %d, want %d", decoded.ID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+// firstReconnectDelay is the wait before the first attempt to reconnect;
+// it doubles after each failure up to CACHE_RECONNECT_INTERVAL
+const firstReconnectDelay = time.Second
+
+// unavailableError reports that the cache backend could not be reached, as
+// opposed to being misconfigured
+type unavailableError struct {
+	err error
+}
+
+func (e *unavailableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *unavailableError) Unwrap() error {
+	return e.err
+}
+
+// FallbackCache lets the API start while Redis is down. Until the cache
+// selected by CACHE_DRIVER can be reached it behaves like NoopCache, and it
+// keeps trying to connect in the background, switching over once it
+// succeeds.
+type FallbackCache struct {
+	current   atomic.Pointer[Cache]
+	connected atomic.Bool
+	stop      chan struct{}
+}
+
+// NewFallbackCache connects to the configured cache, falling back to
+// NoopCache if it cannot be reached. Configuration errors are returned.
+func NewFallbackCache(cfg *config.Config) (*FallbackCache, error) {
+	c := &FallbackCache{stop: make(chan struct{})}
+
+	next, err := NewCache(cfg)
+	if err == nil {
+		c.use(next)
+		return c, nil
+	}
+
+	var unavailable *unavailableError
+	if !errors.As(err, &unavailable) {
+		return nil, err
+	}
+
+	log.Printf("Cache unavailable, serving without it: %v\n", err)
+	var noop Cache = NoopCache{}
+	c.current.Store(&noop)
+	go c.reconnect(cfg)
+
+	return c, nil
+}
+
+// Connected reports whether the configured cache is in use
+func (c *FallbackCache) Connected() bool {
+	return c.connected.Load()
+}
+
+// Unwrap returns the cache in use
+func (c *FallbackCache) Unwrap() Cache {
+	return *c.current.Load()
+}
+
+func (c *FallbackCache) use(next Cache) {
+	c.current.Store(&next)
+	c.connected.Store(true)
+}
+
+func (c *FallbackCache) reconnect(cfg *config.Config) {
+	delay := firstReconnectDelay
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-time.After(delay):
+		}
+
+		next, err := NewCache(cfg)
+		if err == nil {
+			select {
+			case <-c.stop:
+				next.Close()
+				return
+			default:
+			}
+			c.use(next)
+			log.Println("Cache connected")
+			return
+		}
+
+		log.Printf("Failed to reconnect to cache: %v\n", err)
+		if delay *= 2; delay > cfg.Cache.ReconnectInterval {
+			delay = cfg.Cache.ReconnectInterval
+		}
+	}
+}
+
+func (c *FallbackCache) GetBook(ctx context.Context, id uint) (*BookEntry, error) {
+	return c.Unwrap().GetBook(ctx, id)
+}
+
+func (c *FallbackCache) SetBook(ctx context.Context, book *models.Book, loadTime time.Duration) error {
+	return c.Unwrap().SetBook(ctx, book, loadTime)
+}
+
+func (c *FallbackCache) SetBookMissing(ctx context.Context, id uint) error {
+	return c.Unwrap().SetBookMissing(ctx, id)
+}
+
+func (c *FallbackCache) DeleteBook(ctx context.Context, id uint) error {
+	return c.Unwrap().DeleteBook(ctx, id)
+}
+
+func (c *FallbackCache) GetBookIDByISBN(ctx context.Context, isbn string) (uint, error) {
+	return c.Unwrap().GetBookIDByISBN(ctx, isbn)
+}
+
+func (c *FallbackCache) SetBookISBN(ctx context.Context, isbn string, id uint) error {
+	return c.Unwrap().SetBookISBN(ctx, isbn, id)
+}
+
+func (c *FallbackCache) GetBooksList(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, ListGeneration, error) {
+	return c.Unwrap().GetBooksList(ctx, filter, page, pageSize)
+}
+
+func (c *FallbackCache) SetBooksList(ctx context.Context, generation ListGeneration, filter models.BookFilter, page, pageSize int, list *models.BookList) error {
+	return c.Unwrap().SetBooksList(ctx, generation, filter, page, pageSize, list)
+}
+
+func (c *FallbackCache) InvalidateBooksList(ctx context.Context) error {
+	return c.Unwrap().InvalidateBooksList(ctx)
+}
+
+func (c *FallbackCache) InvalidateBookPages(ctx context.Context, bookID uint) error {
+	return c.Unwrap().InvalidateBookPages(ctx, bookID)
+}
+
+func (c *FallbackCache) GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, ListGeneration, error) {
+	return c.Unwrap().GetStats(ctx, filter)
+}
+
+func (c *FallbackCache) SetStats(ctx context.Context, generation ListGeneration, filter models.StatsFilter, stats *models.CatalogueStats) error {
+	return c.Unwrap().SetStats(ctx, generation, filter, stats)
+}
+
+func (c *FallbackCache) GetAvailableCopies(ctx context.Context, bookIDs []uint) (map[uint]int, error) {
+	return c.Unwrap().GetAvailableCopies(ctx, bookIDs)
+}
+
+func (c *FallbackCache) SetAvailableCopies(ctx context.Context, counts map[uint]int) error {
+	return c.Unwrap().SetAvailableCopies(ctx, counts)
+}
+
+func (c *FallbackCache) DeleteAvailableCopies(ctx context.Context, bookID uint) error {
+	return c.Unwrap().DeleteAvailableCopies(ctx, bookID)
+}
+
+func (c *FallbackCache) TryLock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return c.Unwrap().TryLock(ctx, key, ttl)
+}
+
+func (c *FallbackCache) Unlock(ctx context.Context, key, token string) error {
+	return c.Unwrap().Unlock(ctx, key, token)
+}
+
+func (c *FallbackCache) RemoveStaleLists(ctx context.Context) (int, error) {
+	return c.Unwrap().RemoveStaleLists(ctx)
+}
+
+func (c *FallbackCache) InspectBook(ctx context.Context, id uint) (*BookEntry, time.Duration, error) {
+	return c.Unwrap().InspectBook(ctx, id)
+}
+
+func (c *FallbackCache) DeleteKey(ctx context.Context, key string) error {
+	return c.Unwrap().DeleteKey(ctx, key)
+}
+
+func (c *FallbackCache) Clear(ctx context.Context) error {
+	return c.Unwrap().Clear(ctx)
+}
+
+// Close stops reconnecting and closes the cache in use
+func (c *FallbackCache) Close() error {
+	close(c.stop)
+	return c.Unwrap().Close()
+}
@@ -0,0 +1,346 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/breaker"
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+// maxDroppedBooks bounds how many books with skipped invalidations are
+// remembered while the breaker is open
+const maxDroppedBooks = 10000
+
+var errNotConnected = errors.New("not connected, retrying in the background")
+
+// skippedKey is the context key under which a caller of a lookup asks to
+// be told that the breaker skipped it
+type skippedKey struct{}
+
+// trackSkipped returns a context for a lookup and the flag GuardedCache sets
+// when it answers the lookup without calling the cache
+func trackSkipped(ctx context.Context) (context.Context, *bool) {
+	skipped := new(bool)
+	return context.WithValue(ctx, skippedKey{}, skipped), skipped
+}
+
+func markSkipped(ctx context.Context) {
+	if skipped, ok := ctx.Value(skippedKey{}).(*bool); ok {
+		*skipped = true
+	}
+}
+
+// GuardedCache bounds how long a request waits on the cache it wraps and
+// stops calling it after repeated failures. While its circuit breaker is
+// open it behaves like NoopCache, so a slow or unreachable Redis costs
+// requests nothing beyond the trip to the database. Invalidations skipped
+// in that time are replayed once the cache recovers, so entries written
+// before the outage do not outlive the changes made during it.
+type GuardedCache struct {
+	next    Cache
+	breaker *breaker.Breaker
+	timeout time.Duration
+
+	mu           sync.Mutex
+	droppedBooks map[uint]struct{}
+	droppedLists bool
+}
+
+func NewGuardedCache(next Cache, cfg config.CacheConfig) *GuardedCache {
+	c := &GuardedCache{
+		next:         next,
+		breaker:      breaker.New("cache", cfg.BreakerFailures, cfg.BreakerCooldown),
+		timeout:      cfg.Timeout,
+		droppedBooks: make(map[uint]struct{}),
+	}
+	c.breaker.OnStateChange(func(from, to breaker.State) {
+		if to == breaker.Closed {
+			c.replay()
+		}
+	})
+	return c
+}
+
+// Check reports whether the cache is connected and in use, for health
+// checks. It does not call the cache itself.
+func (c *GuardedCache) Check(ctx context.Context) error {
+	if fallback, ok := c.next.(*FallbackCache); ok && !fallback.Connected() {
+		return errNotConnected
+	}
+	if state := c.breaker.State(); state != breaker.Closed {
+		return fmt.Errorf("circuit breaker is %s", state)
+	}
+	return nil
+}
+
+// Unwrap returns the wrapped cache
+func (c *GuardedCache) Unwrap() Cache {
+	return c.next
+}
+
+// call runs fn against the wrapped cache within the timeout, unless the
+// breaker is open. Calls cancelled by the caller do not count as failures.
+func (c *GuardedCache) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !c.breaker.Allow() {
+		return breaker.ErrOpen
+	}
+
+	callCtx := ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	err := fn(callCtx)
+	switch {
+	case err == nil:
+		c.breaker.Success()
+	case ctx.Err() != nil:
+		c.breaker.Ignore()
+	default:
+		c.breaker.Failure()
+	}
+	return err
+}
+
+// invalidate runs an invalidation, remembering the book it concerns if the
+// cache cannot be reached
+func (c *GuardedCache) invalidate(ctx context.Context, bookID uint, fn func(ctx context.Context) error) error {
+	err := c.call(ctx, fn)
+	if err == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	if bookID == 0 {
+		c.droppedLists = true
+	} else if len(c.droppedBooks) < maxDroppedBooks {
+		c.droppedBooks[bookID] = struct{}{}
+	} else {
+		log.Printf("Too many skipped cache invalidations, book %d may be stale until it expires\n", bookID)
+	}
+	c.mu.Unlock()
+
+	if err == breaker.ErrOpen {
+		return nil
+	}
+	return err
+}
+
+// replay applies the invalidations skipped while the cache was unreachable
+func (c *GuardedCache) replay() {
+	c.mu.Lock()
+	books, lists := c.droppedBooks, c.droppedLists
+	c.droppedBooks, c.droppedLists = make(map[uint]struct{}), false
+	c.mu.Unlock()
+
+	if len(books) == 0 && !lists {
+		return
+	}
+
+	ctx := context.Background()
+	for id := range books {
+		if err := c.DeleteBook(ctx, id); err != nil {
+			log.Printf("Failed to replay book cache invalidation: %v\n", err)
+		}
+		if err := c.DeleteAvailableCopies(ctx, id); err != nil {
+			log.Printf("Failed to replay available copies invalidation: %v\n", err)
+		}
+		if err := c.InvalidateBookPages(ctx, id); err != nil {
+			log.Printf("Failed to replay books list invalidation: %v\n", err)
+		}
+	}
+	if lists {
+		if err := c.InvalidateBooksList(ctx); err != nil {
+			log.Printf("Failed to replay books list invalidation: %v\n", err)
+		}
+	}
+	log.Printf("Replayed cache invalidations for %d books\n", len(books))
+}
+
+func (c *GuardedCache) GetBook(ctx context.Context, id uint) (*BookEntry, error) {
+	var entry *BookEntry
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		entry, err = c.next.GetBook(ctx, id)
+		return err
+	})
+	if err == breaker.ErrOpen {
+		markSkipped(ctx)
+		return nil, nil
+	}
+	return entry, err
+}
+
+func (c *GuardedCache) SetBook(ctx context.Context, book *models.Book, loadTime time.Duration) error {
+	return c.invalidate(ctx, book.ID, func(ctx context.Context) error {
+		return c.next.SetBook(ctx, book, loadTime)
+	})
+}
+
+func (c *GuardedCache) SetBookMissing(ctx context.Context, id uint) error {
+	return ignoreOpen(c.call(ctx, func(ctx context.Context) error {
+		return c.next.SetBookMissing(ctx, id)
+	}))
+}
+
+func (c *GuardedCache) DeleteBook(ctx context.Context, id uint) error {
+	return c.invalidate(ctx, id, func(ctx context.Context) error {
+		return c.next.DeleteBook(ctx, id)
+	})
+}
+
+func (c *GuardedCache) GetBookIDByISBN(ctx context.Context, isbn string) (uint, error) {
+	var id uint
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		id, err = c.next.GetBookIDByISBN(ctx, isbn)
+		return err
+	})
+	if err == breaker.ErrOpen {
+		markSkipped(ctx)
+		return 0, nil
+	}
+	return id, err
+}
+
+func (c *GuardedCache) SetBookISBN(ctx context.Context, isbn string, id uint) error {
+	return ignoreOpen(c.call(ctx, func(ctx context.Context) error {
+		return c.next.SetBookISBN(ctx, isbn, id)
+	}))
+}
+
+func (c *GuardedCache) GetBooksList(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, ListGeneration, error) {
+	var list *models.BookList
+	var generation ListGeneration
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		list, generation, err = c.next.GetBooksList(ctx, filter, page, pageSize)
+		return err
+	})
+	if err == breaker.ErrOpen {
+		markSkipped(ctx)
+		return nil, generation, nil
+	}
+	return list, generation, err
+}
+
+func (c *GuardedCache) SetBooksList(ctx context.Context, generation ListGeneration, filter models.BookFilter, page, pageSize int, list *models.BookList) error {
+	return ignoreOpen(c.call(ctx, func(ctx context.Context) error {
+		return c.next.SetBooksList(ctx, generation, filter, page, pageSize, list)
+	}))
+}
+
+func (c *GuardedCache) InvalidateBooksList(ctx context.Context) error {
+	return c.invalidate(ctx, 0, func(ctx context.Context) error {
+		return c.next.InvalidateBooksList(ctx)
+	})
+}
+
+func (c *GuardedCache) InvalidateBookPages(ctx context.Context, bookID uint) error {
+	return c.invalidate(ctx, bookID, func(ctx context.Context) error {
+		return c.next.InvalidateBookPages(ctx, bookID)
+	})
+}
+
+func (c *GuardedCache) GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, ListGeneration, error) {
+	var stats *models.CatalogueStats
+	var generation ListGeneration
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		stats, generation, err = c.next.GetStats(ctx, filter)
+		return err
+	})
+	if err == breaker.ErrOpen {
+		markSkipped(ctx)
+		return nil, generation, nil
+	}
+	return stats, generation, err
+}
+
+func (c *GuardedCache) SetStats(ctx context.Context, generation ListGeneration, filter models.StatsFilter, stats *models.CatalogueStats) error {
+	return ignoreOpen(c.call(ctx, func(ctx context.Context) error {
+		return c.next.SetStats(ctx, generation, filter, stats)
+	}))
+}
+
+func (c *GuardedCache) GetAvailableCopies(ctx context.Context, bookIDs []uint) (map[uint]int, error) {
+	var counts map[uint]int
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		counts, err = c.next.GetAvailableCopies(ctx, bookIDs)
+		return err
+	})
+	if err == breaker.ErrOpen {
+		markSkipped(ctx)
+		return map[uint]int{}, nil
+	}
+	return counts, err
+}
+
+func (c *GuardedCache) SetAvailableCopies(ctx context.Context, counts map[uint]int) error {
+	return ignoreOpen(c.call(ctx, func(ctx context.Context) error {
+		return c.next.SetAvailableCopies(ctx, counts)
+	}))
+}
+
+func (c *GuardedCache) DeleteAvailableCopies(ctx context.Context, bookID uint) error {
+	return c.invalidate(ctx, bookID, func(ctx context.Context) error {
+		return c.next.DeleteAvailableCopies(ctx, bookID)
+	})
+}
+
+// TryLock hands out a lock while the breaker is open, as NoopCache does, so
+// callers load entries themselves instead of waiting on the cache
+func (c *GuardedCache) TryLock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	var token string
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		token, err = c.next.TryLock(ctx, key, ttl)
+		return err
+	})
+	if err == breaker.ErrOpen {
+		return NoopCache{}.TryLock(ctx, key, ttl)
+	}
+	return token, err
+}
+
+func (c *GuardedCache) Unlock(ctx context.Context, key, token string) error {
+	return ignoreOpen(c.call(ctx, func(ctx context.Context) error {
+		return c.next.Unlock(ctx, key, token)
+	}))
+}
+
+// RemoveStaleLists runs without the call timeout since the sweep is a
+// background job that may take a while
+func (c *GuardedCache) RemoveStaleLists(ctx context.Context) (int, error) {
+	if c.breaker.State() != breaker.Closed {
+		return 0, breaker.ErrOpen
+	}
+	return c.next.RemoveStaleLists(ctx)
+}
+
+func (c *GuardedCache) InspectBook(ctx context.Context, id uint) (*BookEntry, time.Duration, error) {
+	return c.next.InspectBook(ctx, id)
+}
+
+func (c *GuardedCache) DeleteKey(ctx context.Context, key string) error {
+	return c.next.DeleteKey(ctx, key)
+}
+
+func (c *GuardedCache) Clear(ctx context.Context) error {
+	return c.next.Clear(ctx)
+}
+
+func (c *GuardedCache) Close() error {
+	return c.next.Close()
+}
+
+// ignoreOpen drops the error of a write skipped because the breaker is open
+func ignoreOpen(err error) error {
+	if err == breaker.ErrOpen {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,242 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	idempotencyKeyPrefix     = "idempotency:"
+	idempotencyLockKeyPrefix = "idempotency:lock:"
+)
+
+type IdempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+type IdempotencyStore interface {
+	// Get returns the stored response for key, or nil if none is stored
+	Get(ctx context.Context, key string) (*IdempotencyRecord, error)
+	Save(ctx context.Context, key string, record *IdempotencyRecord) error
+
+	// Lock marks key as in flight and returns a token for Unlock, or an
+	// empty token if another request holds it
+	Lock(ctx context.Context, key string) (string, error)
+	// Unlock releases key only if it is still held with token, so a request
+	// whose lock expired cannot release another request's
+	Unlock(ctx context.Context, key, token string) error
+}
+
+type RedisIdempotencyStore struct {
+	client  redis.UniversalClient
+	ttl     time.Duration
+	lockTTL time.Duration
+}
+
+// NewIdempotencyStore returns a store kept alongside c: in Redis for caches
+// backed by Redis and in process otherwise
+func NewIdempotencyStore(c Cache, cfg *config.Config) IdempotencyStore {
+	switch c := c.(type) {
+	case *RedisCache:
+		return newRedisIdempotencyStore(c.client, cfg)
+	case *TieredCache:
+		return newRedisIdempotencyStore(c.remote.client, cfg)
+	case *InstrumentedCache:
+		return NewIdempotencyStore(c.next, cfg)
+	case *GuardedCache:
+		return &guardedIdempotencyStore{cache: c, next: NewIdempotencyStore(c.next, cfg)}
+	case *FallbackCache:
+		if c.Connected() {
+			return NewIdempotencyStore(c.Unwrap(), cfg)
+		}
+		return &fallbackIdempotencyStore{cache: c, cfg: cfg, memory: NewMemoryIdempotencyStore(cfg)}
+	default:
+		return NewMemoryIdempotencyStore(cfg)
+	}
+}
+
+func newRedisIdempotencyStore(client redis.UniversalClient, cfg *config.Config) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{
+		client:  client,
+		ttl:     cfg.Idempotency.TTL,
+		lockTTL: cfg.Idempotency.LockTTL,
+	}
+}
+
+func (s *RedisIdempotencyStore) Get(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	data, err := s.client.Get(ctx, idempotencyKeyPrefix+key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record IdempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (s *RedisIdempotencyStore) Save(ctx context.Context, key string, record *IdempotencyRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, idempotencyKeyPrefix+key, data, s.ttl).Err()
+}
+
+func (s *RedisIdempotencyStore) Lock(ctx context.Context, key string) (string, error) {
+	token := newLockToken()
+	ok, err := s.client.SetNX(ctx, idempotencyLockKeyPrefix+key, token, s.lockTTL).Result()
+	if err != nil || !ok {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *RedisIdempotencyStore) Unlock(ctx context.Context, key, token string) error {
+	return unlockScript.Run(ctx, s.client, []string{idempotencyLockKeyPrefix + key}, token).Err()
+}
+
+// MemoryIdempotencyStore keeps idempotency records in process. Keys are only
+// honoured by the instance that saw the original request.
+type MemoryIdempotencyStore struct {
+	entries *lru
+	ttl     time.Duration
+	lockTTL time.Duration
+}
+
+func NewMemoryIdempotencyStore(cfg *config.Config) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		entries: newLRU(cfg.Cache.MaxBytes),
+		ttl:     cfg.Idempotency.TTL,
+		lockTTL: cfg.Idempotency.LockTTL,
+	}
+}
+
+func (s *MemoryIdempotencyStore) Get(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	data, ok := s.entries.get(idempotencyKeyPrefix + key)
+	if !ok {
+		return nil, nil
+	}
+
+	var record IdempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (s *MemoryIdempotencyStore) Save(ctx context.Context, key string, record *IdempotencyRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.entries.set(idempotencyKeyPrefix+key, data, s.ttl)
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Lock(ctx context.Context, key string) (string, error) {
+	token := newLockToken()
+	if !s.entries.setNX(idempotencyLockKeyPrefix+key, []byte(token), s.lockTTL) {
+		return "", nil
+	}
+	return token, nil
+}
+
+func (s *MemoryIdempotencyStore) Unlock(ctx context.Context, key, token string) error {
+	s.entries.deleteIfEqual(idempotencyLockKeyPrefix+key, []byte(token))
+	return nil
+}
+
+// guardedIdempotencyStore runs a store kept alongside a GuardedCache
+// through the cache's timeout and circuit breaker. While the breaker is
+// open every call fails, so requests run without idempotency.
+type guardedIdempotencyStore struct {
+	cache *GuardedCache
+	next  IdempotencyStore
+}
+
+func (s *guardedIdempotencyStore) Get(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	var record *IdempotencyRecord
+	err := s.cache.call(ctx, func(ctx context.Context) (err error) {
+		record, err = s.next.Get(ctx, key)
+		return err
+	})
+	return record, err
+}
+
+func (s *guardedIdempotencyStore) Save(ctx context.Context, key string, record *IdempotencyRecord) error {
+	return s.cache.call(ctx, func(ctx context.Context) error {
+		return s.next.Save(ctx, key, record)
+	})
+}
+
+func (s *guardedIdempotencyStore) Lock(ctx context.Context, key string) (string, error) {
+	var token string
+	err := s.cache.call(ctx, func(ctx context.Context) (err error) {
+		token, err = s.next.Lock(ctx, key)
+		return err
+	})
+	return token, err
+}
+
+func (s *guardedIdempotencyStore) Unlock(ctx context.Context, key, token string) error {
+	return s.cache.call(ctx, func(ctx context.Context) error {
+		return s.next.Unlock(ctx, key, token)
+	})
+}
+
+// fallbackIdempotencyStore keeps keys in process while the cache is
+// unreachable and moves to the cache's own store once it connects. Keys
+// saved before then are not carried over.
+type fallbackIdempotencyStore struct {
+	cache  *FallbackCache
+	cfg    *config.Config
+	memory IdempotencyStore
+
+	connected atomic.Pointer[IdempotencyStore]
+}
+
+func (s *fallbackIdempotencyStore) store() IdempotencyStore {
+	if store := s.connected.Load(); store != nil {
+		return *store
+	}
+	if !s.cache.Connected() {
+		return s.memory
+	}
+
+	store := NewIdempotencyStore(s.cache.Unwrap(), s.cfg)
+	s.connected.Store(&store)
+	return store
+}
+
+func (s *fallbackIdempotencyStore) Get(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	return s.store().Get(ctx, key)
+}
+
+func (s *fallbackIdempotencyStore) Save(ctx context.Context, key string, record *IdempotencyRecord) error {
+	return s.store().Save(ctx, key, record)
+}
+
+func (s *fallbackIdempotencyStore) Lock(ctx context.Context, key string) (string, error) {
+	return s.store().Lock(ctx, key)
+}
+
+func (s *fallbackIdempotencyStore) Unlock(ctx context.Context, key, token string) error {
+	return s.store().Unlock(ctx, key, token)
+}
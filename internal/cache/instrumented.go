@@ -0,0 +1,214 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+// InstrumentedCache records metrics for every operation of the cache it
+// wraps. The admin operations InspectBook and DeleteKey are passed through
+// unrecorded so inspecting the cache does not skew its hit ratio. Lookups a
+// GuardedCache below skips while its breaker is open are counted as
+// skipped, not as misses.
+type InstrumentedCache struct {
+	next    Cache
+	driver  string
+	metrics *Metrics
+}
+
+func NewInstrumentedCache(next Cache, driver string) *InstrumentedCache {
+	return &InstrumentedCache{
+		next:    next,
+		driver:  driver,
+		metrics: NewMetrics(),
+	}
+}
+
+// Stats is a snapshot of the metrics of an instrumented cache
+type Stats struct {
+	Driver     string
+	Operations []OperationStats
+}
+
+func (c *InstrumentedCache) Stats() Stats {
+	return Stats{
+		Driver:     c.driver,
+		Operations: c.metrics.Snapshot(),
+	}
+}
+
+// Unwrap returns the wrapped cache
+func (c *InstrumentedCache) Unwrap() Cache {
+	return c.next
+}
+
+func (c *InstrumentedCache) GetBook(ctx context.Context, id uint) (*BookEntry, error) {
+	start := time.Now()
+	ctx, skipped := trackSkipped(ctx)
+	entry, err := c.next.GetBook(ctx, id)
+	c.lookup("get_book", start, *skipped, found(entry != nil), found(entry == nil), err)
+	return entry, err
+}
+
+func (c *InstrumentedCache) SetBook(ctx context.Context, book *models.Book, loadTime time.Duration) error {
+	start := time.Now()
+	err := c.next.SetBook(ctx, book, loadTime)
+	c.metrics.observe("set_book", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) SetBookMissing(ctx context.Context, id uint) error {
+	start := time.Now()
+	err := c.next.SetBookMissing(ctx, id)
+	c.metrics.observe("set_book_missing", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) DeleteBook(ctx context.Context, id uint) error {
+	start := time.Now()
+	err := c.next.DeleteBook(ctx, id)
+	c.metrics.observe("delete_book", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) GetBookIDByISBN(ctx context.Context, isbn string) (uint, error) {
+	start := time.Now()
+	ctx, skipped := trackSkipped(ctx)
+	id, err := c.next.GetBookIDByISBN(ctx, isbn)
+	c.lookup("get_book_isbn", start, *skipped, found(id != 0), found(id == 0), err)
+	return id, err
+}
+
+func (c *InstrumentedCache) SetBookISBN(ctx context.Context, isbn string, id uint) error {
+	start := time.Now()
+	err := c.next.SetBookISBN(ctx, isbn, id)
+	c.metrics.observe("set_book_isbn", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) GetBooksList(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, ListGeneration, error) {
+	start := time.Now()
+	ctx, skipped := trackSkipped(ctx)
+	list, generation, err := c.next.GetBooksList(ctx, filter, page, pageSize)
+	c.lookup("get_books_list", start, *skipped, found(list != nil), found(list == nil), err)
+	return list, generation, err
+}
+
+func (c *InstrumentedCache) SetBooksList(ctx context.Context, generation ListGeneration, filter models.BookFilter, page, pageSize int, list *models.BookList) error {
+	start := time.Now()
+	err := c.next.SetBooksList(ctx, generation, filter, page, pageSize, list)
+	c.metrics.observe("set_books_list", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) InvalidateBooksList(ctx context.Context) error {
+	start := time.Now()
+	err := c.next.InvalidateBooksList(ctx)
+	c.metrics.observe("invalidate_books_list", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) InvalidateBookPages(ctx context.Context, bookID uint) error {
+	start := time.Now()
+	err := c.next.InvalidateBookPages(ctx, bookID)
+	c.metrics.observe("invalidate_book_pages", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, ListGeneration, error) {
+	start := time.Now()
+	ctx, skipped := trackSkipped(ctx)
+	stats, generation, err := c.next.GetStats(ctx, filter)
+	c.lookup("get_stats", start, *skipped, found(stats != nil), found(stats == nil), err)
+	return stats, generation, err
+}
+
+func (c *InstrumentedCache) SetStats(ctx context.Context, generation ListGeneration, filter models.StatsFilter, stats *models.CatalogueStats) error {
+	start := time.Now()
+	err := c.next.SetStats(ctx, generation, filter, stats)
+	c.metrics.observe("set_stats", time.Since(start), 0, 0, err)
+	return err
+}
+
+// GetAvailableCopies counts a hit or miss for each requested book
+func (c *InstrumentedCache) GetAvailableCopies(ctx context.Context, bookIDs []uint) (map[uint]int, error) {
+	start := time.Now()
+	ctx, skipped := trackSkipped(ctx)
+	counts, err := c.next.GetAvailableCopies(ctx, bookIDs)
+	c.lookup("get_available_copies", start, *skipped, len(counts), len(bookIDs)-len(counts), err)
+	return counts, err
+}
+
+func (c *InstrumentedCache) SetAvailableCopies(ctx context.Context, counts map[uint]int) error {
+	start := time.Now()
+	err := c.next.SetAvailableCopies(ctx, counts)
+	c.metrics.observe("set_available_copies", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) DeleteAvailableCopies(ctx context.Context, bookID uint) error {
+	start := time.Now()
+	err := c.next.DeleteAvailableCopies(ctx, bookID)
+	c.metrics.observe("delete_available_copies", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) TryLock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	start := time.Now()
+	token, err := c.next.TryLock(ctx, key, ttl)
+	c.metrics.observe("try_lock", time.Since(start), 0, 0, err)
+	return token, err
+}
+
+func (c *InstrumentedCache) Unlock(ctx context.Context, key, token string) error {
+	start := time.Now()
+	err := c.next.Unlock(ctx, key, token)
+	c.metrics.observe("unlock", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) RemoveStaleLists(ctx context.Context) (int, error) {
+	start := time.Now()
+	removed, err := c.next.RemoveStaleLists(ctx)
+	c.metrics.observe("remove_stale_lists", time.Since(start), 0, 0, err)
+	return removed, err
+}
+
+func (c *InstrumentedCache) InspectBook(ctx context.Context, id uint) (*BookEntry, time.Duration, error) {
+	return c.next.InspectBook(ctx, id)
+}
+
+func (c *InstrumentedCache) DeleteKey(ctx context.Context, key string) error {
+	return c.next.DeleteKey(ctx, key)
+}
+
+func (c *InstrumentedCache) Clear(ctx context.Context) error {
+	start := time.Now()
+	err := c.next.Clear(ctx)
+	c.metrics.observe("clear", time.Since(start), 0, 0, err)
+	return err
+}
+
+func (c *InstrumentedCache) Close() error {
+	return c.next.Close()
+}
+
+// lookup records a lookup, counting its keys as skipped when it did not
+// reach the cache
+func (c *InstrumentedCache) lookup(op string, start time.Time, skipped bool, hits, misses int, err error) {
+	if skipped {
+		c.metrics.observeSkipped(op, time.Since(start), hits+misses)
+		return
+	}
+	c.metrics.observe(op, time.Since(start), hits, misses, err)
+}
+
+// found converts the outcome of a single-key lookup into a hit or miss count
+func found(ok bool) int {
+	if ok {
+		return 1
+	}
+	return 0
+}
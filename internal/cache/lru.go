@@ -0,0 +1,211 @@
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+	"time"
+)
+
+// entryOverhead approximates the memory an entry costs beyond its key and
+// value, so many tiny entries still count against the limit
+const entryOverhead = 64
+
+// lru is a thread-safe least-recently-used store of byte values with
+// per-entry expiry, bounded by the total size of its entries
+type lru struct {
+	mu       sync.Mutex
+	maxBytes int64
+	bytes    int64
+	order    *list.List
+	items    map[string]*list.Element
+	now      func() time.Time
+	// onRemove, if set, is called with the key of every entry removed other
+	// than by clear. It runs with mu held and must not use the lru.
+	onRemove func(key string)
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e *lruEntry) size() int64 {
+	return int64(len(e.key) + len(e.value) + entryOverhead)
+}
+
+func newLRU(maxBytes int64) *lru {
+	return &lru{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// get returns the value stored for key unless it is missing or expired
+func (l *lru) get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if l.expired(entry) {
+		l.remove(elem)
+		return nil, false
+	}
+
+	l.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// peek returns the value stored for key with the time it has left to live,
+// without marking it as recently used
+func (l *lru) peek(key string) ([]byte, time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.items[key]
+	if !ok || l.expired(elem.Value.(*lruEntry)) {
+		return nil, 0, false
+	}
+	entry := elem.Value.(*lruEntry)
+	return entry.value, entry.expiresAt.Sub(l.now()), true
+}
+
+// set stores value under key for ttl, evicting the least recently used
+// entries to make room. Values larger than the whole cache are not stored.
+func (l *lru) set(key string, value []byte, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.put(key, value, ttl)
+}
+
+// setThen stores value like set and, if it was stored, calls stored before
+// releasing the lock, so stored runs before the entry can be removed again.
+// Like onRemove, stored must not use the lru.
+func (l *lru) setThen(key string, value []byte, ttl time.Duration, stored func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.put(key, value, ttl)
+	if _, ok := l.items[key]; ok {
+		stored()
+	}
+}
+
+// setIf stores value unless key holds a live entry that replace rejects,
+// and reports whether it stored it
+func (l *lru) setIf(key string, value []byte, ttl time.Duration, replace func(current []byte) bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.items[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		if !l.expired(entry) && !replace(entry.value) {
+			return false
+		}
+	}
+	l.put(key, value, ttl)
+	return true
+}
+
+// setNX stores value only if key holds no live entry and reports whether it
+// did
+func (l *lru) setNX(key string, value []byte, ttl time.Duration) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.items[key]; ok && !l.expired(elem.Value.(*lruEntry)) {
+		return false
+	}
+	l.put(key, value, ttl)
+	return true
+}
+
+func (l *lru) delete(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := l.items[key]; ok {
+			l.remove(elem)
+		}
+	}
+}
+
+// deleteIfEqual deletes key only while it still holds value
+func (l *lru) deleteIfEqual(key string, value []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.items[key]; ok && bytes.Equal(elem.Value.(*lruEntry).value, value) {
+		l.remove(elem)
+	}
+}
+
+// removeIf deletes expired entries and those whose key matches stale, and
+// reports how many it deleted
+func (l *lru) removeIf(stale func(key string) bool) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	removed := 0
+	for elem := l.order.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*lruEntry)
+		if l.expired(entry) || stale(entry.key) {
+			l.remove(elem)
+			removed++
+		}
+		elem = next
+	}
+	return removed
+}
+
+func (l *lru) clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.order.Init()
+	l.items = make(map[string]*list.Element)
+	l.bytes = 0
+}
+
+// put stores an entry; the caller must hold mu
+func (l *lru) put(key string, value []byte, ttl time.Duration) {
+	if elem, ok := l.items[key]; ok {
+		l.remove(elem)
+	}
+
+	entry := &lruEntry{key: key, value: value, expiresAt: l.now().Add(ttl)}
+	if entry.size() > l.maxBytes {
+		return
+	}
+
+	for l.bytes+entry.size() > l.maxBytes {
+		l.remove(l.order.Back())
+	}
+
+	l.items[key] = l.order.PushFront(entry)
+	l.bytes += entry.size()
+}
+
+// remove deletes an entry; the caller must hold mu
+func (l *lru) remove(elem *list.Element) {
+	entry := l.order.Remove(elem).(*lruEntry)
+	delete(l.items, entry.key)
+	l.bytes -= entry.size()
+	if l.onRemove != nil {
+		l.onRemove(entry.key)
+	}
+}
+
+func (l *lru) expired(entry *lruEntry) bool {
+	return !l.now().Before(entry.expiresAt)
+}
@@ -0,0 +1,266 @@
+package cache
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testEntrySize is the size lru accounts for a one-letter key holding a
+// ten-byte value
+const testEntrySize = 1 + 10 + entryOverhead
+
+func TestLRUEviction(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxBytes int64
+		run      func(l *lru)
+		present  []string
+		absent   []string
+	}{
+		{
+			name:     "evicts the least recently used entry",
+			maxBytes: 2 * testEntrySize,
+			run: func(l *lru) {
+				l.set("a", testValue(10), time.Minute)
+				l.set("b", testValue(10), time.Minute)
+				l.set("c", testValue(10), time.Minute)
+			},
+			present: []string{"b", "c"},
+			absent:  []string{"a"},
+		},
+		{
+			name:     "get marks an entry as recently used",
+			maxBytes: 2 * testEntrySize,
+			run: func(l *lru) {
+				l.set("a", testValue(10), time.Minute)
+				l.set("b", testValue(10), time.Minute)
+				l.get("a")
+				l.set("c", testValue(10), time.Minute)
+			},
+			present: []string{"a", "c"},
+			absent:  []string{"b"},
+		},
+		{
+			name:     "peek leaves the order alone",
+			maxBytes: 2 * testEntrySize,
+			run: func(l *lru) {
+				l.set("a", testValue(10), time.Minute)
+				l.set("b", testValue(10), time.Minute)
+				l.peek("a")
+				l.set("c", testValue(10), time.Minute)
+			},
+			present: []string{"b", "c"},
+			absent:  []string{"a"},
+		},
+		{
+			name:     "replacing an entry frees its old size",
+			maxBytes: 2 * testEntrySize,
+			run: func(l *lru) {
+				l.set("a", testValue(10), time.Minute)
+				l.set("b", testValue(10), time.Minute)
+				l.set("a", testValue(10), time.Minute)
+			},
+			present: []string{"a", "b"},
+		},
+		{
+			name:     "a large entry evicts as many as it needs",
+			maxBytes: 3 * testEntrySize,
+			run: func(l *lru) {
+				l.set("a", testValue(10), time.Minute)
+				l.set("b", testValue(10), time.Minute)
+				l.set("c", testValue(10), time.Minute)
+				l.set("d", testValue(10+testEntrySize), time.Minute)
+			},
+			present: []string{"c", "d"},
+			absent:  []string{"a", "b"},
+		},
+		{
+			name:     "a value larger than the cache is not stored",
+			maxBytes: 2 * testEntrySize,
+			run: func(l *lru) {
+				l.set("a", testValue(10), time.Minute)
+				l.set("b", testValue(2*testEntrySize), time.Minute)
+			},
+			present: []string{"a"},
+			absent:  []string{"b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLRU(tt.maxBytes)
+			tt.run(l)
+
+			for _, key := range tt.present {
+				if _, ok := l.get(key); !ok {
+					t.Errorf("%s was evicted", key)
+				}
+			}
+			for _, key := range tt.absent {
+				if _, ok := l.get(key); ok {
+					t.Errorf("%s was kept", key)
+				}
+			}
+			if l.bytes > l.maxBytes {
+				t.Errorf("holds %d bytes, limit is %d", l.bytes, l.maxBytes)
+			}
+		})
+	}
+}
+
+func TestLRUExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		elapsed time.Duration
+		found   bool
+	}{
+		{name: "fresh", ttl: time.Minute, elapsed: 0, found: true},
+		{name: "just before expiry", ttl: time.Minute, elapsed: time.Minute - time.Nanosecond, found: true},
+		{name: "at expiry", ttl: time.Minute, elapsed: time.Minute, found: false},
+		{name: "after expiry", ttl: time.Minute, elapsed: time.Hour, found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+			l := newLRU(1 << 20)
+			l.now = func() time.Time { return now }
+
+			l.set("a", testValue(10), tt.ttl)
+			now = now.Add(tt.elapsed)
+
+			_, left, ok := l.peek("a")
+			if ok != tt.found {
+				t.Fatalf("peek found = %v, want %v", ok, tt.found)
+			}
+			if ok && left != tt.ttl-tt.elapsed {
+				t.Errorf("peek ttl = %v, want %v", left, tt.ttl-tt.elapsed)
+			}
+
+			if _, ok := l.get("a"); ok != tt.found {
+				t.Errorf("get found = %v, want %v", ok, tt.found)
+			}
+
+			// An expired entry neither blocks setNX nor is consulted by setIf
+			if stored := l.setNX("a", testValue(10), tt.ttl); stored == tt.found {
+				t.Errorf("setNX stored = %v, want %v", stored, !tt.found)
+			}
+			l.set("b", testValue(10), tt.ttl)
+			now = now.Add(tt.elapsed)
+			reject := func([]byte) bool { return false }
+			if stored := l.setIf("b", testValue(10), tt.ttl, reject); stored == tt.found {
+				t.Errorf("setIf stored = %v, want %v", stored, !tt.found)
+			}
+		})
+	}
+}
+
+func TestLRUOnRemove(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     func(l *lru, advance func(time.Duration))
+		removed []string
+	}{
+		{
+			name: "eviction",
+			run: func(l *lru, _ func(time.Duration)) {
+				l.set("a", testValue(10), time.Minute)
+				l.set("b", testValue(10), time.Minute)
+				l.set("c", testValue(10), time.Minute)
+			},
+			removed: []string{"a"},
+		},
+		{
+			name: "delete",
+			run: func(l *lru, _ func(time.Duration)) {
+				l.set("a", testValue(10), time.Minute)
+				l.set("b", testValue(10), time.Minute)
+				l.delete("a", "missing")
+			},
+			removed: []string{"a"},
+		},
+		{
+			name: "replacement",
+			run: func(l *lru, _ func(time.Duration)) {
+				l.set("a", testValue(10), time.Minute)
+				l.set("a", testValue(10), time.Minute)
+			},
+			removed: []string{"a"},
+		},
+		{
+			name: "expired entry read",
+			run: func(l *lru, advance func(time.Duration)) {
+				l.set("a", testValue(10), time.Minute)
+				advance(time.Minute)
+				l.get("a")
+			},
+			removed: []string{"a"},
+		},
+		{
+			name: "deleteIfEqual only on a match",
+			run: func(l *lru, _ func(time.Duration)) {
+				l.set("a", []byte("first"), time.Minute)
+				l.set("b", []byte("second"), time.Minute)
+				l.deleteIfEqual("a", []byte("other"))
+				l.deleteIfEqual("b", []byte("second"))
+			},
+			removed: []string{"b"},
+		},
+		{
+			name: "removeIf",
+			run: func(l *lru, advance func(time.Duration)) {
+				l.set("a", testValue(10), time.Second)
+				l.set("c", testValue(10), time.Minute)
+				advance(time.Second)
+				l.removeIf(func(key string) bool { return key == "c" })
+			},
+			removed: []string{"c", "a"},
+		},
+		{
+			name: "not on clear",
+			run: func(l *lru, _ func(time.Duration)) {
+				l.set("a", testValue(10), time.Minute)
+				l.clear()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+			l := newLRU(2 * testEntrySize)
+			l.now = func() time.Time { return now }
+
+			var removed []string
+			l.onRemove = func(key string) { removed = append(removed, key) }
+
+			tt.run(l, func(d time.Duration) { now = now.Add(d) })
+			if !reflect.DeepEqual(removed, tt.removed) {
+				t.Errorf("removed %v, want %v", removed, tt.removed)
+			}
+		})
+	}
+}
+
+func TestLRUSetThen(t *testing.T) {
+	l := newLRU(2 * testEntrySize)
+
+	called := false
+	l.setThen("a", testValue(10), time.Minute, func() { called = true })
+	if value, ok := l.get("a"); !called || !ok || !bytes.Equal(value, testValue(10)) {
+		t.Errorf("stored entry: called = %v, found = %v", called, ok)
+	}
+
+	called = false
+	l.setThen("b", testValue(2*testEntrySize), time.Minute, func() { called = true })
+	if called {
+		t.Error("stored called for a value too large to keep")
+	}
+}
+
+func testValue(n int) []byte {
+	return bytes.Repeat([]byte{'x'}, n)
+}
@@ -0,0 +1,318 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+// MemoryCache keeps the cache in process for development and single
+// instance deployments. Values are stored JSON encoded under the same keys
+// as RedisCache, so callers always get their own copy and the cache's size
+// is measured the same way Redis would see it.
+type MemoryCache struct {
+	entries     *lru
+	ttl         time.Duration
+	negativeTTL time.Duration
+	// generation mirrors the Redis list generation counter
+	generation atomic.Int64
+
+	// pages indexes the cached list page keys by the books on them, and
+	// pageBooks the books by page key so evicted pages can be dropped
+	pagesMu   sync.Mutex
+	pages     map[uint]map[string]struct{}
+	pageBooks map[string][]uint
+}
+
+func NewMemoryCache(cfg *config.Config) *MemoryCache {
+	return newMemoryCache(cfg.Cache.MaxBytes, defaultExpiration, cfg.Cache.NegativeTTL)
+}
+
+func newMemoryCache(maxBytes int64, ttl, negativeTTL time.Duration) *MemoryCache {
+	c := &MemoryCache{
+		entries:     newLRU(maxBytes),
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		pages:       make(map[uint]map[string]struct{}),
+		pageBooks:   make(map[string][]uint),
+	}
+	c.entries.onRemove = c.forgetPage
+	return c
+}
+
+func (c *MemoryCache) GetBook(ctx context.Context, id uint) (*BookEntry, error) {
+	data, ok := c.entries.get(fmt.Sprintf("%s%d", bookKeyPrefix, id))
+	if !ok {
+		return nil, nil
+	}
+	return decodeBookEntry(data, json.Unmarshal)
+}
+
+func (c *MemoryCache) SetBook(ctx context.Context, book *models.Book, loadTime time.Duration) error {
+	return c.setBookEntry(book.ID, &BookEntry{Book: book, LoadTime: loadTime, ExpiresAt: time.Now().Add(c.ttl)})
+}
+
+func (c *MemoryCache) SetBookMissing(ctx context.Context, id uint) error {
+	return c.setBookEntry(id, &BookEntry{ExpiresAt: time.Now().Add(c.negativeTTL)})
+}
+
+func (c *MemoryCache) DeleteBook(ctx context.Context, id uint) error {
+	c.entries.delete(fmt.Sprintf("%s%d", bookKeyPrefix, id))
+	return nil
+}
+
+// deleteBookBefore drops the cached book if it is older than version
+func (c *MemoryCache) deleteBookBefore(id uint, version int64) {
+	key := fmt.Sprintf("%s%d", bookKeyPrefix, id)
+	data, ok := c.entries.get(key)
+	if !ok {
+		return
+	}
+	if entry, err := decodeBookEntry(data, json.Unmarshal); err != nil || entry == nil || entry.Book == nil || entry.Book.Version < version {
+		c.entries.delete(key)
+	}
+}
+
+// setBookEntry stores entry until it expires, but for no longer than the
+// cache's own TTL
+func (c *MemoryCache) setBookEntry(id uint, entry *BookEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	ttl := time.Until(entry.ExpiresAt)
+	if ttl > c.ttl {
+		ttl = c.ttl
+	}
+	c.entries.setIf(fmt.Sprintf("%s%d", bookKeyPrefix, id), data, ttl, func(current []byte) bool {
+		// A negative entry never replaces a live one, as with SET NX
+		if entry.Book == nil {
+			return false
+		}
+		cached, err := decodeBookEntry(current, json.Unmarshal)
+		return err != nil || cached == nil || cached.Book == nil || cached.Book.Version <= entry.Book.Version
+	})
+	return nil
+}
+
+func (c *MemoryCache) GetBookIDByISBN(ctx context.Context, isbn string) (uint, error) {
+	data, ok := c.entries.get(bookISBNKeyPrefix + isbn)
+	if !ok {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func (c *MemoryCache) SetBookISBN(ctx context.Context, isbn string, id uint) error {
+	c.entries.set(bookISBNKeyPrefix+isbn, []byte(strconv.FormatUint(uint64(id), 10)), c.ttl)
+	return nil
+}
+
+func (c *MemoryCache) GetBooksList(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, ListGeneration, error) {
+	generation := ListGeneration{local: c.generation.Load()}
+	var list models.BookList
+	if ok, err := c.getJSON(booksListKey(generation.local, filter, page, pageSize), &list); !ok || err != nil {
+		return nil, generation, err
+	}
+	return &list, generation, nil
+}
+
+func (c *MemoryCache) SetBooksList(ctx context.Context, generation ListGeneration, filter models.BookFilter, page, pageSize int, list *models.BookList) error {
+	key := booksListKey(generation.local, filter, page, pageSize)
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+
+	// The page is indexed while the lru is still locked, so its removal,
+	// which drops it from the index, always comes after
+	c.entries.setThen(key, data, c.ttl, func() {
+		c.pagesMu.Lock()
+		defer c.pagesMu.Unlock()
+		bookIDs := make([]uint, len(list.Books))
+		for i, book := range list.Books {
+			if c.pages[book.ID] == nil {
+				c.pages[book.ID] = make(map[string]struct{})
+			}
+			c.pages[book.ID][key] = struct{}{}
+			bookIDs[i] = book.ID
+		}
+		c.pageBooks[key] = bookIDs
+	})
+	return nil
+}
+
+// forgetPage drops key from the page index once the entry is gone, however
+// it was removed
+func (c *MemoryCache) forgetPage(key string) {
+	if !strings.HasPrefix(key, bookListKeyPrefix) {
+		return
+	}
+
+	c.pagesMu.Lock()
+	defer c.pagesMu.Unlock()
+	for _, bookID := range c.pageBooks[key] {
+		if pages := c.pages[bookID]; pages != nil {
+			delete(pages, key)
+			if len(pages) == 0 {
+				delete(c.pages, bookID)
+			}
+		}
+	}
+	delete(c.pageBooks, key)
+}
+
+func (c *MemoryCache) InvalidateBookPages(ctx context.Context, bookID uint) error {
+	c.pagesMu.Lock()
+	pages := c.pages[bookID]
+	delete(c.pages, bookID)
+	c.pagesMu.Unlock()
+
+	for key := range pages {
+		c.entries.delete(key)
+	}
+	return nil
+}
+
+// InvalidateBooksList moves list and stats keys to a new generation, as
+// RedisCache does. Older generations are dropped by RemoveStaleLists or
+// evicted as the cache fills up.
+func (c *MemoryCache) InvalidateBooksList(ctx context.Context) error {
+	c.generation.Add(1)
+	return nil
+}
+
+func (c *MemoryCache) GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, ListGeneration, error) {
+	generation := ListGeneration{local: c.generation.Load()}
+	var stats models.CatalogueStats
+	if ok, err := c.getJSON(statsKey(generation.local, filter), &stats); !ok || err != nil {
+		return nil, generation, err
+	}
+	return &stats, generation, nil
+}
+
+func (c *MemoryCache) SetStats(ctx context.Context, generation ListGeneration, filter models.StatsFilter, stats *models.CatalogueStats) error {
+	return c.setJSON(statsKey(generation.local, filter), stats)
+}
+
+func (c *MemoryCache) GetAvailableCopies(ctx context.Context, bookIDs []uint) (map[uint]int, error) {
+	counts := make(map[uint]int, len(bookIDs))
+	for _, id := range bookIDs {
+		data, ok := c.entries.get(fmt.Sprintf("%s%d", availableKeyPrefix, id))
+		if !ok {
+			continue
+		}
+		count, err := strconv.Atoi(string(data))
+		if err != nil {
+			continue
+		}
+		counts[id] = count
+	}
+	return counts, nil
+}
+
+func (c *MemoryCache) SetAvailableCopies(ctx context.Context, counts map[uint]int) error {
+	for id, count := range counts {
+		c.entries.set(fmt.Sprintf("%s%d", availableKeyPrefix, id), []byte(strconv.Itoa(count)), c.ttl)
+	}
+	return nil
+}
+
+func (c *MemoryCache) DeleteAvailableCopies(ctx context.Context, bookID uint) error {
+	c.entries.delete(fmt.Sprintf("%s%d", availableKeyPrefix, bookID))
+	return nil
+}
+
+func (c *MemoryCache) TryLock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	token := newLockToken()
+	if !c.entries.setNX(lockKeyPrefix+key, []byte(token), ttl) {
+		return "", nil
+	}
+	return token, nil
+}
+
+func (c *MemoryCache) Unlock(ctx context.Context, key, token string) error {
+	c.entries.deleteIfEqual(lockKeyPrefix+key, []byte(token))
+	return nil
+}
+
+// RemoveStaleLists deletes list and stats entries of earlier generations
+// along with any expired entries
+func (c *MemoryCache) RemoveStaleLists(ctx context.Context) (int, error) {
+	generation := c.generation.Load()
+	removed := c.entries.removeIf(func(key string) bool {
+		for _, prefix := range []string{bookListKeyPrefix, statsKeyPrefix} {
+			if strings.HasPrefix(key, prefix) {
+				return keyGeneration(key, prefix) < generation
+			}
+		}
+		return false
+	})
+
+	return removed, nil
+}
+
+func (c *MemoryCache) Clear(ctx context.Context) error {
+	c.entries.clear()
+	c.pagesMu.Lock()
+	c.pages = make(map[uint]map[string]struct{})
+	c.pageBooks = make(map[string][]uint)
+	c.pagesMu.Unlock()
+	// The generation only moves forward, so a page loaded before the clear
+	// can never be stored as current
+	c.generation.Add(1)
+	return nil
+}
+
+func (c *MemoryCache) Close() error {
+	return nil
+}
+
+func (c *MemoryCache) InspectBook(ctx context.Context, id uint) (*BookEntry, time.Duration, error) {
+	data, ttl, ok := c.entries.peek(fmt.Sprintf("%s%d", bookKeyPrefix, id))
+	if !ok {
+		return nil, 0, nil
+	}
+	entry, err := decodeBookEntry(data, json.Unmarshal)
+	return entry, ttl, err
+}
+
+func (c *MemoryCache) DeleteKey(ctx context.Context, key string) error {
+	c.entries.delete(key)
+	return nil
+}
+
+// getJSON decodes the entry stored under key into v and reports whether
+// there was one
+func (c *MemoryCache) getJSON(key string, v interface{}) (bool, error) {
+	data, ok := c.entries.get(key)
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *MemoryCache) setJSON(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.entries.set(key, data, c.ttl)
+	return nil
+}
@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds of the latency histogram kept for
+// each cache operation
+var LatencyBuckets = []time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+}
+
+// Metrics counts calls, hits, misses, skipped lookups and errors and
+// records latencies for each cache operation. It is safe for concurrent use.
+type Metrics struct {
+	mu         sync.RWMutex
+	operations map[string]*operationMetrics
+}
+
+type operationMetrics struct {
+	calls  atomic.Uint64
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	// skipped counts keys of lookups the circuit breaker did not let
+	// reach the cache
+	skipped atomic.Uint64
+	errors  atomic.Uint64
+	// buckets counts calls per latency bucket, with one extra bucket for
+	// calls slower than the last bound
+	buckets []atomic.Uint64
+	// total latency in nanoseconds
+	latency atomic.Int64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{operations: make(map[string]*operationMetrics)}
+}
+
+// observe records one call of op. Hits and misses are only counted for
+// lookups, which pass how many keys they found and missed.
+func (m *Metrics) observe(op string, elapsed time.Duration, hits, misses int, err error) {
+	o := m.call(op, elapsed)
+	if err != nil {
+		o.errors.Add(1)
+		return
+	}
+	o.hits.Add(uint64(hits))
+	o.misses.Add(uint64(misses))
+}
+
+// observeSkipped records one call of the lookup op that was answered
+// without reaching the cache, for the given number of keys
+func (m *Metrics) observeSkipped(op string, elapsed time.Duration, keys int) {
+	m.call(op, elapsed).skipped.Add(uint64(keys))
+}
+
+// call counts one call of op and its latency
+func (m *Metrics) call(op string, elapsed time.Duration) *operationMetrics {
+	o := m.operation(op)
+	o.calls.Add(1)
+	o.latency.Add(int64(elapsed))
+	o.buckets[sort.Search(len(LatencyBuckets), func(i int) bool { return elapsed <= LatencyBuckets[i] })].Add(1)
+	return o
+}
+
+func (m *Metrics) operation(op string) *operationMetrics {
+	m.mu.RLock()
+	o, ok := m.operations[op]
+	m.mu.RUnlock()
+	if ok {
+		return o
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if o, ok = m.operations[op]; !ok {
+		o = &operationMetrics{buckets: make([]atomic.Uint64, len(LatencyBuckets)+1)}
+		m.operations[op] = o
+	}
+	return o
+}
+
+// OperationStats is a snapshot of the metrics of one cache operation
+type OperationStats struct {
+	Name   string
+	Calls  uint64
+	Hits   uint64
+	Misses uint64
+	// Skipped counts keys of lookups made while the circuit breaker was
+	// open. They are neither hits nor misses.
+	Skipped uint64
+	Errors  uint64
+	// Latency counts calls per bucket of LatencyBuckets, cumulatively; the
+	// last element counts every call
+	Latency      []uint64
+	TotalLatency time.Duration
+}
+
+// HitRatio is the share of lookups that were served from the cache, or nil
+// for operations that are not lookups. Skipped lookups are left out.
+func (s OperationStats) HitRatio() *float64 {
+	if s.Hits+s.Misses == 0 {
+		return nil
+	}
+	ratio := float64(s.Hits) / float64(s.Hits+s.Misses)
+	return &ratio
+}
+
+func (s OperationStats) AverageLatency() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Calls)
+}
+
+// LatencyQuantile estimates the latency below which the fraction q of calls
+// completed, as the upper bound of the bucket holding that call. Calls
+// slower than every bucket report the last bound.
+func (s OperationStats) LatencyQuantile(q float64) time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(s.Calls)))
+	for i, bound := range LatencyBuckets {
+		if s.Latency[i] >= rank {
+			return bound
+		}
+	}
+	return LatencyBuckets[len(LatencyBuckets)-1]
+}
+
+// Snapshot returns the metrics of every operation seen so far, sorted by
+// name
+func (m *Metrics) Snapshot() []OperationStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := make([]OperationStats, 0, len(m.operations))
+	for name, o := range m.operations {
+		s := OperationStats{
+			Name:         name,
+			Calls:        o.calls.Load(),
+			Hits:         o.hits.Load(),
+			Misses:       o.misses.Load(),
+			Skipped:      o.skipped.Load(),
+			Errors:       o.errors.Load(),
+			Latency:      make([]uint64, len(o.buckets)),
+			TotalLatency: time.Duration(o.latency.Load()),
+		}
+		var cumulative uint64
+		for i := range o.buckets {
+			cumulative += o.buckets[i].Load()
+			s.Latency[i] = cumulative
+		}
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
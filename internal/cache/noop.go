@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+// NoopCache stores nothing: every lookup misses and every write is
+// dropped. It stands in for the cache while Redis cannot be reached.
+type NoopCache struct{}
+
+func (NoopCache) GetBook(ctx context.Context, id uint) (*BookEntry, error) {
+	return nil, nil
+}
+
+func (NoopCache) SetBook(ctx context.Context, book *models.Book, loadTime time.Duration) error {
+	return nil
+}
+
+func (NoopCache) SetBookMissing(ctx context.Context, id uint) error {
+	return nil
+}
+
+func (NoopCache) DeleteBook(ctx context.Context, id uint) error {
+	return nil
+}
+
+func (NoopCache) GetBookIDByISBN(ctx context.Context, isbn string) (uint, error) {
+	return 0, nil
+}
+
+func (NoopCache) SetBookISBN(ctx context.Context, isbn string, id uint) error {
+	return nil
+}
+
+func (NoopCache) GetBooksList(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, ListGeneration, error) {
+	return nil, ListGeneration{}, nil
+}
+
+func (NoopCache) SetBooksList(ctx context.Context, generation ListGeneration, filter models.BookFilter, page, pageSize int, list *models.BookList) error {
+	return nil
+}
+
+func (NoopCache) InvalidateBooksList(ctx context.Context) error {
+	return nil
+}
+
+func (NoopCache) InvalidateBookPages(ctx context.Context, bookID uint) error {
+	return nil
+}
+
+func (NoopCache) GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, ListGeneration, error) {
+	return nil, ListGeneration{}, nil
+}
+
+func (NoopCache) SetStats(ctx context.Context, generation ListGeneration, filter models.StatsFilter, stats *models.CatalogueStats) error {
+	return nil
+}
+
+func (NoopCache) GetAvailableCopies(ctx context.Context, bookIDs []uint) (map[uint]int, error) {
+	return map[uint]int{}, nil
+}
+
+func (NoopCache) SetAvailableCopies(ctx context.Context, counts map[uint]int) error {
+	return nil
+}
+
+func (NoopCache) DeleteAvailableCopies(ctx context.Context, bookID uint) error {
+	return nil
+}
+
+// TryLock always succeeds, so callers load the entry themselves instead of
+// waiting for it to appear in a cache that never fills
+func (NoopCache) TryLock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return newLockToken(), nil
+}
+
+func (NoopCache) Unlock(ctx context.Context, key, token string) error {
+	return nil
+}
+
+func (NoopCache) RemoveStaleLists(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (NoopCache) InspectBook(ctx context.Context, id uint) (*BookEntry, time.Duration, error) {
+	return nil, 0, nil
+}
+
+func (NoopCache) DeleteKey(ctx context.Context, key string) error {
+	return nil
+}
+
+func (NoopCache) Clear(ctx context.Context) error {
+	return nil
+}
+
+func (NoopCache) Close() error {
+	return nil
+}
@@ -0,0 +1,379 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache/cachepb"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// protobufCodec encodes the cached values with the messages generated from
+// cachepb/cache.proto. It only knows the types the cache stores and keeps
+// the same fields as their JSON form.
+type protobufCodec struct{}
+
+func (protobufCodec) Marshal(v interface{}) ([]byte, error) {
+	var message proto.Message
+	switch v := v.(type) {
+	case *BookEntry:
+		message = bookEntryToProto(v)
+	case *models.BookList:
+		message = bookListToProto(v)
+	case *models.CatalogueStats:
+		message = statsToProto(v)
+	default:
+		return nil, fmt.Errorf("protobuf codec cannot encode %T", v)
+	}
+	return proto.Marshal(message)
+}
+
+func (protobufCodec) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *BookEntry:
+		var message cachepb.BookEntry
+		if err := proto.Unmarshal(data, &message); err != nil {
+			return err
+		}
+		*v = bookEntryFromProto(&message)
+	case *models.BookList:
+		var message cachepb.BookList
+		if err := proto.Unmarshal(data, &message); err != nil {
+			return err
+		}
+		*v = bookListFromProto(&message)
+	case *models.CatalogueStats:
+		var message cachepb.CatalogueStats
+		if err := proto.Unmarshal(data, &message); err != nil {
+			return err
+		}
+		*v = statsFromProto(&message)
+	default:
+		return fmt.Errorf("protobuf codec cannot decode into %T", v)
+	}
+	return nil
+}
+
+// Conversion helpers. Slices the JSON form always writes come back empty
+// rather than nil, as they do from JSON.
+
+func bookEntryToProto(entry *BookEntry) *cachepb.BookEntry {
+	message := &cachepb.BookEntry{
+		LoadTime:  int64(entry.LoadTime),
+		ExpiresAt: timeToProto(entry.ExpiresAt),
+	}
+	if entry.Book != nil {
+		message.Book = bookToProto(entry.Book)
+	}
+	return message
+}
+
+func bookEntryFromProto(message *cachepb.BookEntry) BookEntry {
+	entry := BookEntry{
+		LoadTime:  time.Duration(message.LoadTime),
+		ExpiresAt: timeFromProto(message.ExpiresAt),
+	}
+	if message.Book != nil {
+		book := bookFromProto(message.Book)
+		entry.Book = &book
+	}
+	return entry
+}
+
+func bookToProto(book *models.Book) *cachepb.Book {
+	message := &cachepb.Book{
+		Id:            uint64(book.ID),
+		WorkId:        uintPtrToProto(book.WorkID),
+		Title:         book.Title,
+		Author:        book.Author,
+		Year:          int64(book.Year),
+		Isbn13:        book.ISBN13,
+		Isbn10:        book.ISBN10,
+		PublisherId:   uintPtrToProto(book.PublisherID),
+		Format:        string(book.Format),
+		Language:      book.Language,
+		CoverKey:      book.CoverKey,
+		RatingCount:   int64(book.RatingCount),
+		AverageRating: book.AverageRating,
+		Version:       book.Version,
+		CreatedAt:     timeToProto(book.CreatedAt),
+		UpdatedAt:     timeToProto(book.UpdatedAt),
+	}
+	if book.PageCount != nil {
+		pageCount := int64(*book.PageCount)
+		message.PageCount = &pageCount
+	}
+	if book.PublishedOn != nil {
+		message.PublishedOn = timestamppb.New(*book.PublishedOn)
+	}
+	if book.Publisher != nil {
+		message.Publisher = &cachepb.Publisher{
+			Id:        uint64(book.Publisher.ID),
+			Name:      book.Publisher.Name,
+			Slug:      book.Publisher.Slug,
+			CreatedAt: timeToProto(book.Publisher.CreatedAt),
+			UpdatedAt: timeToProto(book.Publisher.UpdatedAt),
+		}
+	}
+	for _, credit := range book.Authors {
+		author := &cachepb.BookAuthor{
+			BookId:   uint64(credit.BookID),
+			AuthorId: uint64(credit.AuthorID),
+			Role:     string(credit.Role),
+			Position: int64(credit.Position),
+		}
+		if credit.Author != nil {
+			author.Author = &cachepb.Author{
+				Id:        uint64(credit.Author.ID),
+				Name:      credit.Author.Name,
+				CreatedAt: timeToProto(credit.Author.CreatedAt),
+				UpdatedAt: timeToProto(credit.Author.UpdatedAt),
+			}
+		}
+		message.Authors = append(message.Authors, author)
+	}
+	for _, genre := range book.Genres {
+		message.Genres = append(message.Genres, &cachepb.Genre{
+			Id:        uint64(genre.ID),
+			Name:      genre.Name,
+			Slug:      genre.Slug,
+			ParentId:  uintPtrToProto(genre.ParentID),
+			CreatedAt: timeToProto(genre.CreatedAt),
+			UpdatedAt: timeToProto(genre.UpdatedAt),
+		})
+	}
+	for _, tag := range book.Tags {
+		message.Tags = append(message.Tags, &cachepb.Tag{
+			Id:        uint64(tag.ID),
+			Name:      tag.Name,
+			Slug:      tag.Slug,
+			CreatedAt: timeToProto(tag.CreatedAt),
+			UpdatedAt: timeToProto(tag.UpdatedAt),
+		})
+	}
+	return message
+}
+
+func bookFromProto(message *cachepb.Book) models.Book {
+	book := models.Book{
+		ID:            uint(message.Id),
+		WorkID:        uintPtrFromProto(message.WorkId),
+		Title:         message.Title,
+		Author:        message.Author,
+		Year:          int(message.Year),
+		ISBN13:        message.Isbn13,
+		ISBN10:        message.Isbn10,
+		PublisherID:   uintPtrFromProto(message.PublisherId),
+		Format:        models.EditionFormat(message.Format),
+		Language:      message.Language,
+		CoverKey:      message.CoverKey,
+		RatingCount:   int(message.RatingCount),
+		AverageRating: message.AverageRating,
+		Version:       message.Version,
+		CreatedAt:     timeFromProto(message.CreatedAt),
+		UpdatedAt:     timeFromProto(message.UpdatedAt),
+	}
+	if message.PageCount != nil {
+		pageCount := int(*message.PageCount)
+		book.PageCount = &pageCount
+	}
+	if message.PublishedOn != nil {
+		publishedOn := message.PublishedOn.AsTime()
+		book.PublishedOn = &publishedOn
+	}
+	if publisher := message.Publisher; publisher != nil {
+		book.Publisher = &models.Publisher{
+			ID:        uint(publisher.Id),
+			Name:      publisher.Name,
+			Slug:      publisher.Slug,
+			CreatedAt: timeFromProto(publisher.CreatedAt),
+			UpdatedAt: timeFromProto(publisher.UpdatedAt),
+		}
+	}
+	for _, credit := range message.Authors {
+		author := models.BookAuthor{
+			BookID:   uint(credit.BookId),
+			AuthorID: uint(credit.AuthorId),
+			Role:     models.AuthorRole(credit.Role),
+			Position: int(credit.Position),
+		}
+		if credit.Author != nil {
+			author.Author = &models.Author{
+				ID:        uint(credit.Author.Id),
+				Name:      credit.Author.Name,
+				CreatedAt: timeFromProto(credit.Author.CreatedAt),
+				UpdatedAt: timeFromProto(credit.Author.UpdatedAt),
+			}
+		}
+		book.Authors = append(book.Authors, author)
+	}
+	for _, genre := range message.Genres {
+		book.Genres = append(book.Genres, models.Genre{
+			ID:        uint(genre.Id),
+			Name:      genre.Name,
+			Slug:      genre.Slug,
+			ParentID:  uintPtrFromProto(genre.ParentId),
+			CreatedAt: timeFromProto(genre.CreatedAt),
+			UpdatedAt: timeFromProto(genre.UpdatedAt),
+		})
+	}
+	for _, tag := range message.Tags {
+		book.Tags = append(book.Tags, models.Tag{
+			ID:        uint(tag.Id),
+			Name:      tag.Name,
+			Slug:      tag.Slug,
+			CreatedAt: timeFromProto(tag.CreatedAt),
+			UpdatedAt: timeFromProto(tag.UpdatedAt),
+		})
+	}
+	return book
+}
+
+func bookListToProto(list *models.BookList) *cachepb.BookList {
+	message := &cachepb.BookList{
+		Books: make([]*cachepb.Book, len(list.Books)),
+		Total: list.Total,
+	}
+	for i := range list.Books {
+		message.Books[i] = bookToProto(&list.Books[i])
+	}
+	if list.Facets != nil {
+		message.Facets = &cachepb.BookFacets{
+			Genres:  facetsToProto(list.Facets.Genres),
+			Tags:    facetsToProto(list.Facets.Tags),
+			Decades: facetsToProto(list.Facets.Decades),
+		}
+	}
+	return message
+}
+
+func bookListFromProto(message *cachepb.BookList) models.BookList {
+	list := models.BookList{
+		Books: make([]models.Book, len(message.Books)),
+		Total: message.Total,
+	}
+	for i, book := range message.Books {
+		list.Books[i] = bookFromProto(book)
+	}
+	if message.Facets != nil {
+		list.Facets = &models.BookFacets{
+			Genres:  facetsFromProto(message.Facets.Genres),
+			Tags:    facetsFromProto(message.Facets.Tags),
+			Decades: facetsFromProto(message.Facets.Decades),
+		}
+	}
+	return list
+}
+
+func facetsToProto(counts []models.FacetCount) []*cachepb.FacetCount {
+	messages := make([]*cachepb.FacetCount, len(counts))
+	for i, count := range counts {
+		messages[i] = &cachepb.FacetCount{Value: count.Value, Name: count.Name, Count: count.Count}
+	}
+	return messages
+}
+
+func facetsFromProto(messages []*cachepb.FacetCount) []models.FacetCount {
+	counts := make([]models.FacetCount, len(messages))
+	for i, message := range messages {
+		counts[i] = models.FacetCount{Value: message.Value, Name: message.Name, Count: message.Count}
+	}
+	return counts
+}
+
+func statsToProto(stats *models.CatalogueStats) *cachepb.CatalogueStats {
+	message := &cachepb.CatalogueStats{
+		TotalBooks:  stats.TotalBooks,
+		ByDecade:    periodsToProto(stats.ByDecade),
+		ByYear:      periodsToProto(stats.ByYear),
+		TopAuthors:  make([]*cachepb.AuthorCount, len(stats.TopAuthors)),
+		AddedPerDay: make([]*cachepb.DailyCount, len(stats.AddedPerDay)),
+		Growth: &cachepb.GrowthTrend{
+			Added:         stats.Growth.Added,
+			PreviousAdded: stats.Growth.PreviousAdded,
+			AveragePerDay: stats.Growth.AveragePerDay,
+			ChangePercent: stats.Growth.ChangePercent,
+		},
+	}
+	for i, author := range stats.TopAuthors {
+		message.TopAuthors[i] = &cachepb.AuthorCount{AuthorId: uint64(author.AuthorID), Name: author.Name, Count: author.Count}
+	}
+	for i, day := range stats.AddedPerDay {
+		message.AddedPerDay[i] = &cachepb.DailyCount{Date: day.Date, Added: day.Added, Total: day.Total}
+	}
+	return message
+}
+
+func statsFromProto(message *cachepb.CatalogueStats) models.CatalogueStats {
+	stats := models.CatalogueStats{
+		TotalBooks:  message.TotalBooks,
+		ByDecade:    periodsFromProto(message.ByDecade),
+		ByYear:      periodsFromProto(message.ByYear),
+		TopAuthors:  make([]models.AuthorCount, len(message.TopAuthors)),
+		AddedPerDay: make([]models.DailyCount, len(message.AddedPerDay)),
+	}
+	for i, author := range message.TopAuthors {
+		stats.TopAuthors[i] = models.AuthorCount{AuthorID: uint(author.AuthorId), Name: author.Name, Count: author.Count}
+	}
+	for i, day := range message.AddedPerDay {
+		stats.AddedPerDay[i] = models.DailyCount{Date: day.Date, Added: day.Added, Total: day.Total}
+	}
+	if growth := message.Growth; growth != nil {
+		stats.Growth = models.GrowthTrend{
+			Added:         growth.Added,
+			PreviousAdded: growth.PreviousAdded,
+			AveragePerDay: growth.AveragePerDay,
+			ChangePercent: growth.ChangePercent,
+		}
+	}
+	return stats
+}
+
+func periodsToProto(counts []models.PeriodCount) []*cachepb.PeriodCount {
+	messages := make([]*cachepb.PeriodCount, len(counts))
+	for i, count := range counts {
+		messages[i] = &cachepb.PeriodCount{Period: int64(count.Period), Count: count.Count}
+	}
+	return messages
+}
+
+func periodsFromProto(messages []*cachepb.PeriodCount) []models.PeriodCount {
+	counts := make([]models.PeriodCount, len(messages))
+	for i, message := range messages {
+		counts[i] = models.PeriodCount{Period: int(message.Period), Count: message.Count}
+	}
+	return counts
+}
+
+// timeToProto leaves zero times out, so they come back as zero times
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timeFromProto(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	return timestamp.AsTime()
+}
+
+func uintPtrToProto(v *uint) *uint64 {
+	if v == nil {
+		return nil
+	}
+	id := uint64(*v)
+	return &id
+}
+
+func uintPtrFromProto(v *uint64) *uint {
+	if v == nil {
+		return nil
+	}
+	id := uint(*v)
+	return &id
+}
@@ -2,8 +2,10 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/AhmadMuj/books-api-go/internal/config"
@@ -12,36 +14,95 @@ import (
 )
 
 const (
-	bookKeyPrefix     = "book:"
-	bookListKeyPrefix = "books:page:"
-	defaultExpiration = 24 * time.Hour
+	bookKeyPrefix      = "book:"
+	bookISBNKeyPrefix  = "book:isbn:"
+	bookListKeyPrefix  = "books:page:"
+	statsKeyPrefix     = "books:stats:"
+	availableKeyPrefix = "book:available:"
+	defaultExpiration  = 24 * time.Hour
+
+	// listGenerationKey holds the current generation of the list and stats
+	// keys. It never expires.
+	listGenerationKey = "books:generation"
+	// cleanupScanCount is the COUNT hint for each SCAN call of a cleanup
+	cleanupScanCount = 1000
+
+	lockKeyPrefix = "lock:"
+	// pageIndexKeyPrefix names the set of cached list pages a book is on
+	pageIndexKeyPrefix = "book:pages:"
 )
 
+// setBookScript writes a book entry (ARGV[1]) with the given version
+// (ARGV[2]) and TTL in milliseconds (ARGV[3]) unless the cached entry holds
+// a newer version of the book. The cached version is read from the plain
+// text prefix of serialized entries, or from the JSON of entries written
+// before serialized values had a format byte.
+var setBookScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current then
+	local version
+	if string.byte(current, 1) < 32 then
+		version = string.match(current, "^.(%d+):")
+	else
+		local ok, entry = pcall(cjson.decode, current)
+		if ok and type(entry) == "table" and type(entry.book) == "table" then
+			version = entry.book.version
+		end
+	end
+	if tonumber(version) and tonumber(version) > tonumber(ARGV[2]) then
+		return 0
+	end
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[3])
+return 1
+`)
+
+// unlockScript deletes a lock only if it still holds the caller's token, so
+// a holder whose lock expired cannot release someone else's
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisCache stores the cache in Redis, Sentinel-managed Redis or a Redis
+// Cluster. Every command touches a single key, or is split into one command
+// per key, so keys may be spread across cluster slots.
 type RedisCache struct {
-	client *redis.Client
+	client      redis.UniversalClient
+	values      *serializer
+	negativeTTL time.Duration
 }
 
 func NewRedisCache(cfg *config.Config) (*RedisCache, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
-	})
+	values, err := newSerializer(cfg.Cache)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newRedisClient(cfg.Redis)
+	if err != nil {
+		return nil, err
+	}
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := client.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		client.Close()
+		return nil, &unavailableError{err: fmt.Errorf("failed to connect to Redis: %w", err)}
 	}
 
 	return &RedisCache{
-		client: client,
+		client:      client,
+		values:      values,
+		negativeTTL: cfg.Cache.NegativeTTL,
 	}, nil
 }
 
-func (c *RedisCache) GetBook(ctx context.Context, id uint) (*models.Book, error) {
+func (c *RedisCache) GetBook(ctx context.Context, id uint) (*BookEntry, error) {
 	key := fmt.Sprintf("%s%d", bookKeyPrefix, id)
 	data, err := c.client.Get(ctx, key).Bytes()
 	if err != nil {
@@ -51,22 +112,33 @@ func (c *RedisCache) GetBook(ctx context.Context, id uint) (*models.Book, error)
 		return nil, err
 	}
 
-	var book models.Book
-	if err := json.Unmarshal(data, &book); err != nil {
-		return nil, err
+	return decodeBookEntry(data, c.values.decode)
+}
+
+// SetBook stores the book unless a newer version of it is already cached.
+// The check and the write run as one script so a slow reader can never
+// overwrite a fresher copy written in between.
+func (c *RedisCache) SetBook(ctx context.Context, book *models.Book, loadTime time.Duration) error {
+	entry := &BookEntry{Book: book, LoadTime: loadTime, ExpiresAt: time.Now().Add(defaultExpiration)}
+	data, err := c.values.encodeVersioned(entry, book.Version)
+	if err != nil {
+		return err
 	}
 
-	return &book, nil
+	key := fmt.Sprintf("%s%d", bookKeyPrefix, book.ID)
+	return setBookScript.Run(ctx, c.client, []string{key}, data, book.Version, defaultExpiration.Milliseconds()).Err()
 }
 
-func (c *RedisCache) SetBook(ctx context.Context, book *models.Book) error {
-	data, err := json.Marshal(book)
+func (c *RedisCache) SetBookMissing(ctx context.Context, id uint) error {
+	entry := &BookEntry{ExpiresAt: time.Now().Add(c.negativeTTL)}
+	data, err := c.values.encode(entry)
 	if err != nil {
 		return err
 	}
 
-	key := fmt.Sprintf("%s%d", bookKeyPrefix, book.ID)
-	return c.client.Set(ctx, key, data, defaultExpiration).Err()
+	// NX keeps a lookup that raced the book's creation from hiding it
+	key := fmt.Sprintf("%s%d", bookKeyPrefix, id)
+	return c.client.SetNX(ctx, key, data, c.negativeTTL).Err()
 }
 
 func (c *RedisCache) DeleteBook(ctx context.Context, id uint) error {
@@ -74,62 +146,325 @@ func (c *RedisCache) DeleteBook(ctx context.Context, id uint) error {
 	return c.client.Del(ctx, key).Err()
 }
 
-func (c *RedisCache) GetBooksList(ctx context.Context, page, pageSize int) ([]models.Book, int64, error) {
-	key := fmt.Sprintf("%s%d:%d", bookListKeyPrefix, page, pageSize)
+func (c *RedisCache) GetBookIDByISBN(ctx context.Context, isbn string) (uint, error) {
+	id, err := c.client.Get(ctx, bookISBNKeyPrefix+isbn).Uint64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func (c *RedisCache) SetBookISBN(ctx context.Context, isbn string, id uint) error {
+	return c.client.Set(ctx, bookISBNKeyPrefix+isbn, id, defaultExpiration).Err()
+}
+
+func (c *RedisCache) GetBooksList(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, ListGeneration, error) {
+	shared, err := c.listGeneration(ctx)
+	if err != nil {
+		return nil, ListGeneration{}, err
+	}
+	generation := ListGeneration{shared: shared}
+	key := booksListKey(shared, filter, page, pageSize)
 
 	// Get cached data
 	data, err := c.client.Get(ctx, key).Bytes()
 	if err != nil {
 		if err == redis.Nil {
-			return nil, 0, nil
+			return nil, generation, nil
 		}
-		return nil, 0, err
+		return nil, generation, err
 	}
 
-	var result struct {
-		Books []models.Book `json:"books"`
-		Total int64         `json:"total"`
+	var list models.BookList
+	if err := c.values.decode(data, &list); err != nil {
+		return nil, generation, err
 	}
 
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, 0, err
+	return &list, generation, nil
+}
+
+func (c *RedisCache) SetBooksList(ctx context.Context, generation ListGeneration, filter models.BookFilter, page, pageSize int, list *models.BookList) error {
+	data, err := c.values.encode(list)
+	if err != nil {
+		return err
 	}
+	key := booksListKey(generation.shared, filter, page, pageSize)
 
-	return result.Books, result.Total, nil
+	// Index the page under each of its books so InvalidateBookPages can find
+	// it again
+	pipe := c.client.Pipeline()
+	pipe.Set(ctx, key, data, defaultExpiration)
+	for _, book := range list.Books {
+		indexKey := fmt.Sprintf("%s%d", pageIndexKeyPrefix, book.ID)
+		pipe.SAdd(ctx, indexKey, key)
+		pipe.Expire(ctx, indexKey, defaultExpiration)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
 }
 
-func (c *RedisCache) SetBooksList(ctx context.Context, books []models.Book, total int64, page, pageSize int) error {
-	data, err := json.Marshal(struct {
-		Books []models.Book `json:"books"`
-		Total int64         `json:"total"`
-	}{
-		Books: books,
-		Total: total,
-	})
+// InvalidateBookPages deletes the cached list pages the book appears on
+func (c *RedisCache) InvalidateBookPages(ctx context.Context, bookID uint) error {
+	indexKey := fmt.Sprintf("%s%d", pageIndexKeyPrefix, bookID)
+	pages, err := c.client.SMembers(ctx, indexKey).Result()
 	if err != nil {
 		return err
 	}
-
-	key := fmt.Sprintf("%s%d:%d", bookListKeyPrefix, page, pageSize)
-	return c.client.Set(ctx, key, data, defaultExpiration).Err()
+	return unlinkKeys(ctx, c.client, append(pages, indexKey))
 }
 
+// InvalidateBooksList moves list and stats keys to a new generation. Keys
+// of older generations are no longer read and are removed by
+// RemoveStaleLists or when they expire.
 func (c *RedisCache) InvalidateBooksList(ctx context.Context) error {
-	pattern := bookListKeyPrefix + "*"
-	keys, err := c.client.Keys(ctx, pattern).Result()
+	return c.client.Incr(ctx, listGenerationKey).Err()
+}
+
+// RemoveStaleLists deletes list and stats keys left behind by earlier
+// generations. It walks the keyspace of every node with SCAN so Redis is
+// never blocked for long, and reports how many keys it deleted.
+func (c *RedisCache) RemoveStaleLists(ctx context.Context) (int, error) {
+	generation, err := c.listGeneration(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed atomic.Int64
+	err = forEachNode(ctx, c.client, func(ctx context.Context, node redis.UniversalClient) error {
+		for _, prefix := range []string{bookListKeyPrefix, statsKeyPrefix} {
+			iter := node.Scan(ctx, 0, prefix+"*", cleanupScanCount).Iterator()
+			var stale []string
+			for iter.Next(ctx) {
+				key := iter.Val()
+				if keyGeneration(key, prefix) < generation {
+					stale = append(stale, key)
+				}
+				if len(stale) >= cleanupScanCount {
+					if err := unlinkKeys(ctx, node, stale); err != nil {
+						return err
+					}
+					removed.Add(int64(len(stale)))
+					stale = stale[:0]
+				}
+			}
+			if err := iter.Err(); err != nil {
+				return err
+			}
+			if err := unlinkKeys(ctx, node, stale); err != nil {
+				return err
+			}
+			removed.Add(int64(len(stale)))
+		}
+
+		// Forget pages of older generations in the per-book page indexes
+		iter := node.Scan(ctx, 0, pageIndexKeyPrefix+"*", cleanupScanCount).Iterator()
+		for iter.Next(ctx) {
+			pages, err := node.SMembers(ctx, iter.Val()).Result()
+			if err != nil {
+				return err
+			}
+			var stale []interface{}
+			for _, page := range pages {
+				if keyGeneration(page, bookListKeyPrefix) < generation {
+					stale = append(stale, page)
+				}
+			}
+			if len(stale) > 0 {
+				if err := node.SRem(ctx, iter.Val(), stale...).Err(); err != nil {
+					return err
+				}
+			}
+		}
+		return iter.Err()
+	})
+
+	return int(removed.Load()), err
+}
+
+// listGeneration returns the current list key generation; it is zero until
+// the lists are first invalidated
+func (c *RedisCache) listGeneration(ctx context.Context) (int64, error) {
+	generation, err := c.client.Get(ctx, listGenerationKey).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return generation, err
+}
+
+// booksListKey builds the cache key for a page of a filtered listing. Keys
+// embed the generation they were written in so invalidating every filter
+// combination, including its facets, is a single INCR.
+func booksListKey(generation int64, filter models.BookFilter, page, pageSize int) string {
+	key := fmt.Sprintf("%s%d:%d:%d", bookListKeyPrefix, generation, page, pageSize)
+	if filterKey := filter.CacheKey(); filterKey != "" {
+		key += ":" + filterKey
+	}
+	return key
+}
+
+func statsKey(generation int64, filter models.StatsFilter) string {
+	return fmt.Sprintf("%s%d:%s", statsKeyPrefix, generation, filter.CacheKey())
+}
+
+// keyGeneration reads the generation back out of a list or stats key. Keys
+// it cannot parse, such as those written before generations were used,
+// report -1 so they are treated as stale.
+func keyGeneration(key, prefix string) int64 {
+	rest := strings.TrimPrefix(key, prefix)
+	if i := strings.IndexByte(rest, ':'); i >= 0 {
+		rest = rest[:i]
+	}
+	generation, err := strconv.ParseInt(rest, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return generation
+}
+
+func (c *RedisCache) GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, ListGeneration, error) {
+	shared, err := c.listGeneration(ctx)
+	if err != nil {
+		return nil, ListGeneration{}, err
+	}
+	generation := ListGeneration{shared: shared}
+
+	data, err := c.client.Get(ctx, statsKey(shared, filter)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, generation, nil
+		}
+		return nil, generation, err
+	}
+
+	var stats models.CatalogueStats
+	if err := c.values.decode(data, &stats); err != nil {
+		return nil, generation, err
+	}
+
+	return &stats, generation, nil
+}
+
+func (c *RedisCache) SetStats(ctx context.Context, generation ListGeneration, filter models.StatsFilter, stats *models.CatalogueStats) error {
+	data, err := c.values.encode(stats)
 	if err != nil {
 		return err
 	}
+	return c.client.Set(ctx, statsKey(generation.shared, filter), data, defaultExpiration).Err()
+}
 
-	if len(keys) > 0 {
-		return c.client.Del(ctx, keys...).Err()
+func (c *RedisCache) GetAvailableCopies(ctx context.Context, bookIDs []uint) (map[uint]int, error) {
+	counts := make(map[uint]int, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return counts, nil
 	}
 
-	return nil
+	keys := make([]string, len(bookIDs))
+	for i, id := range bookIDs {
+		keys[i] = fmt.Sprintf("%s%d", availableKeyPrefix, id)
+	}
+
+	// One GET per key rather than MGET, which a cluster rejects for keys in
+	// different slots
+	cmds := make([]*redis.StringCmd, len(keys))
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	for i, cmd := range cmds {
+		count, err := cmd.Int()
+		if err != nil {
+			continue
+		}
+		counts[bookIDs[i]] = count
+	}
+	return counts, nil
 }
 
+func (c *RedisCache) SetAvailableCopies(ctx context.Context, counts map[uint]int) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for id, count := range counts {
+		pipe.Set(ctx, fmt.Sprintf("%s%d", availableKeyPrefix, id), count, defaultExpiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisCache) DeleteAvailableCopies(ctx context.Context, bookID uint) error {
+	key := fmt.Sprintf("%s%d", availableKeyPrefix, bookID)
+	return c.client.Del(ctx, key).Err()
+}
+
+func (c *RedisCache) TryLock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	token := newLockToken()
+	ok, err := c.client.SetNX(ctx, lockKeyPrefix+key, token, ttl).Result()
+	if err != nil || !ok {
+		return "", err
+	}
+	return token, nil
+}
+
+func (c *RedisCache) Unlock(ctx context.Context, key, token string) error {
+	return unlockScript.Run(ctx, c.client, []string{lockKeyPrefix + key}, token).Err()
+}
+
+func (c *RedisCache) InspectBook(ctx context.Context, id uint) (*BookEntry, time.Duration, error) {
+	key := fmt.Sprintf("%s%d", bookKeyPrefix, id)
+
+	var get *redis.StringCmd
+	var ttl *redis.DurationCmd
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		ttl = pipe.PTTL(ctx, key)
+		return nil
+	})
+	if err == redis.Nil {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := get.Bytes()
+	if err != nil {
+		return nil, 0, err
+	}
+	entry, err := decodeBookEntry(data, c.values.decode)
+	return entry, ttl.Val(), err
+}
+
+func (c *RedisCache) DeleteKey(ctx context.Context, key string) error {
+	return c.client.Unlink(ctx, key).Err()
+}
+
+// Clear flushes every node, then moves the list generation past the one in
+// use before, so a page loaded before the flush is never stored under a
+// generation that becomes current again
 func (c *RedisCache) Clear(ctx context.Context) error {
-	return c.client.FlushDB(ctx).Err()
+	generation, err := c.listGeneration(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = forEachNode(ctx, c.client, func(ctx context.Context, node redis.UniversalClient) error {
+		return node.FlushDB(ctx).Err()
+	})
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, listGenerationKey, generation+1, 0).Err()
 }
 
 func (c *RedisCache) Close() error {
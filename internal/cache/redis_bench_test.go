@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// BenchmarkInvalidateBooksList compares dropping every cached list page by
+// matching their keys, as the cache used to, with bumping the generation
+// counter. The cost of the first grows with the number of cached pages.
+func BenchmarkInvalidateBooksList(b *testing.B) {
+	for _, pages := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("keys/pages=%d", pages), func(b *testing.B) {
+			c := newBenchRedisCache(b)
+			ctx := context.Background()
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fillListPages(b, c.client, pages)
+				b.StartTimer()
+
+				if err := invalidateByKeys(ctx, c.client); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("generation/pages=%d", pages), func(b *testing.B) {
+			c := newBenchRedisCache(b)
+			ctx := context.Background()
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fillListPages(b, c.client, pages)
+				b.StartTimer()
+
+				if err := c.InvalidateBooksList(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func newBenchRedisCache(b *testing.B) *RedisCache {
+	b.Helper()
+
+	server := miniredis.RunT(b)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	b.Cleanup(func() { client.Close() })
+
+	return &RedisCache{client: client}
+}
+
+// fillListPages caches pages list pages and as many stats entries
+func fillListPages(b *testing.B, client redis.UniversalClient, pages int) {
+	b.Helper()
+
+	ctx := context.Background()
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i := 0; i < pages; i++ {
+			pipe.Set(ctx, fmt.Sprintf("%s0:%d:20", bookListKeyPrefix, i+1), "{}", defaultExpiration)
+			pipe.Set(ctx, fmt.Sprintf("%s0:%d", statsKeyPrefix, i), "{}", defaultExpiration)
+		}
+		return nil
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
+// invalidateByKeys is the former InvalidateBooksList, which listed the keys
+// under each prefix with KEYS and deleted them
+func invalidateByKeys(ctx context.Context, client redis.UniversalClient) error {
+	var keys []string
+	for _, prefix := range []string{bookListKeyPrefix, statsKeyPrefix} {
+		matched, err := client.Keys(ctx, prefix+"*").Result()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, matched...)
+	}
+
+	if len(keys) > 0 {
+		return client.Del(ctx, keys...).Err()
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// newRedisClient connects to a single server, a master found through
+// Sentinel, or a cluster, as selected by cfg.Mode
+func newRedisClient(cfg config.RedisConfig) (redis.UniversalClient, error) {
+	addrs := cfg.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{net.JoinHostPort(cfg.Host, cfg.Port)}
+	}
+
+	opts := &redis.UniversalOptions{
+		Addrs:            addrs,
+		MasterName:       cfg.MasterName,
+		SentinelUsername: cfg.SentinelUsername,
+		SentinelPassword: cfg.SentinelPassword,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		DB:               cfg.DB,
+		PoolSize:         cfg.PoolSize,
+		MinIdleConns:     cfg.MinIdleConns,
+		PoolTimeout:      cfg.PoolTimeout,
+		DialTimeout:      cfg.DialTimeout,
+		ReadTimeout:      cfg.ReadTimeout,
+		WriteTimeout:     cfg.WriteTimeout,
+		MaxRetries:       cfg.MaxRetries,
+		MinRetryBackoff:  cfg.MinRetryBackoff,
+		MaxRetryBackoff:  cfg.MaxRetryBackoff,
+	}
+
+	if cfg.TLS {
+		tlsConfig, err := redisTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	switch cfg.Mode {
+	case "standalone":
+		return redis.NewClient(opts.Simple()), nil
+	case "sentinel":
+		if cfg.MasterName == "" {
+			return nil, fmt.Errorf("REDIS_SENTINEL_MASTER is required in sentinel mode")
+		}
+		return redis.NewFailoverClient(opts.Failover()), nil
+	case "cluster":
+		return redis.NewClusterClient(opts.Cluster()), nil
+	default:
+		return nil, fmt.Errorf("unknown Redis mode %q", cfg.Mode)
+	}
+}
+
+func redisTLSConfig(cfg config.RedisConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.TLSSkipVerify,
+	}
+
+	if cfg.TLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Redis CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in Redis CA file %s", cfg.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// forEachNode runs fn against every master holding data: each shard of a
+// cluster, concurrently, or the one server otherwise. Commands that only see
+// a single node, such as SCAN and FLUSHDB, must go through it.
+func forEachNode(ctx context.Context, client redis.UniversalClient, fn func(ctx context.Context, node redis.UniversalClient) error) error {
+	if cluster, ok := client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return fn(ctx, node)
+		})
+	}
+	return fn(ctx, client)
+}
+
+// unlinkKeys deletes keys with one command per key so they may live in
+// different cluster slots; a cluster pipeline routes each to its node
+func unlinkKeys(ctx context.Context, client redis.UniversalClient, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Unlink(ctx, key)
+		}
+		return nil
+	})
+	return err
+}
+
+// PoolStats returns the connection pool statistics of the Redis client
+// behind c, or nil if c does not use Redis
+func PoolStats(c Cache) *redis.PoolStats {
+	switch c := c.(type) {
+	case *RedisCache:
+		return c.client.PoolStats()
+	case *TieredCache:
+		return c.remote.client.PoolStats()
+	case *InstrumentedCache:
+		return PoolStats(c.next)
+	case *GuardedCache:
+		return PoolStats(c.next)
+	case *FallbackCache:
+		return PoolStats(c.Unwrap())
+	default:
+		return nil
+	}
+}
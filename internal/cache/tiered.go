@@ -0,0 +1,334 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+type invalidationOp string
+
+const (
+	invalidateBook      invalidationOp = "book"
+	invalidateAvailable invalidationOp = "available"
+	invalidatePages     invalidationOp = "pages"
+	invalidateKey       invalidationOp = "key"
+	invalidateLists     invalidationOp = "lists"
+	invalidateAll       invalidationOp = "all"
+)
+
+// invalidation is broadcast to every instance when a shared entry changes
+type invalidation struct {
+	Origin string         `json:"origin"`
+	Op     invalidationOp `json:"op"`
+	ID     uint           `json:"id,omitempty"`
+	Key    string         `json:"key,omitempty"`
+	// Version is set when a book was replaced rather than deleted; only
+	// older local copies are dropped
+	Version int64 `json:"version,omitempty"`
+}
+
+// TieredCache keeps a small, short-lived in-process cache in front of
+// Redis. Reads are served locally when possible; deletes and list
+// invalidations go to Redis and are broadcast over pub/sub so every
+// instance evicts its local copy. A missed broadcast leaves an instance
+// serving a stale entry for at most the local TTL.
+type TieredCache struct {
+	local      *MemoryCache
+	remote     *RedisCache
+	channel    string
+	instanceID string
+	pubsub     *redis.PubSub
+}
+
+func NewTieredCache(cfg *config.Config) (*TieredCache, error) {
+	remote, err := NewRedisCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		remote.Close()
+		return nil, err
+	}
+
+	c := &TieredCache{
+		local:      newMemoryCache(cfg.Cache.MaxBytes, cfg.Cache.LocalTTL, cfg.Cache.NegativeTTL),
+		remote:     remote,
+		channel:    cfg.Cache.InvalidationChannel,
+		instanceID: hex.EncodeToString(id),
+	}
+
+	// Wait for the subscription to be confirmed so no invalidation sent
+	// after startup is missed
+	c.pubsub = remote.client.Subscribe(context.Background(), c.channel)
+	if _, err := c.pubsub.Receive(context.Background()); err != nil {
+		c.pubsub.Close()
+		remote.Close()
+		return nil, &unavailableError{err: fmt.Errorf("failed to subscribe to cache invalidations: %w", err)}
+	}
+	go c.listen()
+
+	return c, nil
+}
+
+func (c *TieredCache) GetBook(ctx context.Context, id uint) (*BookEntry, error) {
+	if entry, err := c.local.GetBook(ctx, id); err == nil && entry != nil {
+		return entry, nil
+	}
+
+	entry, err := c.remote.GetBook(ctx, id)
+	if err != nil || entry == nil {
+		return entry, err
+	}
+	c.local.setBookEntry(id, entry)
+	return entry, nil
+}
+
+// SetBook writes the book through to Redis and tells the other instances
+// to drop local copies older than it
+func (c *TieredCache) SetBook(ctx context.Context, book *models.Book, loadTime time.Duration) error {
+	if err := c.remote.SetBook(ctx, book, loadTime); err != nil {
+		return err
+	}
+	c.local.SetBook(ctx, book, loadTime)
+	return c.publishVersion(ctx, invalidateBook, book.ID, book.Version)
+}
+
+func (c *TieredCache) SetBookMissing(ctx context.Context, id uint) error {
+	if err := c.remote.SetBookMissing(ctx, id); err != nil {
+		return err
+	}
+	return c.local.SetBookMissing(ctx, id)
+}
+
+func (c *TieredCache) DeleteBook(ctx context.Context, id uint) error {
+	c.local.DeleteBook(ctx, id)
+	if err := c.remote.DeleteBook(ctx, id); err != nil {
+		return err
+	}
+	return c.publish(ctx, invalidateBook, id)
+}
+
+func (c *TieredCache) GetBookIDByISBN(ctx context.Context, isbn string) (uint, error) {
+	if id, err := c.local.GetBookIDByISBN(ctx, isbn); err == nil && id != 0 {
+		return id, nil
+	}
+
+	id, err := c.remote.GetBookIDByISBN(ctx, isbn)
+	if err != nil || id == 0 {
+		return id, err
+	}
+	c.local.SetBookISBN(ctx, isbn, id)
+	return id, nil
+}
+
+func (c *TieredCache) SetBookISBN(ctx context.Context, isbn string, id uint) error {
+	if err := c.remote.SetBookISBN(ctx, isbn, id); err != nil {
+		return err
+	}
+	return c.local.SetBookISBN(ctx, isbn, id)
+}
+
+// GetBooksList returns the generations of both tiers it looked in, which
+// SetBooksList hands back to each
+func (c *TieredCache) GetBooksList(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, ListGeneration, error) {
+	list, local, err := c.local.GetBooksList(ctx, filter, page, pageSize)
+	if err == nil && list != nil {
+		return list, local, nil
+	}
+
+	list, generation, err := c.remote.GetBooksList(ctx, filter, page, pageSize)
+	generation.local = local.local
+	if err != nil || list == nil {
+		return list, generation, err
+	}
+	c.local.SetBooksList(ctx, generation, filter, page, pageSize, list)
+	return list, generation, nil
+}
+
+func (c *TieredCache) SetBooksList(ctx context.Context, generation ListGeneration, filter models.BookFilter, page, pageSize int, list *models.BookList) error {
+	if err := c.remote.SetBooksList(ctx, generation, filter, page, pageSize, list); err != nil {
+		return err
+	}
+	return c.local.SetBooksList(ctx, generation, filter, page, pageSize, list)
+}
+
+func (c *TieredCache) InvalidateBookPages(ctx context.Context, bookID uint) error {
+	c.local.InvalidateBookPages(ctx, bookID)
+	if err := c.remote.InvalidateBookPages(ctx, bookID); err != nil {
+		return err
+	}
+	return c.publish(ctx, invalidatePages, bookID)
+}
+
+func (c *TieredCache) InvalidateBooksList(ctx context.Context) error {
+	c.local.InvalidateBooksList(ctx)
+	if err := c.remote.InvalidateBooksList(ctx); err != nil {
+		return err
+	}
+	return c.publish(ctx, invalidateLists, 0)
+}
+
+func (c *TieredCache) GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, ListGeneration, error) {
+	stats, local, err := c.local.GetStats(ctx, filter)
+	if err == nil && stats != nil {
+		return stats, local, nil
+	}
+
+	stats, generation, err := c.remote.GetStats(ctx, filter)
+	generation.local = local.local
+	if err != nil || stats == nil {
+		return stats, generation, err
+	}
+	c.local.SetStats(ctx, generation, filter, stats)
+	return stats, generation, nil
+}
+
+func (c *TieredCache) SetStats(ctx context.Context, generation ListGeneration, filter models.StatsFilter, stats *models.CatalogueStats) error {
+	if err := c.remote.SetStats(ctx, generation, filter, stats); err != nil {
+		return err
+	}
+	return c.local.SetStats(ctx, generation, filter, stats)
+}
+
+func (c *TieredCache) GetAvailableCopies(ctx context.Context, bookIDs []uint) (map[uint]int, error) {
+	counts, _ := c.local.GetAvailableCopies(ctx, bookIDs)
+
+	var missing []uint
+	for _, id := range bookIDs {
+		if _, ok := counts[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return counts, nil
+	}
+
+	found, err := c.remote.GetAvailableCopies(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	c.local.SetAvailableCopies(ctx, found)
+	for id, count := range found {
+		counts[id] = count
+	}
+	return counts, nil
+}
+
+func (c *TieredCache) SetAvailableCopies(ctx context.Context, counts map[uint]int) error {
+	if err := c.remote.SetAvailableCopies(ctx, counts); err != nil {
+		return err
+	}
+	return c.local.SetAvailableCopies(ctx, counts)
+}
+
+func (c *TieredCache) DeleteAvailableCopies(ctx context.Context, bookID uint) error {
+	c.local.DeleteAvailableCopies(ctx, bookID)
+	if err := c.remote.DeleteAvailableCopies(ctx, bookID); err != nil {
+		return err
+	}
+	return c.publish(ctx, invalidateAvailable, bookID)
+}
+
+// TryLock takes the lock in Redis so it is shared by every instance
+func (c *TieredCache) TryLock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return c.remote.TryLock(ctx, key, ttl)
+}
+
+func (c *TieredCache) Unlock(ctx context.Context, key, token string) error {
+	return c.remote.Unlock(ctx, key, token)
+}
+
+func (c *TieredCache) RemoveStaleLists(ctx context.Context) (int, error) {
+	removed, _ := c.local.RemoveStaleLists(ctx)
+	remoteRemoved, err := c.remote.RemoveStaleLists(ctx)
+	return removed + remoteRemoved, err
+}
+
+// InspectBook reports the entry shared through Redis rather than this
+// instance's local copy
+func (c *TieredCache) InspectBook(ctx context.Context, id uint) (*BookEntry, time.Duration, error) {
+	return c.remote.InspectBook(ctx, id)
+}
+
+func (c *TieredCache) DeleteKey(ctx context.Context, key string) error {
+	c.local.DeleteKey(ctx, key)
+	if err := c.remote.DeleteKey(ctx, key); err != nil {
+		return err
+	}
+	return c.send(ctx, invalidation{Origin: c.instanceID, Op: invalidateKey, Key: key})
+}
+
+func (c *TieredCache) Clear(ctx context.Context) error {
+	c.local.Clear(ctx)
+	if err := c.remote.Clear(ctx); err != nil {
+		return err
+	}
+	return c.publish(ctx, invalidateAll, 0)
+}
+
+func (c *TieredCache) Close() error {
+	c.pubsub.Close()
+	return c.remote.Close()
+}
+
+func (c *TieredCache) publish(ctx context.Context, op invalidationOp, id uint) error {
+	return c.publishVersion(ctx, op, id, 0)
+}
+
+func (c *TieredCache) publishVersion(ctx context.Context, op invalidationOp, id uint, version int64) error {
+	return c.send(ctx, invalidation{Origin: c.instanceID, Op: op, ID: id, Version: version})
+}
+
+func (c *TieredCache) send(ctx context.Context, inv invalidation) error {
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	return c.remote.client.Publish(ctx, c.channel, data).Err()
+}
+
+// listen applies invalidations broadcast by other instances until the
+// subscription is closed
+func (c *TieredCache) listen() {
+	ctx := context.Background()
+	for msg := range c.pubsub.Channel() {
+		var inv invalidation
+		if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+			log.Printf("Failed to decode cache invalidation: %v\n", err)
+			continue
+		}
+		if inv.Origin == c.instanceID {
+			continue
+		}
+
+		switch inv.Op {
+		case invalidateBook:
+			if inv.Version > 0 {
+				c.local.deleteBookBefore(inv.ID, inv.Version)
+			} else {
+				c.local.DeleteBook(ctx, inv.ID)
+			}
+		case invalidateAvailable:
+			c.local.DeleteAvailableCopies(ctx, inv.ID)
+		case invalidatePages:
+			c.local.InvalidateBookPages(ctx, inv.ID)
+		case invalidateKey:
+			c.local.DeleteKey(ctx, inv.Key)
+		case invalidateLists:
+			c.local.InvalidateBooksList(ctx)
+		case invalidateAll:
+			c.local.Clear(ctx)
+		}
+	}
+}
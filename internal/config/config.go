@@ -5,20 +5,32 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	Kafka    KafkaConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Redis       RedisConfig
+	Cache       CacheConfig
+	Kafka       KafkaConfig
+	Idempotency IdempotencyConfig
+	Storage     StorageConfig
+	Covers      CoverConfig
+	Loans       LoanConfig
+	Holds       HoldConfig
+	Reviews     ReviewConfig
+	Admin       AdminConfig
 }
 
 type ServerConfig struct {
 	Port string
 	Mode string
+	// MetricsPort serves Prometheus metrics apart from the API, so they
+	// are not exposed with it; empty disables them
+	MetricsPort string
 }
 
 type DatabaseConfig struct {
@@ -31,15 +43,157 @@ type DatabaseConfig struct {
 }
 
 type RedisConfig struct {
-	Host     string
-	Port     string
+	// Mode is "standalone", "sentinel" or "cluster"
+	Mode string
+	Host string
+	Port string
+	// Addrs lists the sentinels or cluster seed nodes, or the server in
+	// standalone mode. It defaults to Host:Port.
+	Addrs []string
+	// Name of the master monitored by the sentinels
+	MasterName       string
+	SentinelUsername string
+	SentinelPassword string
+	// Username selects an ACL user; empty authenticates as the default user
+	Username string
 	Password string
-	DB       int
+	// DB is ignored in cluster mode, which only has database 0
+	DB int
+
+	TLS bool
+	// PEM file with the CA that signed the server certificate; the system
+	// roots are used when empty
+	TLSCAFile     string
+	TLSSkipVerify bool
+
+	PoolSize     int
+	MinIdleConns int
+	PoolTimeout  time.Duration
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	// Retries after a failed command, with exponential backoff between the
+	// two bounds
+	MaxRetries      int
+	MinRetryBackoff time.Duration
+	MaxRetryBackoff time.Duration
+}
+
+type CacheConfig struct {
+	// Driver is "redis", "memory" or "tiered". The memory driver keeps
+	// everything in process and suits development and single instance
+	// deployments; tiered keeps a short-lived local copy in front of Redis.
+	Driver string
+	// Largest total size of the in-process entries in bytes
+	MaxBytes int64
+	// How long the tiered driver keeps entries locally
+	LocalTTL time.Duration
+	// How long a lookup for a book that does not exist is remembered
+	NegativeTTL time.Duration
+	// Scales how early popular books are refreshed before their cache entry
+	// expires; 0 disables early refreshes
+	EarlyExpiryBeta float64
+	// Pub/sub channel the tiered driver broadcasts invalidations on
+	InvalidationChannel string
+	// How often entries of invalidated list generations are swept
+	CleanupInterval time.Duration
+	// Codec is "json", "msgpack" or "protobuf" and Compression is "none",
+	// "zstd" or "snappy". They apply to values written to Redis; values in
+	// any format are always readable.
+	Codec       string
+	Compression string
+	// Smallest encoded value in bytes that is compressed
+	CompressionThreshold int
+
+	// Longest a request waits on a single cache call before treating it as
+	// a miss
+	Timeout time.Duration
+	// Consecutive failed calls that open the circuit breaker, and how long
+	// it then bypasses the cache before trying it again
+	BreakerFailures int
+	BreakerCooldown time.Duration
+	// Longest wait between attempts to connect to a cache that was down at
+	// startup
+	ReconnectInterval time.Duration
 }
 
 type KafkaConfig struct {
 	Brokers []string
 	Topic   string
+
+	// Longest a request waits on publishing an event
+	PublishTimeout time.Duration
+	// Consecutive failed publishes that open the circuit breaker, and how
+	// long events are then dropped before trying Kafka again
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+type IdempotencyConfig struct {
+	// How long a completed response is kept for replay
+	TTL time.Duration
+	// How long an in-flight request holds its key before it is released
+	LockTTL time.Duration
+}
+
+type StorageConfig struct {
+	// Driver is "filesystem" or "s3"
+	Driver string
+	// Public URL prefix blobs are served from. Defaults to the API's own
+	// /blobs route for the filesystem driver and to the bucket URL for s3.
+	BaseURL string
+
+	// Filesystem driver
+	Path string
+
+	// S3 driver; any S3-compatible service such as MinIO
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3UseSSL    bool
+}
+
+type CoverConfig struct {
+	// Largest accepted upload in bytes
+	MaxSize int
+	// Longest edge of the generated variants in pixels
+	ThumbnailSize int
+	MediumSize    int
+}
+
+type LoanConfig struct {
+	// Loan length for a new checkout; loans fall due at the end of that day
+	Period time.Duration
+	// How far each renewal pushes the due date
+	RenewalPeriod time.Duration
+	MaxRenewals   int
+	// Most open loans a member may hold, overdue ones included
+	MaxPerMember int
+	// Location whose end of day is used for due dates
+	TimeZone *time.Location
+	// How often the scheduler looks for loans that have become overdue
+	OverdueCheckInterval time.Duration
+}
+
+type HoldConfig struct {
+	// How long a copy set aside for a hold waits to be collected
+	PickupPeriod time.Duration
+	// How often the scheduler expires uncollected holds
+	ExpiryCheckInterval time.Duration
+}
+
+type ReviewConfig struct {
+	// Hold new and edited reviews for moderation instead of publishing them
+	// immediately
+	RequireApproval bool
+}
+
+type AdminConfig struct {
+	// Token the admin endpoints expect in the X-Admin-Token header. The
+	// endpoints are disabled while it is empty.
+	Token string
 }
 
 func LoadConfig(envFile string) (*Config, error) {
@@ -53,8 +207,9 @@ func LoadConfig(envFile string) (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Port: getEnv("PORT", "8080"),
-			Mode: getEnv("GIN_MODE", "debug"),
+			Port:        getEnv("PORT", "8080"),
+			Mode:        getEnv("GIN_MODE", "debug"),
+			MetricsPort: getEnv("METRICS_PORT", "9090"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -65,14 +220,90 @@ func LoadConfig(envFile string) (*Config, error) {
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		},
 		Redis: RedisConfig{
-			Host:     getEnv("REDIS_HOST", "localhost"),
-			Port:     getEnv("REDIS_PORT", "6379"),
-			Password: getEnv("REDIS_PASSWORD", ""),
-			DB:       getEnvAsInt("REDIS_DB", 0),
+			Mode:             getEnv("REDIS_MODE", "standalone"),
+			Host:             getEnv("REDIS_HOST", "localhost"),
+			Port:             getEnv("REDIS_PORT", "6379"),
+			Addrs:            getEnvAsList("REDIS_ADDRS"),
+			MasterName:       getEnv("REDIS_SENTINEL_MASTER", ""),
+			SentinelUsername: getEnv("REDIS_SENTINEL_USERNAME", ""),
+			SentinelPassword: getEnv("REDIS_SENTINEL_PASSWORD", ""),
+			Username:         getEnv("REDIS_USERNAME", ""),
+			Password:         getEnv("REDIS_PASSWORD", ""),
+			DB:               getEnvAsInt("REDIS_DB", 0),
+			TLS:              getEnvAsBool("REDIS_TLS", false),
+			TLSCAFile:        getEnv("REDIS_TLS_CA_FILE", ""),
+			TLSSkipVerify:    getEnvAsBool("REDIS_TLS_SKIP_VERIFY", false),
+			PoolSize:         getEnvAsInt("REDIS_POOL_SIZE", 0),
+			MinIdleConns:     getEnvAsInt("REDIS_MIN_IDLE_CONNS", 0),
+			PoolTimeout:      getEnvAsDuration("REDIS_POOL_TIMEOUT", 0),
+			DialTimeout:      getEnvAsDuration("REDIS_DIAL_TIMEOUT", 5*time.Second),
+			ReadTimeout:      getEnvAsDuration("REDIS_READ_TIMEOUT", 3*time.Second),
+			WriteTimeout:     getEnvAsDuration("REDIS_WRITE_TIMEOUT", 3*time.Second),
+			MaxRetries:       getEnvAsInt("REDIS_MAX_RETRIES", 3),
+			MinRetryBackoff:  getEnvAsDuration("REDIS_MIN_RETRY_BACKOFF", 8*time.Millisecond),
+			MaxRetryBackoff:  getEnvAsDuration("REDIS_MAX_RETRY_BACKOFF", 512*time.Millisecond),
+		},
+		Cache: CacheConfig{
+			Driver:               getEnv("CACHE_DRIVER", "redis"),
+			MaxBytes:             int64(getEnvAsInt("CACHE_MAX_BYTES", 64<<20)),
+			LocalTTL:             getEnvAsDuration("CACHE_LOCAL_TTL", 5*time.Second),
+			NegativeTTL:          getEnvAsDuration("CACHE_NEGATIVE_TTL", 30*time.Second),
+			EarlyExpiryBeta:      getEnvAsFloat("CACHE_EARLY_EXPIRY_BETA", 1),
+			InvalidationChannel:  getEnv("CACHE_INVALIDATION_CHANNEL", "cache:invalidations"),
+			CleanupInterval:      getEnvAsDuration("CACHE_CLEANUP_INTERVAL", 10*time.Minute),
+			Codec:                getEnv("CACHE_CODEC", "json"),
+			Compression:          getEnv("CACHE_COMPRESSION", "none"),
+			CompressionThreshold: getEnvAsInt("CACHE_COMPRESSION_THRESHOLD", 1024),
+			Timeout:              getEnvAsDuration("CACHE_TIMEOUT", 100*time.Millisecond),
+			BreakerFailures:      getEnvAsInt("CACHE_BREAKER_FAILURES", 5),
+			BreakerCooldown:      getEnvAsDuration("CACHE_BREAKER_COOLDOWN", 10*time.Second),
+			ReconnectInterval:    getEnvAsDuration("CACHE_RECONNECT_INTERVAL", 30*time.Second),
 		},
 		Kafka: KafkaConfig{
 			Brokers: strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
 			Topic:   getEnv("KAFKA_TOPIC", "book_events"),
+
+			PublishTimeout:  getEnvAsDuration("KAFKA_PUBLISH_TIMEOUT", time.Second),
+			BreakerFailures: getEnvAsInt("KAFKA_BREAKER_FAILURES", 5),
+			BreakerCooldown: getEnvAsDuration("KAFKA_BREAKER_COOLDOWN", 30*time.Second),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:     getEnvAsDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+			LockTTL: getEnvAsDuration("IDEMPOTENCY_LOCK_TTL", 30*time.Second),
+		},
+		Storage: StorageConfig{
+			Driver:      getEnv("STORAGE_DRIVER", "filesystem"),
+			BaseURL:     getEnv("STORAGE_BASE_URL", ""),
+			Path:        getEnv("STORAGE_PATH", "./data/blobs"),
+			S3Endpoint:  getEnv("S3_ENDPOINT", "localhost:9000"),
+			S3Region:    getEnv("S3_REGION", ""),
+			S3Bucket:    getEnv("S3_BUCKET", "books"),
+			S3AccessKey: getEnv("S3_ACCESS_KEY", ""),
+			S3SecretKey: getEnv("S3_SECRET_KEY", ""),
+			S3UseSSL:    getEnvAsBool("S3_USE_SSL", false),
+		},
+		Covers: CoverConfig{
+			MaxSize:       getEnvAsInt("COVER_MAX_SIZE", 5<<20),
+			ThumbnailSize: getEnvAsInt("COVER_THUMBNAIL_SIZE", 160),
+			MediumSize:    getEnvAsInt("COVER_MEDIUM_SIZE", 600),
+		},
+		Loans: LoanConfig{
+			Period:               getEnvAsDuration("LOAN_PERIOD", 14*24*time.Hour),
+			RenewalPeriod:        getEnvAsDuration("LOAN_RENEWAL_PERIOD", 14*24*time.Hour),
+			MaxRenewals:          getEnvAsInt("LOAN_MAX_RENEWALS", 2),
+			MaxPerMember:         getEnvAsInt("LOAN_MAX_PER_MEMBER", 5),
+			TimeZone:             getEnvAsLocation("LOAN_TIME_ZONE", time.UTC),
+			OverdueCheckInterval: getEnvAsDuration("LOAN_OVERDUE_CHECK_INTERVAL", time.Minute),
+		},
+		Holds: HoldConfig{
+			PickupPeriod:        getEnvAsDuration("HOLD_PICKUP_PERIOD", 72*time.Hour),
+			ExpiryCheckInterval: getEnvAsDuration("HOLD_EXPIRY_CHECK_INTERVAL", time.Minute),
+		},
+		Reviews: ReviewConfig{
+			RequireApproval: getEnvAsBool("REVIEWS_REQUIRE_APPROVAL", false),
+		},
+		Admin: AdminConfig{
+			Token: getEnv("ADMIN_TOKEN", ""),
 		},
 	}
 
@@ -86,6 +317,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAsList splits a comma-separated variable, skipping empty items
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intVal, err := strconv.Atoi(value); err == nil {
@@ -94,3 +336,39 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsLocation(key string, defaultValue *time.Location) *time.Location {
+	if value := os.Getenv(key); value != "" {
+		if location, err := time.LoadLocation(value); err == nil {
+			return location
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type CreateAuthorRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+type UpdateAuthorRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+type AuthorResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListAuthorsResponse struct {
+	Authors    []AuthorResponse `json:"authors"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalItems int64            `json:"total_items"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// AuthorSummary is a contributor as embedded in a book response
+type AuthorSummary struct {
+	ID       uint              `json:"id"`
+	Name     string            `json:"name"`
+	Role     models.AuthorRole `json:"role"`
+	Position int               `json:"position"`
+}
+
+// BookAuthorRequest links an existing author to a book. The order of the
+// links in the request is the order the contributors are credited in.
+type BookAuthorRequest struct {
+	AuthorID uint   `json:"author_id" binding:"required"`
+	Role     string `json:"role" binding:"omitempty,oneof=author editor translator"`
+}
+
+// Conversion helpers
+func ToAuthorResponse(author *models.Author) *AuthorResponse {
+	return &AuthorResponse{
+		ID:        author.ID,
+		Name:      author.Name,
+		CreatedAt: author.CreatedAt,
+		UpdatedAt: author.UpdatedAt,
+	}
+}
+
+func ToAuthorResponseList(authors []models.Author) []AuthorResponse {
+	responses := make([]AuthorResponse, len(authors))
+	for i, author := range authors {
+		responses[i] = *ToAuthorResponse(&author)
+	}
+	return responses
+}
+
+func ToAuthorSummaries(links []models.BookAuthor) []AuthorSummary {
+	summaries := make([]AuthorSummary, 0, len(links))
+	for _, link := range links {
+		summary := AuthorSummary{
+			ID:       link.AuthorID,
+			Role:     link.Role,
+			Position: link.Position,
+		}
+		if link.Author != nil {
+			summary.Name = link.Author.Name
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// ToBookAuthors builds the contributor links for a book. Without explicit
+// links, the free-text author credit is split into names that the
+// repository resolves to existing or new authors.
+func ToBookAuthors(credit string, links []BookAuthorRequest) []models.BookAuthor {
+	if len(links) == 0 {
+		names := models.SplitAuthorNames(credit)
+		authors := make([]models.BookAuthor, len(names))
+		for i, name := range names {
+			authors[i] = models.BookAuthor{
+				Role:     models.AuthorRoleAuthor,
+				Position: i,
+				Author:   &models.Author{Name: name},
+			}
+		}
+		return authors
+	}
+
+	authors := make([]models.BookAuthor, len(links))
+	for i, link := range links {
+		role := models.AuthorRole(link.Role)
+		if role == "" {
+			role = models.AuthorRoleAuthor
+		}
+		authors[i] = models.BookAuthor{
+			AuthorID: link.AuthorID,
+			Role:     role,
+			Position: i,
+		}
+	}
+	return authors
+}
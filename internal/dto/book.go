@@ -1,7 +1,8 @@
 package dto
 
 import (
-	"fmt"
+	"encoding/json"
+	"math"
 	"time"
 
 	"github.com/AhmadMuj/books-api-go/internal/errors"
@@ -9,44 +10,135 @@ import (
 )
 
 type CreateBookRequest struct {
-	Title  string `json:"title" binding:"required"`
-	Author string `json:"author" binding:"required"`
-	Year   int    `json:"year" binding:"required,min=1500"`
+	Title       string              `json:"title" binding:"required"`
+	Author      string              `json:"author" binding:"required"`
+	Year        int                 `json:"year" binding:"required,min=1500"`
+	ISBN        string              `json:"isbn" binding:"omitempty,max=20"`
+	Authors     []BookAuthorRequest `json:"authors" binding:"omitempty,dive"`
+	Genres      []uint              `json:"genre_ids"`
+	Tags        []string            `json:"tags" binding:"omitempty,max=20,dive,max=64"`
+	WorkID      *uint               `json:"work_id"`
+	Publisher   string              `json:"publisher" binding:"omitempty,max=255"`
+	Format      string              `json:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook other"`
+	Language    string              `json:"language" binding:"omitempty,bcp47_language_tag"`
+	PageCount   *int                `json:"page_count" binding:"omitempty,min=1"`
+	PublishedOn string              `json:"published_on" binding:"omitempty,datetime=2006-01-02"`
 }
 
+// UpdateBookRequest replaces every detail of a book, so each field must be
+// sent. An empty string, an empty list or null clears a field; work_id
+// cannot be null since every edition belongs to a work.
 type UpdateBookRequest struct {
-	Title  string `json:"title" binding:"required"`
-	Author string `json:"author" binding:"required"`
-	Year   int    `json:"year" binding:"required,min=1500"`
+	Title       string              `json:"title" binding:"required"`
+	Author      string              `json:"author" binding:"required"`
+	Year        int                 `json:"year" binding:"required,min=1500"`
+	ISBN        string              `json:"isbn" binding:"omitempty,max=20"`
+	Authors     []BookAuthorRequest `json:"authors" binding:"omitempty,dive"`
+	Genres      []uint              `json:"genre_ids"`
+	Tags        []string            `json:"tags" binding:"omitempty,max=20,dive,max=64"`
+	WorkID      *uint               `json:"work_id" binding:"required"`
+	Publisher   string              `json:"publisher" binding:"omitempty,max=255"`
+	Format      string              `json:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook other"`
+	Language    string              `json:"language" binding:"omitempty,bcp47_language_tag"`
+	PageCount   *int                `json:"page_count" binding:"omitempty,min=1"`
+	PublishedOn string              `json:"published_on" binding:"omitempty,datetime=2006-01-02"`
+
+	// missing lists the fields left out of the request body
+	missing []string
+}
+
+// updateBookFields are the fields every UpdateBookRequest must include
+var updateBookFields = []string{
+	"title", "author", "year", "isbn", "authors", "genre_ids", "tags", "work_id",
+	"publisher", "format", "language", "page_count", "published_on",
+}
+
+// UnmarshalJSON decodes the request and notes which fields it left out,
+// which the fields alone cannot tell apart from empty ones
+func (r *UpdateBookRequest) UnmarshalJSON(data []byte) error {
+	type request UpdateBookRequest
+	if err := json.Unmarshal(data, (*request)(r)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	r.missing = nil
+	for _, field := range updateBookFields {
+		if _, ok := fields[field]; !ok {
+			r.missing = append(r.missing, field)
+		}
+	}
+	return nil
 }
 
 type BookResponse struct {
-	ID        uint      `json:"id"`
-	Title     string    `json:"title"`
-	Author    string    `json:"author"`
-	Year      int       `json:"year"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint              `json:"id"`
+	Title           string            `json:"title"`
+	Author          string            `json:"author"`
+	Year            int               `json:"year"`
+	ISBN13          string            `json:"isbn_13,omitempty"`
+	ISBN10          string            `json:"isbn_10,omitempty"`
+	WorkID          *uint             `json:"work_id,omitempty"`
+	Publisher       *PublisherSummary `json:"publisher,omitempty"`
+	Format          string            `json:"format,omitempty"`
+	Language        string            `json:"language,omitempty"`
+	PageCount       *int              `json:"page_count,omitempty"`
+	PublishedOn     string            `json:"published_on,omitempty"`
+	CoverURLs       *CoverURLs        `json:"cover_urls,omitempty"`
+	AvailableCopies int               `json:"available_copies"`
+	AverageRating   float64           `json:"average_rating"`
+	RatingCount     int               `json:"rating_count"`
+	Authors         []AuthorSummary   `json:"authors"`
+	Genres          []GenreSummary    `json:"genres"`
+	Tags            []TagSummary      `json:"tags"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
 }
 
 type ListBooksResponse struct {
-	Books      []BookResponse `json:"books"`
-	Page       int            `json:"page"`
-	PageSize   int            `json:"page_size"`
-	TotalItems int64          `json:"total_items"`
-	TotalPages int            `json:"total_pages"`
+	Books      []BookResponse     `json:"books"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalItems int64              `json:"total_items"`
+	TotalPages int                `json:"total_pages"`
+	Facets     *models.BookFacets `json:"facets,omitempty"`
 }
 
 // Conversion helpers
 func ToBookResponse(book *models.Book) *BookResponse {
-	return &BookResponse{
-		ID:        book.ID,
-		Title:     book.Title,
-		Author:    book.Author,
-		Year:      book.Year,
-		CreatedAt: book.CreatedAt,
-		UpdatedAt: book.UpdatedAt,
+	response := &BookResponse{
+		ID:              book.ID,
+		Title:           book.Title,
+		Author:          book.Author,
+		Year:            book.Year,
+		WorkID:          book.WorkID,
+		Publisher:       ToPublisherSummary(book.Publisher),
+		Format:          string(book.Format),
+		Language:        book.Language,
+		PageCount:       book.PageCount,
+		CoverURLs:       ToCoverURLs(book.CoverKey),
+		AvailableCopies: book.AvailableCopies,
+		AverageRating:   math.Round(book.AverageRating*100) / 100,
+		RatingCount:     book.RatingCount,
+		Authors:         ToAuthorSummaries(book.Authors),
+		Genres:          ToGenreSummaries(book.Genres),
+		Tags:            ToTagSummaries(book.Tags),
+		CreatedAt:       book.CreatedAt,
+		UpdatedAt:       book.UpdatedAt,
+	}
+	if book.ISBN13 != nil {
+		response.ISBN13 = *book.ISBN13
+	}
+	if book.ISBN10 != nil {
+		response.ISBN10 = *book.ISBN10
 	}
+	if book.PublishedOn != nil {
+		response.PublishedOn = book.PublishedOn.Format(publishedOnLayout)
+	}
+	return response
 }
 
 func ToBookResponseList(books []models.Book) []BookResponse {
@@ -61,21 +153,46 @@ func ToBookResponseList(books []models.Book) []BookResponse {
 func (r *CreateBookRequest) Validate() error {
 	currentYear := time.Now().Year()
 	if r.Year < 1500 || r.Year > currentYear {
-		return errors.NewValidationError(fmt.Sprintf(
-			"book year must be between 1500 and %d",
-			currentYear,
-		))
+		return errors.NewFieldValidationError("year", "range", errors.MsgBookYearRange, 1500, currentYear)
+	}
+
+	if r.ISBN != "" {
+		isbn, err := NormalizeISBN(r.ISBN)
+		if err != nil {
+			return err
+		}
+		r.ISBN = isbn
 	}
 	return nil
 }
 
 func (r *UpdateBookRequest) Validate() error {
+	if len(r.missing) > 0 {
+		return errors.NewFieldValidationError(r.missing[0], "required", errors.MsgBookFieldMissing, r.missing[0])
+	}
+
 	currentYear := time.Now().Year()
 	if r.Year < 1500 || r.Year > currentYear {
-		return errors.NewValidationError(fmt.Sprintf(
-			"book year must be between 1500 and %d",
-			currentYear,
-		))
+		return errors.NewFieldValidationError("year", "range", errors.MsgBookYearRange, 1500, currentYear)
+	}
+
+	if r.ISBN != "" {
+		isbn, err := NormalizeISBN(r.ISBN)
+		if err != nil {
+			return err
+		}
+		r.ISBN = isbn
 	}
 	return nil
 }
+
+// ApplyEdition copies the edition details onto book. A book created without
+// a work_id starts a new work.
+func (r *CreateBookRequest) ApplyEdition(book *models.Book) {
+	applyEdition(book, r.WorkID, r.Publisher, r.Format, r.Language, r.PageCount, r.PublishedOn)
+}
+
+// ApplyEdition copies the edition details onto book
+func (r *UpdateBookRequest) ApplyEdition(book *models.Book) {
+	applyEdition(book, r.WorkID, r.Publisher, r.Format, r.Language, r.PageCount, r.PublishedOn)
+}
@@ -0,0 +1,154 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+const (
+	defaultWarmPages = 5
+	defaultWarmBooks = 50
+)
+
+// WarmCacheRequest selects what a warm-up loads. Omitted counts use the
+// defaults; zero skips that part.
+type WarmCacheRequest struct {
+	// Number of pages of the unfiltered book listing, at the default page size
+	Pages *int `json:"pages" binding:"omitempty,min=0,max=100" example:"5"`
+	// Number of most borrowed books
+	Books *int `json:"books" binding:"omitempty,min=0,max=1000" example:"50"`
+}
+
+func (r *WarmCacheRequest) PageCount() int {
+	if r.Pages == nil {
+		return defaultWarmPages
+	}
+	return *r.Pages
+}
+
+func (r *WarmCacheRequest) BookCount() int {
+	if r.Books == nil {
+		return defaultWarmBooks
+	}
+	return *r.Books
+}
+
+type WarmCacheResponse struct {
+	Pages      int     `json:"pages"`
+	Books      int     `json:"books"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+type CacheStatsResponse struct {
+	Driver     string                   `json:"driver"`
+	Operations []CacheOperationResponse `json:"operations"`
+}
+
+type CacheOperationResponse struct {
+	Name   string `json:"name"`
+	Calls  uint64 `json:"calls"`
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	// Lookups made while the circuit breaker was open, which did not reach
+	// the cache
+	Skipped uint64 `json:"skipped"`
+	Errors  uint64 `json:"errors"`
+	// Share of lookups served from the cache, leaving out skipped ones;
+	// omitted for operations that are not lookups
+	HitRatio *float64             `json:"hit_ratio,omitempty"`
+	Latency  CacheLatencyResponse `json:"latency"`
+}
+
+// CacheLatencyResponse summarizes an operation's latency histogram.
+// Percentiles are the upper bounds of the buckets they fall in.
+type CacheLatencyResponse struct {
+	AverageMs float64 `json:"average_ms"`
+	P50Ms     float64 `json:"p50_ms"`
+	P95Ms     float64 `json:"p95_ms"`
+	P99Ms     float64 `json:"p99_ms"`
+	// Buckets count calls at or below each bound, cumulatively
+	Buckets []CacheLatencyBucket `json:"buckets"`
+}
+
+type CacheLatencyBucket struct {
+	// Upper bound in milliseconds; the last bucket has none
+	LeMs  *float64 `json:"le_ms,omitempty"`
+	Count uint64   `json:"count"`
+}
+
+type CachedBookResponse struct {
+	Key string `json:"key"`
+	// Missing is true for an entry recording that no book has the ID
+	Missing bool `json:"missing"`
+	// Seconds until the entry is evicted
+	TTLSeconds float64 `json:"ttl_seconds"`
+	// When the book is due to be reloaded
+	ExpiresAt  time.Time     `json:"expires_at"`
+	LoadTimeMs float64       `json:"load_time_ms"`
+	Book       *BookResponse `json:"book,omitempty"`
+}
+
+// Conversion helpers
+
+func ToCacheStatsResponse(stats cache.Stats) *CacheStatsResponse {
+	response := &CacheStatsResponse{
+		Driver:     stats.Driver,
+		Operations: make([]CacheOperationResponse, len(stats.Operations)),
+	}
+	for i, op := range stats.Operations {
+		buckets := make([]CacheLatencyBucket, len(op.Latency))
+		for j, count := range op.Latency {
+			buckets[j].Count = count
+			if j < len(cache.LatencyBuckets) {
+				bound := milliseconds(cache.LatencyBuckets[j])
+				buckets[j].LeMs = &bound
+			}
+		}
+
+		response.Operations[i] = CacheOperationResponse{
+			Name:     op.Name,
+			Calls:    op.Calls,
+			Hits:     op.Hits,
+			Misses:   op.Misses,
+			Skipped:  op.Skipped,
+			Errors:   op.Errors,
+			HitRatio: op.HitRatio(),
+			Latency: CacheLatencyResponse{
+				AverageMs: milliseconds(op.AverageLatency()),
+				P50Ms:     milliseconds(op.LatencyQuantile(0.5)),
+				P95Ms:     milliseconds(op.LatencyQuantile(0.95)),
+				P99Ms:     milliseconds(op.LatencyQuantile(0.99)),
+				Buckets:   buckets,
+			},
+		}
+	}
+	return response
+}
+
+func ToCachedBookResponse(key string, entry *cache.BookEntry, ttl time.Duration) *CachedBookResponse {
+	response := &CachedBookResponse{
+		Key:        key,
+		Missing:    entry.Book == nil,
+		TTLSeconds: ttl.Seconds(),
+		ExpiresAt:  entry.ExpiresAt,
+		LoadTimeMs: milliseconds(entry.LoadTime),
+	}
+	if entry.Book != nil {
+		response.Book = ToBookResponse(entry.Book)
+	}
+	return response
+}
+
+func ToWarmCacheResponse(warmup *models.CacheWarmup) *WarmCacheResponse {
+	return &WarmCacheResponse{
+		Pages:      warmup.Pages,
+		Books:      warmup.Books,
+		DurationMs: milliseconds(warmup.Duration),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type CreateCopyRequest struct {
+	Barcode   string `json:"barcode" binding:"required,max=64"`
+	Branch    string `json:"branch" binding:"required,max=100"`
+	Location  string `json:"location" binding:"max=100"`
+	Condition string `json:"condition" binding:"omitempty,oneof=new good fair poor damaged"`
+	Status    string `json:"status" binding:"omitempty,oneof=available on_loan on_hold in_repair lost withdrawn"`
+}
+
+type UpdateCopyRequest struct {
+	Barcode   string `json:"barcode" binding:"required,max=64"`
+	Branch    string `json:"branch" binding:"required,max=100"`
+	Location  string `json:"location" binding:"max=100"`
+	Condition string `json:"condition" binding:"required,oneof=new good fair poor damaged"`
+	Status    string `json:"status" binding:"required,oneof=available on_loan on_hold in_repair lost withdrawn"`
+}
+
+type CopyResponse struct {
+	ID        uint      `json:"id"`
+	BookID    uint      `json:"book_id"`
+	Barcode   string    `json:"barcode"`
+	Branch    string    `json:"branch"`
+	Location  string    `json:"location,omitempty"`
+	Condition string    `json:"condition"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListCopiesResponse struct {
+	Copies          []CopyResponse `json:"copies"`
+	TotalCopies     int            `json:"total_copies"`
+	AvailableCopies int            `json:"available_copies"`
+}
+
+// ToNewCopy builds a copy from a create request. New copies default to good
+// condition and available.
+func ToNewCopy(bookID uint, req *CreateCopyRequest) *models.Copy {
+	bookCopy := &models.Copy{
+		BookID:    bookID,
+		Barcode:   req.Barcode,
+		Branch:    req.Branch,
+		Location:  req.Location,
+		Condition: models.CopyCondition(req.Condition),
+		Status:    models.CopyStatus(req.Status),
+	}
+	if bookCopy.Condition == "" {
+		bookCopy.Condition = models.ConditionGood
+	}
+	if bookCopy.Status == "" {
+		bookCopy.Status = models.CopyAvailable
+	}
+	return bookCopy
+}
+
+// Conversion helpers
+func ToCopyResponse(bookCopy *models.Copy) *CopyResponse {
+	return &CopyResponse{
+		ID:        bookCopy.ID,
+		BookID:    bookCopy.BookID,
+		Barcode:   bookCopy.Barcode,
+		Branch:    bookCopy.Branch,
+		Location:  bookCopy.Location,
+		Condition: string(bookCopy.Condition),
+		Status:    string(bookCopy.Status),
+		CreatedAt: bookCopy.CreatedAt,
+		UpdatedAt: bookCopy.UpdatedAt,
+	}
+}
+
+func ToListCopiesResponse(copies []models.Copy) *ListCopiesResponse {
+	response := &ListCopiesResponse{
+		Copies:      make([]CopyResponse, len(copies)),
+		TotalCopies: len(copies),
+	}
+	for i, bookCopy := range copies {
+		response.Copies[i] = *ToCopyResponse(&bookCopy)
+		if bookCopy.Status == models.CopyAvailable {
+			response.AvailableCopies++
+		}
+	}
+	return response
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/AhmadMuj/books-api-go/internal/models"
+
+// CoverURLs links to a book's cover image and its resized variants
+type CoverURLs struct {
+	Original  string `json:"original"`
+	Medium    string `json:"medium"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+var blobURL = func(key string) string { return key }
+
+// RegisterBlobURLs sets how responses turn blob keys into public URLs
+func RegisterBlobURLs(url func(key string) string) {
+	blobURL = url
+}
+
+func ToCoverURLs(coverKey *string) *CoverURLs {
+	if coverKey == nil {
+		return nil
+	}
+	return &CoverURLs{
+		Original:  blobURL(*coverKey),
+		Medium:    blobURL(models.CoverVariantKey(*coverKey, models.CoverMedium)),
+		Thumbnail: blobURL(models.CoverVariantKey(*coverKey, models.CoverThumbnail)),
+	}
+}
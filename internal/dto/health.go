@@ -0,0 +1,38 @@
+package dto
+
+import "github.com/AhmadMuj/books-api-go/internal/models"
+
+type HealthResponse struct {
+	// ok, degraded when an optional component is down, or down when the
+	// database is
+	Status     string                    `json:"status" example:"ok"`
+	Components []ComponentHealthResponse `json:"components"`
+}
+
+type ComponentHealthResponse struct {
+	Name     string `json:"name" example:"cache"`
+	Status   string `json:"status" example:"up"`
+	Critical bool   `json:"critical"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Conversion helpers
+
+func ToHealthResponse(health *models.Health) *HealthResponse {
+	response := &HealthResponse{
+		Status:     string(health.Status),
+		Components: make([]ComponentHealthResponse, len(health.Components)),
+	}
+	for i, component := range health.Components {
+		response.Components[i] = ComponentHealthResponse{
+			Name:     component.Name,
+			Status:   "up",
+			Critical: component.Critical,
+		}
+		if component.Err != nil {
+			response.Components[i].Status = "down"
+			response.Components[i].Error = component.Err.Error()
+		}
+	}
+	return response
+}
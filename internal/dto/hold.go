@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type CreateHoldRequest struct {
+	MemberID string `json:"member_id" binding:"required,max=64"`
+}
+
+type HoldResponse struct {
+	ID       uint   `json:"id"`
+	BookID   uint   `json:"book_id"`
+	MemberID string `json:"member_id"`
+	Status   string `json:"status"`
+	// Position is the hold's place in the queue; omitted once it has left
+	// the queue
+	Position  int        `json:"position,omitempty"`
+	CopyID    *uint      `json:"copy_id,omitempty"`
+	ReadyAt   *time.Time `json:"ready_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ListHoldsResponse is a book's hold queue. Ready holds come first, then
+// waiting holds in the order they will be served.
+type ListHoldsResponse struct {
+	BookID  uint           `json:"book_id"`
+	Holds   []HoldResponse `json:"holds"`
+	Waiting int            `json:"waiting"`
+}
+
+// Conversion helpers
+func ToHoldResponse(hold *models.Hold, position int) *HoldResponse {
+	return &HoldResponse{
+		ID:        hold.ID,
+		BookID:    hold.BookID,
+		MemberID:  hold.MemberID,
+		Status:    string(hold.Status),
+		Position:  position,
+		CopyID:    hold.CopyID,
+		ReadyAt:   hold.ReadyAt,
+		ExpiresAt: hold.ExpiresAt,
+		CreatedAt: hold.CreatedAt,
+	}
+}
+
+func ToListHoldsResponse(bookID uint, holds []models.Hold) *ListHoldsResponse {
+	response := &ListHoldsResponse{
+		BookID: bookID,
+		Holds:  make([]HoldResponse, len(holds)),
+	}
+	for i, hold := range holds {
+		position := 0
+		if hold.Status == models.HoldWaiting {
+			response.Waiting++
+			position = response.Waiting
+		}
+		response.Holds[i] = *ToHoldResponse(&hold, position)
+	}
+	return response
+}
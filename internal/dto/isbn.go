@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+// NormalizeISBN strips hyphens and spaces from an ISBN-10 or ISBN-13,
+// verifies its check digit and returns the ISBN-13 form. ISBN-13s must
+// carry the 978 or 979 prefix, which sets them apart from other EAN-13s.
+func NormalizeISBN(raw string) (string, error) {
+	isbn := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(raw))
+
+	switch {
+	case len(isbn) == 10 && validISBN10(isbn):
+		return isbn10To13(isbn), nil
+	case len(isbn) == 13 && (strings.HasPrefix(isbn, "978") || strings.HasPrefix(isbn, "979")) && validISBN13(isbn):
+		return isbn, nil
+	}
+	return "", errors.NewFieldValidationError("isbn", "isbn", errors.MsgInvalidISBN, raw)
+}
+
+// ISBN10 returns the ISBN-10 form of an ISBN-13, or "" when the number has
+// no ISBN-10 equivalent (979 prefixes).
+func ISBN10(isbn13 string) string {
+	if len(isbn13) != 13 || !strings.HasPrefix(isbn13, "978") {
+		return ""
+	}
+
+	body := isbn13[3:12]
+	sum := 0
+	for i, r := range body {
+		sum += (10 - i) * int(r-'0')
+	}
+
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return body + "X"
+	}
+	return body + string(rune('0'+check))
+}
+
+// ApplyISBN sets both ISBN columns of book from a normalized ISBN-13
+func ApplyISBN(book *models.Book, isbn13 string) {
+	book.ISBN13, book.ISBN10 = nil, nil
+	if isbn13 == "" {
+		return
+	}
+
+	book.ISBN13 = &isbn13
+	if isbn10 := ISBN10(isbn13); isbn10 != "" {
+		book.ISBN10 = &isbn10
+	}
+}
+
+func validISBN10(isbn string) bool {
+	sum := 0
+	for i, r := range isbn {
+		var digit int
+		switch {
+		case r >= '0' && r <= '9':
+			digit = int(r - '0')
+		case r == 'X' && i == 9:
+			digit = 10
+		default:
+			return false
+		}
+		sum += (10 - i) * digit
+	}
+	return sum%11 == 0
+}
+
+func validISBN13(isbn string) bool {
+	sum := 0
+	for i, r := range isbn {
+		if r < '0' || r > '9' {
+			return false
+		}
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(r-'0')
+	}
+	return sum%10 == 0
+}
+
+func isbn10To13(isbn10 string) string {
+	body := "978" + isbn10[:9]
+
+	sum := 0
+	for i, r := range body {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(r-'0')
+	}
+	return body + string(rune('0'+(10-sum%10)%10))
+}
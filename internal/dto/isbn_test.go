@@ -0,0 +1,72 @@
+package dto
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{name: "isbn-13", raw: "9780306406157", want: "9780306406157"},
+		{name: "isbn-13 with hyphens", raw: "978-0-306-40615-7", want: "9780306406157"},
+		{name: "isbn-13 with spaces", raw: "978 0 306 40615 7", want: "9780306406157"},
+		{name: "979 prefix", raw: "979-10-90636-07-1", want: "9791090636071"},
+		{name: "isbn-10", raw: "0306406152", want: "9780306406157"},
+		{name: "isbn-10 with hyphens", raw: "0-306-40615-2", want: "9780306406157"},
+		{name: "isbn-10 with X check digit", raw: "0-8044-2957-X", want: "9780804429573"},
+		{name: "isbn-10 with lowercase x", raw: "080442957x", want: "9780804429573"},
+		{name: "ean-13 without isbn prefix", raw: "4006381333931", wantErr: true},
+		{name: "isbn-13 with bad checksum", raw: "978-0-306-40615-8", wantErr: true},
+		{name: "isbn-10 with bad checksum", raw: "0-306-40615-3", wantErr: true},
+		{name: "X before the check digit", raw: "03064061X2", wantErr: true},
+		{name: "letters in isbn-13", raw: "978030640615A", wantErr: true},
+		{name: "too short", raw: "030640615", wantErr: true},
+		{name: "empty", raw: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeISBN(tt.raw)
+			if tt.wantErr {
+				var appErr *errors.AppError
+				if !stderrors.As(err, &appErr) || appErr.Code != errors.MsgInvalidISBN {
+					t.Fatalf("NormalizeISBN(%q) error = %v, want %s", tt.raw, err, errors.MsgInvalidISBN)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeISBN(%q) error = %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeISBN(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISBN10(t *testing.T) {
+	tests := []struct {
+		isbn13 string
+		want   string
+	}{
+		{isbn13: "9780306406157", want: "0306406152"},
+		{isbn13: "9780804429573", want: "080442957X"},
+		{isbn13: "9791090636071", want: ""},
+		{isbn13: "978030640615", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.isbn13, func(t *testing.T) {
+			if got := ISBN10(tt.isbn13); got != tt.want {
+				t.Errorf("ISBN10(%q) = %q, want %q", tt.isbn13, got, tt.want)
+			}
+		})
+	}
+}
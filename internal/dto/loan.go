@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+// CreateLoanRequest checks out a specific copy, or any available copy of a
+// book when only book_id is given
+type CreateLoanRequest struct {
+	MemberID string `json:"member_id" binding:"required,max=64"`
+	CopyID   uint   `json:"copy_id"`
+	BookID   uint   `json:"book_id"`
+}
+
+type LoanResponse struct {
+	ID           uint       `json:"id"`
+	CopyID       uint       `json:"copy_id"`
+	BookID       uint       `json:"book_id"`
+	MemberID     string     `json:"member_id"`
+	Status       string     `json:"status"`
+	CheckedOutAt time.Time  `json:"checked_out_at"`
+	DueAt        time.Time  `json:"due_at"`
+	ReturnedAt   *time.Time `json:"returned_at,omitempty"`
+	Renewals     int        `json:"renewals"`
+}
+
+type ListLoansResponse struct {
+	Loans      []LoanResponse `json:"loans"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	TotalItems int64          `json:"total_items"`
+	TotalPages int            `json:"total_pages"`
+}
+
+// Conversion helpers
+func ToLoanResponse(loan *models.Loan) *LoanResponse {
+	return &LoanResponse{
+		ID:           loan.ID,
+		CopyID:       loan.CopyID,
+		BookID:       loan.BookID,
+		MemberID:     loan.MemberID,
+		Status:       string(loan.Status),
+		CheckedOutAt: loan.CheckedOutAt,
+		DueAt:        loan.DueAt,
+		ReturnedAt:   loan.ReturnedAt,
+		Renewals:     loan.Renewals,
+	}
+}
+
+func ToLoanResponseList(loans []models.Loan) []LoanResponse {
+	responses := make([]LoanResponse, len(loans))
+	for i, loan := range loans {
+		responses[i] = *ToLoanResponse(&loan)
+	}
+	return responses
+}
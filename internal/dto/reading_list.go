@@ -0,0 +1,175 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type CreateReadingListRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=2000"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=private public"`
+}
+
+// UpdateReadingListRequest changes a list. Shelves keep their name but their
+// description and visibility may change.
+type UpdateReadingListRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=2000"`
+	Visibility  string `json:"visibility" binding:"required,oneof=private public"`
+}
+
+// AddListEntryRequest puts a book on a list, at the end unless a position is
+// given
+type AddListEntryRequest struct {
+	BookID     uint   `json:"book_id" binding:"required"`
+	Position   int    `json:"position" binding:"omitempty,min=1"`
+	Notes      string `json:"notes" binding:"max=10000"`
+	StartedOn  string `json:"started_on" binding:"omitempty,datetime=2006-01-02"`
+	FinishedOn string `json:"finished_on" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// UpdateListEntryRequest changes an entry. Omitting position keeps the
+// entry where it is.
+type UpdateListEntryRequest struct {
+	Position   int    `json:"position" binding:"omitempty,min=1"`
+	Notes      string `json:"notes" binding:"max=10000"`
+	StartedOn  string `json:"started_on" binding:"omitempty,datetime=2006-01-02"`
+	FinishedOn string `json:"finished_on" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type ReadingListResponse struct {
+	ID          uint   `json:"id"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Visibility  string `json:"visibility"`
+	// ShareToken is only shown to the list's owner
+	ShareToken string              `json:"share_token,omitempty"`
+	EntryCount int                 `json:"entry_count"`
+	Entries    []ListEntryResponse `json:"entries,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+type ListReadingListsResponse struct {
+	Lists []ReadingListResponse `json:"lists"`
+}
+
+// ListEntryResponse is a book on a list. Book is null and BookRemovedAt set
+// once the book has been deleted; BookTitle still names it.
+type ListEntryResponse struct {
+	ID            uint           `json:"id"`
+	Position      int            `json:"position"`
+	BookTitle     string         `json:"book_title"`
+	Book          *ListEntryBook `json:"book"`
+	BookRemovedAt *time.Time     `json:"book_removed_at,omitempty"`
+	Notes         string         `json:"notes,omitempty"`
+	StartedOn     string         `json:"started_on,omitempty"`
+	FinishedOn    string         `json:"finished_on,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+type ListEntryBook struct {
+	ID        uint       `json:"id"`
+	Title     string     `json:"title"`
+	Author    string     `json:"author"`
+	Year      int        `json:"year"`
+	CoverURLs *CoverURLs `json:"cover_urls,omitempty"`
+}
+
+func (r *AddListEntryRequest) ToEntry() *models.ReadingListEntry {
+	bookID := r.BookID
+	return &models.ReadingListEntry{
+		BookID:     &bookID,
+		Position:   r.Position,
+		Notes:      r.Notes,
+		StartedOn:  parseDate(r.StartedOn),
+		FinishedOn: parseDate(r.FinishedOn),
+	}
+}
+
+func (r *UpdateListEntryRequest) ToEntry() *models.ReadingListEntry {
+	return &models.ReadingListEntry{
+		Position:   r.Position,
+		Notes:      r.Notes,
+		StartedOn:  parseDate(r.StartedOn),
+		FinishedOn: parseDate(r.FinishedOn),
+	}
+}
+
+// Conversion helpers
+
+// ToReadingListResponse converts a list; the share token is only included
+// for its owner
+func ToReadingListResponse(list *models.ReadingList, owner bool) *ReadingListResponse {
+	response := &ReadingListResponse{
+		ID:          list.ID,
+		Kind:        string(list.Kind),
+		Name:        list.Name,
+		Description: list.Description,
+		Visibility:  string(list.Visibility),
+		EntryCount:  list.EntryCount,
+		CreatedAt:   list.CreatedAt,
+		UpdatedAt:   list.UpdatedAt,
+	}
+	if owner && list.ShareToken != nil {
+		response.ShareToken = *list.ShareToken
+	}
+	if len(list.Entries) > 0 {
+		response.Entries = make([]ListEntryResponse, len(list.Entries))
+		for i := range list.Entries {
+			response.Entries[i] = *ToListEntryResponse(&list.Entries[i])
+		}
+	}
+	return response
+}
+
+func ToReadingListResponseList(lists []models.ReadingList) []ReadingListResponse {
+	responses := make([]ReadingListResponse, len(lists))
+	for i := range lists {
+		responses[i] = *ToReadingListResponse(&lists[i], true)
+	}
+	return responses
+}
+
+func ToListEntryResponse(entry *models.ReadingListEntry) *ListEntryResponse {
+	response := &ListEntryResponse{
+		ID:            entry.ID,
+		Position:      entry.Position,
+		BookTitle:     entry.BookTitle,
+		BookRemovedAt: entry.BookRemovedAt,
+		Notes:         entry.Notes,
+		StartedOn:     formatDate(entry.StartedOn),
+		FinishedOn:    formatDate(entry.FinishedOn),
+		CreatedAt:     entry.CreatedAt,
+		UpdatedAt:     entry.UpdatedAt,
+	}
+	if entry.Book != nil {
+		response.Book = &ListEntryBook{
+			ID:        entry.Book.ID,
+			Title:     entry.Book.Title,
+			Author:    entry.Book.Author,
+			Year:      entry.Book.Year,
+			CoverURLs: ToCoverURLs(entry.Book.CoverKey),
+		}
+	}
+	return response
+}
+
+func parseDate(value string) *time.Time {
+	date, err := time.Parse(publishedOnLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &date
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(publishedOnLayout)
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Title  string `json:"title" binding:"max=255"`
+	Body   string `json:"body" binding:"max=10000"`
+}
+
+type UpdateReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Title  string `json:"title" binding:"max=255"`
+	Body   string `json:"body" binding:"max=10000"`
+}
+
+type ModerateReviewRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending approved rejected"`
+}
+
+type ReviewResponse struct {
+	ID        uint      `json:"id"`
+	BookID    uint      `json:"book_id"`
+	UserID    string    `json:"user_id"`
+	Rating    int       `json:"rating"`
+	Title     string    `json:"title,omitempty"`
+	Body      string    `json:"body,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListReviewsResponse struct {
+	Reviews    []ReviewResponse `json:"reviews"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalItems int64            `json:"total_items"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// Conversion helpers
+func ToReviewResponse(review *models.Review) *ReviewResponse {
+	return &ReviewResponse{
+		ID:        review.ID,
+		BookID:    review.BookID,
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+		Title:     review.Title,
+		Body:      review.Body,
+		Status:    string(review.Status),
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
+
+func ToReviewResponseList(reviews []models.Review) []ReviewResponse {
+	responses := make([]ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = *ToReviewResponse(&review)
+	}
+	return responses
+}
@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type CreateSeriesRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description" binding:"max=2000"`
+}
+
+type UpdateSeriesRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description" binding:"max=2000"`
+}
+
+// SetSeriesBookRequest places a book in a series. Positions may be
+// fractional, e.g. 2.5 for a novella between the second and third books.
+type SetSeriesBookRequest struct {
+	Position float64 `json:"position" binding:"required,gt=0"`
+}
+
+type SeriesResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SeriesDetailResponse struct {
+	ID          uint                 `json:"id"`
+	Name        string               `json:"name"`
+	Slug        string               `json:"slug"`
+	Description string               `json:"description,omitempty"`
+	Books       []SeriesBookResponse `json:"books"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+type ListSeriesResponse struct {
+	Series     []SeriesResponse `json:"series"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalItems int64            `json:"total_items"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// SeriesBookResponse is a book as listed in a series
+type SeriesBookResponse struct {
+	Position float64 `json:"position"`
+	ID       uint    `json:"id"`
+	Title    string  `json:"title"`
+	Author   string  `json:"author"`
+	Year     int     `json:"year"`
+}
+
+type SeriesSummary struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type SeriesNeighborsResponse struct {
+	Series   SeriesSummary       `json:"series"`
+	Position float64             `json:"position"`
+	Previous *SeriesBookResponse `json:"previous"`
+	Next     *SeriesBookResponse `json:"next"`
+}
+
+type ListSeriesNeighborsResponse struct {
+	BookID uint                      `json:"book_id"`
+	Series []SeriesNeighborsResponse `json:"series"`
+}
+
+// Conversion helpers
+func ToSeriesResponse(series *models.Series) *SeriesResponse {
+	return &SeriesResponse{
+		ID:          series.ID,
+		Name:        series.Name,
+		Slug:        series.Slug,
+		Description: series.Description,
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+	}
+}
+
+func ToSeriesResponseList(series []models.Series) []SeriesResponse {
+	responses := make([]SeriesResponse, len(series))
+	for i, s := range series {
+		responses[i] = *ToSeriesResponse(&s)
+	}
+	return responses
+}
+
+func ToSeriesDetailResponse(series *models.Series) *SeriesDetailResponse {
+	books := make([]SeriesBookResponse, 0, len(series.Entries))
+	for _, entry := range series.Entries {
+		books = append(books, *ToSeriesBookResponse(&entry))
+	}
+
+	return &SeriesDetailResponse{
+		ID:          series.ID,
+		Name:        series.Name,
+		Slug:        series.Slug,
+		Description: series.Description,
+		Books:       books,
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+	}
+}
+
+func ToSeriesBookResponse(entry *models.SeriesEntry) *SeriesBookResponse {
+	if entry == nil {
+		return nil
+	}
+
+	response := &SeriesBookResponse{
+		Position: entry.Position,
+		ID:       entry.BookID,
+	}
+	if entry.Book != nil {
+		response.Title = entry.Book.Title
+		response.Author = entry.Book.Author
+		response.Year = entry.Book.Year
+	}
+	return response
+}
+
+func ToSeriesNeighborsResponseList(neighbors []models.SeriesNeighbors) []SeriesNeighborsResponse {
+	responses := make([]SeriesNeighborsResponse, len(neighbors))
+	for i, n := range neighbors {
+		responses[i] = SeriesNeighborsResponse{
+			Series:   SeriesSummary{ID: n.Series.ID, Name: n.Series.Name, Slug: n.Series.Slug},
+			Position: n.Position,
+			Previous: ToSeriesBookResponse(n.Previous),
+			Next:     ToSeriesBookResponse(n.Next),
+		}
+	}
+	return responses
+}
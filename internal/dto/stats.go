@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/AhmadMuj/books-api-go/internal/models"
+
+type StatsResponse struct {
+	TotalBooks int64                `json:"total_books"`
+	ByDecade   []models.PeriodCount `json:"by_decade"`
+	ByYear     []models.PeriodCount `json:"by_year"`
+	TopAuthors []models.AuthorCount `json:"top_authors"`
+	// AddedPerDay covers every day of the requested range, oldest first
+	AddedPerDay []models.DailyCount `json:"added_per_day"`
+	Growth      models.GrowthTrend  `json:"growth"`
+}
+
+// Conversion helpers
+
+func ToStatsResponse(stats *models.CatalogueStats) *StatsResponse {
+	return &StatsResponse{
+		TotalBooks:  stats.TotalBooks,
+		ByDecade:    stats.ByDecade,
+		ByYear:      stats.ByYear,
+		TopAuthors:  stats.TopAuthors,
+		AddedPerDay: stats.AddedPerDay,
+		Growth:      stats.Growth,
+	}
+}
@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type CreateGenreRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+type UpdateGenreRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+type GenreResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	ParentID  *uint     `json:"parent_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListGenresResponse struct {
+	Genres []GenreResponse `json:"genres"`
+}
+
+type CreateTagRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+}
+
+type UpdateTagRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+}
+
+type TagResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListTagsResponse struct {
+	Tags       []TagResponse `json:"tags"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+	TotalItems int64         `json:"total_items"`
+	TotalPages int           `json:"total_pages"`
+}
+
+// GenreSummary is a genre as embedded in a book response
+type GenreSummary struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// TagSummary is a tag as embedded in a book response
+type TagSummary struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// Conversion helpers
+func ToGenreResponse(genre *models.Genre) *GenreResponse {
+	return &GenreResponse{
+		ID:        genre.ID,
+		Name:      genre.Name,
+		Slug:      genre.Slug,
+		ParentID:  genre.ParentID,
+		CreatedAt: genre.CreatedAt,
+		UpdatedAt: genre.UpdatedAt,
+	}
+}
+
+func ToGenreResponseList(genres []models.Genre) []GenreResponse {
+	responses := make([]GenreResponse, len(genres))
+	for i, genre := range genres {
+		responses[i] = *ToGenreResponse(&genre)
+	}
+	return responses
+}
+
+func ToTagResponse(tag *models.Tag) *TagResponse {
+	return &TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		Slug:      tag.Slug,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+	}
+}
+
+func ToTagResponseList(tags []models.Tag) []TagResponse {
+	responses := make([]TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = *ToTagResponse(&tag)
+	}
+	return responses
+}
+
+func ToGenreSummaries(genres []models.Genre) []GenreSummary {
+	summaries := make([]GenreSummary, len(genres))
+	for i, genre := range genres {
+		summaries[i] = GenreSummary{ID: genre.ID, Name: genre.Name, Slug: genre.Slug}
+	}
+	return summaries
+}
+
+func ToTagSummaries(tags []models.Tag) []TagSummary {
+	summaries := make([]TagSummary, len(tags))
+	for i, tag := range tags {
+		summaries[i] = TagSummary{Name: tag.Name, Slug: tag.Slug}
+	}
+	return summaries
+}
+
+// ToBookGenres turns requested genre IDs into genre references, dropping
+// repeated IDs
+func ToBookGenres(ids []uint) []models.Genre {
+	genres := make([]models.Genre, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		genres = append(genres, models.Genre{ID: id})
+	}
+	return genres
+}
+
+func ToBookTags(names []string) []models.Tag {
+	tags := make([]models.Tag, len(names))
+	for i, name := range names {
+		tags[i] = models.Tag{Name: name}
+	}
+	return tags
+}
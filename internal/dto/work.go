@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"golang.org/x/text/language"
+)
+
+const publishedOnLayout = "2006-01-02"
+
+type CreateWorkRequest struct {
+	Title            string `json:"title" binding:"required,max=255"`
+	OriginalLanguage string `json:"original_language" binding:"omitempty,bcp47_language_tag"`
+}
+
+type UpdateWorkRequest struct {
+	Title            string `json:"title" binding:"required,max=255"`
+	OriginalLanguage string `json:"original_language" binding:"omitempty,bcp47_language_tag"`
+}
+
+type WorkResponse struct {
+	ID               uint      `json:"id"`
+	Title            string    `json:"title"`
+	OriginalLanguage string    `json:"original_language,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type ListWorksResponse struct {
+	Works      []WorkResponse `json:"works"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	TotalItems int64          `json:"total_items"`
+	TotalPages int            `json:"total_pages"`
+}
+
+type ListEditionsResponse struct {
+	Work     WorkResponse   `json:"work"`
+	Editions []BookResponse `json:"editions"`
+}
+
+// PublisherSummary is a publisher as embedded in a book response
+type PublisherSummary struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// applyEdition copies the fields that tell one edition of a work from
+// another onto book. They must have passed binding validation.
+func applyEdition(book *models.Book, workID *uint, publisher, format, lang string, pageCount *int, publishedOn string) {
+	book.WorkID = workID
+	book.Format = models.EditionFormat(format)
+	book.Language = CanonicalLanguage(lang)
+	book.PageCount = pageCount
+
+	book.Publisher = nil
+	if publisher != "" {
+		book.Publisher = &models.Publisher{Name: publisher}
+	}
+
+	book.PublishedOn = nil
+	if date, err := time.Parse(publishedOnLayout, publishedOn); err == nil {
+		book.PublishedOn = &date
+	}
+}
+
+// CanonicalLanguage normalizes a BCP 47 tag so "PT-br" and "pt-BR" are stored
+// the same way
+func CanonicalLanguage(tag string) string {
+	if tag == "" {
+		return ""
+	}
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return tag
+	}
+	return parsed.String()
+}
+
+// Conversion helpers
+func ToWorkResponse(work *models.Work) *WorkResponse {
+	return &WorkResponse{
+		ID:               work.ID,
+		Title:            work.Title,
+		OriginalLanguage: work.OriginalLanguage,
+		CreatedAt:        work.CreatedAt,
+		UpdatedAt:        work.UpdatedAt,
+	}
+}
+
+func ToWorkResponseList(works []models.Work) []WorkResponse {
+	responses := make([]WorkResponse, len(works))
+	for i, work := range works {
+		responses[i] = *ToWorkResponse(&work)
+	}
+	return responses
+}
+
+func ToPublisherSummary(publisher *models.Publisher) *PublisherSummary {
+	if publisher == nil {
+		return nil
+	}
+	return &PublisherSummary{ID: publisher.ID, Name: publisher.Name}
+}
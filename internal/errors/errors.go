@@ -10,12 +10,24 @@ const (
 	ValidationErr ErrorType = "VALIDATION_ERROR"
 	DatabaseErr   ErrorType = "DATABASE_ERROR"
 	InternalErr   ErrorType = "INTERNAL_ERROR"
+	Conflict      ErrorType = "CONFLICT"
+	Unprocessable ErrorType = "UNPROCESSABLE_ENTITY"
+	TooLarge      ErrorType = "PAYLOAD_TOO_LARGE"
+	Unsupported   ErrorType = "UNSUPPORTED_MEDIA_TYPE"
+	Unauthorized  ErrorType = "UNAUTHORIZED"
+	Forbidden     ErrorType = "FORBIDDEN"
 )
 
 type AppError struct {
 	Type    ErrorType
 	Message string
 	Err     error
+	Fields  []FieldError
+
+	// Code and Args select the translated message shown to clients. Errors
+	// without a code fall back to Message.
+	Code MessageCode
+	Args []string
 }
 
 func (e *AppError) Error() string {
@@ -25,6 +37,23 @@ func (e *AppError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Type, e.Message)
 }
 
+// NewLocalizedError builds an error whose client-facing message comes from
+// the locale catalogues. Message holds the English text for logs.
+func NewLocalizedError(errType ErrorType, code MessageCode, args ...interface{}) *AppError {
+	params := toParams(args)
+	message, ok := translate(DefaultTranslator(), string(code), params...)
+	if !ok {
+		message = string(code)
+	}
+
+	return &AppError{
+		Type:    errType,
+		Message: message,
+		Code:    code,
+		Args:    params,
+	}
+}
+
 // Constructor functions for common errors
 func NewNotFoundError(message string) *AppError {
 	return &AppError{
@@ -45,6 +74,7 @@ func NewDatabaseError(err error) *AppError {
 		Type:    DatabaseErr,
 		Message: "database operation failed",
 		Err:     err,
+		Code:    MsgDatabaseError,
 	}
 }
 
@@ -55,10 +85,45 @@ func NewValidationError(message string) *AppError {
 	}
 }
 
+func NewFieldValidationError(field, rule string, code MessageCode, args ...interface{}) *AppError {
+	appErr := NewLocalizedError(ValidationErr, code, args...)
+	appErr.Fields = []FieldError{{
+		Field:   field,
+		Rule:    rule,
+		Message: appErr.Message,
+		code:    code,
+		args:    appErr.Args,
+	}}
+	return appErr
+}
+
 func NewInternalError(err error) *AppError {
 	return &AppError{
 		Type:    InternalErr,
 		Message: "internal server error",
 		Err:     err,
+		Code:    MsgInternalError,
+	}
+}
+
+func NewConflictError(message string) *AppError {
+	return &AppError{
+		Type:    Conflict,
+		Message: message,
+	}
+}
+
+func NewUnprocessableError(message string) *AppError {
+	return &AppError{
+		Type:    Unprocessable,
+		Message: message,
+	}
+}
+
+func toParams(args []interface{}) []string {
+	params := make([]string, len(args))
+	for i, arg := range args {
+		params[i] = fmt.Sprint(arg)
 	}
+	return params
 }
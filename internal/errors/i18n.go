@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"log"
+	"strings"
+
+	"github.com/go-playground/locales/ar"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"golang.org/x/text/language"
+)
+
+const (
+	titleKeyPrefix = "title."
+	ruleKeyPrefix  = "rule."
+	defaultRuleKey = ruleKeyPrefix + "default"
+)
+
+// Unicode first-strong isolate and pop directional isolate. Wrapping
+// parameters in them keeps Latin field names and numbers from reordering
+// the surrounding right-to-left text.
+const (
+	firstStrongIsolate    = "\u2068"
+	popDirectionalIsolate = "\u2069"
+)
+
+type catalogue struct {
+	titles   map[ErrorType]string
+	messages map[MessageCode]string
+	rules    map[string]string
+}
+
+var (
+	universal *ut.UniversalTranslator
+
+	catalogues = map[string]*catalogue{
+		"en": englishCatalogue,
+		"de": germanCatalogue,
+		"ar": arabicCatalogue,
+	}
+	rtlLocales = map[string]bool{"ar": true}
+)
+
+func init() {
+	english := en.New()
+	universal = ut.New(english, english, de.New(), ar.New())
+
+	for locale, cat := range catalogues {
+		trans, found := universal.GetTranslator(locale)
+		if !found {
+			log.Fatalf("no translator registered for locale %q", locale)
+		}
+
+		for errType, text := range cat.titles {
+			mustAdd(trans, titleKeyPrefix+string(errType), text)
+		}
+		for code, text := range cat.messages {
+			mustAdd(trans, string(code), text)
+		}
+		for rule, text := range cat.rules {
+			mustAdd(trans, ruleKeyPrefix+rule, text)
+		}
+	}
+}
+
+func mustAdd(trans ut.Translator, key, text string) {
+	if err := trans.Add(key, text, false); err != nil {
+		log.Fatalf("invalid %s translation for %q: %v", trans.Locale(), key, err)
+	}
+}
+
+// DefaultTranslator returns the English translator used when the client
+// does not ask for a supported language.
+func DefaultTranslator() ut.Translator {
+	return universal.GetFallback()
+}
+
+// FindTranslator picks the best supported translator for an
+// Accept-Language header value.
+func FindTranslator(acceptLanguage string) ut.Translator {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultTranslator()
+	}
+
+	candidates := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		candidates = append(candidates, base.String())
+	}
+
+	trans, _ := universal.FindTranslator(candidates...)
+	return trans
+}
+
+func translate(trans ut.Translator, key string, params ...string) (string, bool) {
+	text, ok := catalogueText(trans.Locale(), key)
+	if !ok {
+		return "", false
+	}
+
+	// Missing parameters would make the translator index out of range
+	for len(params) < strings.Count(text, "{") {
+		params = append(params, "")
+	}
+
+	if rtlLocales[trans.Locale()] {
+		isolated := make([]string, len(params))
+		for i, param := range params {
+			isolated[i] = firstStrongIsolate + param + popDirectionalIsolate
+		}
+		params = isolated
+	}
+
+	msg, err := trans.T(key, params...)
+	if err != nil {
+		return "", false
+	}
+	return msg, true
+}
+
+func catalogueText(locale, key string) (string, bool) {
+	cat, ok := catalogues[locale]
+	if !ok {
+		return "", false
+	}
+
+	var text string
+	switch {
+	case strings.HasPrefix(key, titleKeyPrefix):
+		text, ok = cat.titles[ErrorType(strings.TrimPrefix(key, titleKeyPrefix))]
+	case strings.HasPrefix(key, ruleKeyPrefix):
+		text, ok = cat.rules[strings.TrimPrefix(key, ruleKeyPrefix)]
+	default:
+		text, ok = cat.messages[MessageCode(key)]
+	}
+	return text, ok
+}
@@ -0,0 +1,127 @@
+package errors
+
+// MessageCode identifies a client-facing message in the locale catalogues
+type MessageCode string
+
+const (
+	MsgDatabaseError           MessageCode = "database_error"
+	MsgInternalError           MessageCode = "internal_error"
+	MsgRequestValidationFailed MessageCode = "request_validation_failed"
+	MsgInvalidJSON             MessageCode = "invalid_json"
+	MsgBodyRequired            MessageCode = "body_required"
+	MsgBodyUnreadable          MessageCode = "body_unreadable"
+	MsgInvalidBody             MessageCode = "invalid_body"
+	MsgFieldType               MessageCode = "field_type"
+
+	MsgIdempotencyKeyTooLong MessageCode = "idempotency_key_too_long"
+	MsgIdempotencyInProgress MessageCode = "idempotency_in_progress"
+	MsgIdempotencyKeyReused  MessageCode = "idempotency_key_reused"
+
+	MsgInvalidBookID      MessageCode = "invalid_book_id"
+	MsgBookNotFound       MessageCode = "book_not_found"
+	MsgBookAlreadyExists  MessageCode = "book_already_exists"
+	MsgBookRequired       MessageCode = "book_required"
+	MsgBookTitleRequired  MessageCode = "book_title_required"
+	MsgBookAuthorRequired MessageCode = "book_author_required"
+	MsgBookYearRange      MessageCode = "book_year_range"
+	MsgBookFieldMissing   MessageCode = "book_field_missing"
+
+	MsgInvalidAuthorID     MessageCode = "invalid_author_id"
+	MsgAuthorNotFound      MessageCode = "author_not_found"
+	MsgAuthorAlreadyExists MessageCode = "author_already_exists"
+	MsgAuthorNameRequired  MessageCode = "author_name_required"
+	MsgAuthorHasBooks      MessageCode = "author_has_books"
+	MsgDuplicateBookAuthor MessageCode = "duplicate_book_author"
+
+	MsgInvalidISBN       MessageCode = "invalid_isbn"
+	MsgISBNAlreadyExists MessageCode = "isbn_already_exists"
+
+	MsgInvalidGenreID     MessageCode = "invalid_genre_id"
+	MsgGenreNotFound      MessageCode = "genre_not_found"
+	MsgGenreAlreadyExists MessageCode = "genre_already_exists"
+	MsgGenreNameRequired  MessageCode = "genre_name_required"
+	MsgGenreHasChildren   MessageCode = "genre_has_children"
+	MsgGenreParentCycle   MessageCode = "genre_parent_cycle"
+	MsgInvalidTagID       MessageCode = "invalid_tag_id"
+	MsgTagNotFound        MessageCode = "tag_not_found"
+	MsgTagAlreadyExists   MessageCode = "tag_already_exists"
+	MsgTagNameRequired    MessageCode = "tag_name_required"
+
+	MsgInvalidWorkID     MessageCode = "invalid_work_id"
+	MsgWorkNotFound      MessageCode = "work_not_found"
+	MsgWorkTitleRequired MessageCode = "work_title_required"
+	MsgWorkHasEditions   MessageCode = "work_has_editions"
+
+	MsgInvalidSeriesID     MessageCode = "invalid_series_id"
+	MsgSeriesNotFound      MessageCode = "series_not_found"
+	MsgSeriesAlreadyExists MessageCode = "series_already_exists"
+	MsgSeriesNameRequired  MessageCode = "series_name_required"
+	MsgSeriesPositionTaken MessageCode = "series_position_taken"
+	MsgSeriesPositionRange MessageCode = "series_position_range"
+	MsgSeriesEntryNotFound MessageCode = "series_entry_not_found"
+
+	MsgCoverRequired        MessageCode = "cover_required"
+	MsgCoverTooLarge        MessageCode = "cover_too_large"
+	MsgCoverUnsupportedType MessageCode = "cover_unsupported_type"
+	MsgCoverInvalidImage    MessageCode = "cover_invalid_image"
+
+	MsgInvalidCopyID       MessageCode = "invalid_copy_id"
+	MsgCopyNotFound        MessageCode = "copy_not_found"
+	MsgCopyBarcodeExists   MessageCode = "copy_barcode_exists"
+	MsgCopyBarcodeRequired MessageCode = "copy_barcode_required"
+
+	MsgInvalidLoanID       MessageCode = "invalid_loan_id"
+	MsgLoanNotFound        MessageCode = "loan_not_found"
+	MsgMemberIDRequired    MessageCode = "member_id_required"
+	MsgLoanTargetRequired  MessageCode = "loan_target_required"
+	MsgLoanLimitReached    MessageCode = "loan_limit_reached"
+	MsgCopyNotAvailable    MessageCode = "copy_not_available"
+	MsgNoCopyAvailable     MessageCode = "no_copy_available"
+	MsgCopyOnLoan          MessageCode = "copy_on_loan"
+	MsgLoanAlreadyReturned MessageCode = "loan_already_returned"
+	MsgLoanRenewalLimit    MessageCode = "loan_renewal_limit"
+	MsgLoanOverdue         MessageCode = "loan_overdue"
+
+	MsgCopyLoanStatus MessageCode = "copy_loan_status"
+
+	MsgInvalidLoanStatus MessageCode = "invalid_loan_status"
+
+	MsgInvalidHoldID MessageCode = "invalid_hold_id"
+	MsgHoldNotFound  MessageCode = "hold_not_found"
+	MsgHoldExists    MessageCode = "hold_exists"
+	MsgBookAvailable MessageCode = "book_available"
+	MsgHoldClosed    MessageCode = "hold_closed"
+
+	MsgInvalidBookSort     MessageCode = "invalid_book_sort"
+	MsgInvalidReviewID     MessageCode = "invalid_review_id"
+	MsgReviewNotFound      MessageCode = "review_not_found"
+	MsgReviewExists        MessageCode = "review_exists"
+	MsgUserIDRequired      MessageCode = "user_id_required"
+	MsgReviewRatingRange   MessageCode = "review_rating_range"
+	MsgInvalidReviewStatus MessageCode = "invalid_review_status"
+
+	MsgUserHeaderRequired MessageCode = "user_header_required"
+	MsgInvalidListID      MessageCode = "invalid_list_id"
+	MsgListNotFound       MessageCode = "list_not_found"
+	MsgListNameRequired   MessageCode = "list_name_required"
+	MsgListExists         MessageCode = "list_exists"
+	MsgShelfNotEditable   MessageCode = "shelf_not_editable"
+	MsgInvalidEntryID     MessageCode = "invalid_entry_id"
+	MsgListEntryNotFound  MessageCode = "list_entry_not_found"
+	MsgListEntryExists    MessageCode = "list_entry_exists"
+	MsgEntryPositionRange MessageCode = "entry_position_range"
+	MsgEntryDatesOrder    MessageCode = "entry_dates_order"
+
+	MsgInvalidStatsDate  MessageCode = "invalid_stats_date"
+	MsgStatsRangeOrder   MessageCode = "stats_range_order"
+	MsgStatsRangeTooLong MessageCode = "stats_range_too_long"
+
+	MsgAdminTokenRequired MessageCode = "admin_token_required"
+	MsgCacheEntryNotFound MessageCode = "cache_entry_not_found"
+	MsgCacheKeyRequired   MessageCode = "cache_key_required"
+
+	MsgCopyHoldStatus   MessageCode = "copy_hold_status"
+	MsgHoldQueueWaiting MessageCode = "hold_queue_waiting"
+
+	MsgReviewNotAuthor MessageCode = "review_not_author"
+)
@@ -0,0 +1,149 @@
+package errors
+
+var arabicCatalogue = &catalogue{
+	titles: map[ErrorType]string{
+		NotFound:      "غير موجود",
+		AlreadyExists: "موجود مسبقًا",
+		ValidationErr: "فشل التحقق",
+		DatabaseErr:   "خطأ في قاعدة البيانات",
+		InternalErr:   "خطأ داخلي في الخادم",
+		Conflict:      "تعارض",
+		Unprocessable: "طلب غير قابل للمعالجة",
+		TooLarge:      "حجم الطلب كبير جدًا",
+		Unsupported:   "نوع وسائط غير مدعوم",
+		Unauthorized:  "غير مصرح",
+		Forbidden:     "محظور",
+	},
+	messages: map[MessageCode]string{
+		MsgDatabaseError:           "فشلت عملية قاعدة البيانات",
+		MsgInternalError:           "خطأ داخلي في الخادم",
+		MsgRequestValidationFailed: "فشل التحقق من صحة الطلب",
+		MsgInvalidJSON:             "نص الطلب ليس JSON صالحًا",
+		MsgBodyRequired:            "نص الطلب مطلوب",
+		MsgBodyUnreadable:          "تعذرت قراءة نص الطلب",
+		MsgInvalidBody:             "نص الطلب غير صالح",
+		MsgFieldType:               "يجب أن يكون {0} من النوع {1}",
+
+		MsgIdempotencyKeyTooLong: "مفتاح منع التكرار طويل جدًا",
+		MsgIdempotencyInProgress: "هناك طلب بمفتاح منع التكرار هذا قيد المعالجة بالفعل",
+		MsgIdempotencyKeyReused:  "تم استخدام مفتاح منع التكرار هذا مع طلب مختلف",
+
+		MsgInvalidBookID:      "معرّف الكتاب غير صالح",
+		MsgBookNotFound:       "الكتاب غير موجود",
+		MsgBookAlreadyExists:  "توجد بالفعل طبعة بنفس العنوان والمؤلف والشكل واللغة",
+		MsgBookRequired:       "لا يمكن أن يكون الكتاب فارغًا",
+		MsgBookTitleRequired:  "عنوان الكتاب مطلوب",
+		MsgBookAuthorRequired: "مؤلف الكتاب مطلوب",
+		MsgBookYearRange:      "يجب أن تكون سنة نشر الكتاب بين {0} و{1}",
+		MsgBookFieldMissing:   "{0} مطلوب؛ أرسل قيمة فارغة لمسحه",
+
+		MsgInvalidAuthorID:     "معرّف المؤلف غير صالح",
+		MsgAuthorNotFound:      "المؤلف غير موجود",
+		MsgAuthorAlreadyExists: "يوجد بالفعل مؤلف بنفس الاسم",
+		MsgAuthorNameRequired:  "اسم المؤلف مطلوب",
+		MsgAuthorHasBooks:      "لا يزال المؤلف مرتبطًا بكتب",
+		MsgDuplicateBookAuthor: "المؤلف {0} مدرج أكثر من مرة بالدور {1}",
+
+		MsgInvalidISBN:       "{0} ليس رقم ISBN-10 أو ISBN-13 صالحًا",
+		MsgISBNAlreadyExists: "يوجد بالفعل كتاب بهذا الرقم ISBN",
+
+		MsgInvalidGenreID:     "معرّف النوع الأدبي غير صالح",
+		MsgGenreNotFound:      "النوع الأدبي غير موجود",
+		MsgGenreAlreadyExists: "يوجد بالفعل نوع أدبي بنفس الاسم",
+		MsgGenreNameRequired:  "اسم النوع الأدبي مطلوب",
+		MsgGenreHasChildren:   "لا يزال للنوع الأدبي أنواع فرعية",
+		MsgGenreParentCycle:   "لا يمكن أن يكون النوع الأدبي أصلًا لنفسه",
+		MsgInvalidTagID:       "معرّف الوسم غير صالح",
+		MsgTagNotFound:        "الوسم غير موجود",
+		MsgTagAlreadyExists:   "يوجد بالفعل وسم بنفس الاسم",
+		MsgTagNameRequired:    "اسم الوسم مطلوب",
+
+		MsgInvalidWorkID:     "معرّف العمل غير صالح",
+		MsgWorkNotFound:      "العمل غير موجود",
+		MsgWorkTitleRequired: "عنوان العمل مطلوب",
+		MsgWorkHasEditions:   "لا تزال للعمل طبعات",
+
+		MsgInvalidSeriesID:     "معرّف السلسلة غير صالح",
+		MsgSeriesNotFound:      "السلسلة غير موجودة",
+		MsgSeriesAlreadyExists: "توجد بالفعل سلسلة بنفس الاسم",
+		MsgSeriesNameRequired:  "اسم السلسلة مطلوب",
+		MsgSeriesPositionTaken: "الموضع {0} مشغول بالفعل في هذه السلسلة",
+		MsgSeriesPositionRange: "يجب أن يكون الموضع في السلسلة أكبر من 0",
+		MsgSeriesEntryNotFound: "الكتاب ليس جزءًا من هذه السلسلة",
+
+		MsgCoverRequired:        "صورة الغلاف مطلوبة في حقل النموذج \"cover\"",
+		MsgCoverTooLarge:        "يجب ألا يتجاوز حجم صورة الغلاف {0} بايت",
+		MsgCoverUnsupportedType: "يجب أن تكون صورة الغلاف بصيغة JPEG أو PNG أو WebP وليس {0}",
+		MsgCoverInvalidImage:    "تعذرت قراءة صورة الغلاف",
+
+		MsgInvalidCopyID:       "معرّف النسخة غير صالح",
+		MsgCopyNotFound:        "النسخة غير موجودة",
+		MsgCopyBarcodeExists:   "توجد بالفعل نسخة بهذا الرمز الشريطي",
+		MsgCopyBarcodeRequired: "الرمز الشريطي للنسخة مطلوب",
+
+		MsgInvalidLoanID:       "معرّف الإعارة غير صالح",
+		MsgLoanNotFound:        "الإعارة غير موجودة",
+		MsgMemberIDRequired:    "member_id مطلوب",
+		MsgLoanTargetRequired:  "يجب تحديد copy_id أو book_id",
+		MsgLoanLimitReached:    "لدى العضو الحد الأقصى من الإعارات وهو {0}",
+		MsgCopyNotAvailable:    "النسخة غير متاحة للإعارة",
+		MsgNoCopyAvailable:     "لا توجد نسخة متاحة للإعارة من هذا الكتاب",
+		MsgCopyOnLoan:          "النسخة معارة ولا يمكن حذفها",
+		MsgLoanAlreadyReturned: "تمت إعادة الإعارة مسبقًا",
+		MsgLoanRenewalLimit:    "تم تجديد الإعارة الحد الأقصى من المرات وهو {0}",
+		MsgLoanOverdue:         "لا يمكن تجديد الإعارات المتأخرة",
+
+		MsgCopyLoanStatus: "لا تتغير حالة on_loan للنسخة إلا عبر الإعارة والإرجاع",
+
+		MsgInvalidLoanStatus: "يجب أن تكون الحالة active أو overdue أو returned",
+
+		MsgInvalidHoldID: "معرّف الحجز غير صالح",
+		MsgHoldNotFound:  "الحجز غير موجود",
+		MsgHoldExists:    "لدى العضو حجز قائم على هذا الكتاب",
+		MsgBookAvailable: "توجد نسخة متاحة من هذا الكتاب؛ استعرها بدلًا من حجزها",
+		MsgHoldClosed:    "الحجز لم يعد قائمًا",
+
+		MsgInvalidBookSort:     "يجب أن يكون الترتيب newest أو oldest أو title أو year أو rating أو popular",
+		MsgInvalidReviewID:     "معرّف المراجعة غير صالح",
+		MsgReviewNotFound:      "المراجعة غير موجودة",
+		MsgReviewExists:        "قام المستخدم بمراجعة هذا الكتاب مسبقًا",
+		MsgUserIDRequired:      "user_id مطلوب",
+		MsgReviewRatingRange:   "يجب أن يكون التقييم بين {0} و{1}",
+		MsgInvalidReviewStatus: "يجب أن تكون الحالة pending أو approved أو rejected",
+
+		MsgUserHeaderRequired: "الترويسة X-User-ID مطلوبة",
+		MsgInvalidListID:      "معرّف القائمة غير صالح",
+		MsgListNotFound:       "القائمة غير موجودة",
+		MsgListNameRequired:   "اسم القائمة مطلوب",
+		MsgListExists:         "توجد قائمة بهذا الاسم مسبقًا",
+		MsgShelfNotEditable:   "لا يمكن إعادة تسمية الرفوف المدمجة أو حذفها",
+		MsgInvalidEntryID:     "معرّف عنصر القائمة غير صالح",
+		MsgListEntryNotFound:  "عنصر القائمة غير موجود",
+		MsgListEntryExists:    "الكتاب موجود في هذه القائمة مسبقًا",
+		MsgEntryPositionRange: "يجب أن يكون الموضع بين 1 و{0}",
+		MsgEntryDatesOrder:    "يجب ألا يسبق finished_on التاريخ started_on",
+
+		MsgInvalidStatsDate:  "يجب أن يكون {0} تاريخًا بالتنسيق YYYY-MM-DD",
+		MsgStatsRangeOrder:   "يجب ألا يكون from بعد to",
+		MsgStatsRangeTooLong: "يجب ألا يتجاوز النطاق الزمني {0} يومًا",
+
+		MsgAdminTokenRequired: "الترويسة X-Admin-Token صالحة مطلوبة",
+		MsgCacheEntryNotFound: "لا يوجد شيء مخزن مؤقتًا للكتاب {0}",
+		MsgCacheKeyRequired:   "مفتاح ذاكرة التخزين المؤقت مطلوب",
+
+		MsgCopyHoldStatus:   "لا تُعيَّن حالة on_hold للنسخة ولا تُلغى إلا عبر قائمة انتظار الحجوزات",
+		MsgHoldQueueWaiting: "نسخ هذا الكتاب محجوزة للأعضاء المنتظرين في قائمة الحجوزات",
+
+		MsgReviewNotAuthor: "لا يحق تعديل المراجعة إلا لكاتبها أو للمسؤول",
+	},
+	rules: map[string]string{
+		"default":            "{0} غير صالح",
+		"required":           "{0} مطلوب",
+		"min":                "يجب أن يكون {0} على الأقل {1}",
+		"gt":                 "يجب أن يكون {0} أكبر من {1}",
+		"max":                "يجب ألا يتجاوز {0} القيمة {1}",
+		"oneof":              "يجب أن يكون {0} إحدى القيم [{1}]",
+		"datetime":           "يجب أن يكون {0} تاريخًا بالصيغة {1}",
+		"bcp47_language_tag": "يجب أن يكون {0} وسم لغة مثل en أو pt-BR",
+	},
+}
@@ -0,0 +1,149 @@
+package errors
+
+var germanCatalogue = &catalogue{
+	titles: map[ErrorType]string{
+		NotFound:      "Nicht gefunden",
+		AlreadyExists: "Bereits vorhanden",
+		ValidationErr: "Validierung fehlgeschlagen",
+		DatabaseErr:   "Datenbankfehler",
+		InternalErr:   "Interner Serverfehler",
+		Conflict:      "Konflikt",
+		Unprocessable: "Nicht verarbeitbare Anfrage",
+		TooLarge:      "Anfrage zu groß",
+		Unsupported:   "Nicht unterstützter Medientyp",
+		Unauthorized:  "Nicht autorisiert",
+		Forbidden:     "Verboten",
+	},
+	messages: map[MessageCode]string{
+		MsgDatabaseError:           "Datenbankoperation fehlgeschlagen",
+		MsgInternalError:           "interner Serverfehler",
+		MsgRequestValidationFailed: "Validierung der Anfrage fehlgeschlagen",
+		MsgInvalidJSON:             "Anfragetext ist kein gültiges JSON",
+		MsgBodyRequired:            "Anfragetext ist erforderlich",
+		MsgBodyUnreadable:          "Anfragetext konnte nicht gelesen werden",
+		MsgInvalidBody:             "ungültiger Anfragetext",
+		MsgFieldType:               "{0} muss vom Typ {1} sein",
+
+		MsgIdempotencyKeyTooLong: "Idempotenzschlüssel ist zu lang",
+		MsgIdempotencyInProgress: "eine Anfrage mit diesem Idempotenzschlüssel wird bereits verarbeitet",
+		MsgIdempotencyKeyReused:  "Idempotenzschlüssel wurde bereits für eine andere Anfrage verwendet",
+
+		MsgInvalidBookID:      "ungültige Buch-ID",
+		MsgBookNotFound:       "Buch nicht gefunden",
+		MsgBookAlreadyExists:  "eine Ausgabe mit demselben Titel, Autor, Format und derselben Sprache existiert bereits",
+		MsgBookRequired:       "Buch darf nicht leer sein",
+		MsgBookTitleRequired:  "Buchtitel ist erforderlich",
+		MsgBookAuthorRequired: "Buchautor ist erforderlich",
+		MsgBookYearRange:      "Erscheinungsjahr muss zwischen {0} und {1} liegen",
+		MsgBookFieldMissing:   "{0} ist erforderlich; zum Löschen einen leeren Wert senden",
+
+		MsgInvalidAuthorID:     "ungültige Autoren-ID",
+		MsgAuthorNotFound:      "Autor nicht gefunden",
+		MsgAuthorAlreadyExists: "ein Autor mit demselben Namen existiert bereits",
+		MsgAuthorNameRequired:  "Autorenname ist erforderlich",
+		MsgAuthorHasBooks:      "Autor ist noch mit Büchern verknüpft",
+		MsgDuplicateBookAuthor: "Autor {0} ist mehrfach mit der Rolle {1} aufgeführt",
+
+		MsgInvalidISBN:       "{0} ist keine gültige ISBN-10 oder ISBN-13",
+		MsgISBNAlreadyExists: "ein Buch mit dieser ISBN existiert bereits",
+
+		MsgInvalidGenreID:     "ungültige Genre-ID",
+		MsgGenreNotFound:      "Genre nicht gefunden",
+		MsgGenreAlreadyExists: "ein Genre mit demselben Namen existiert bereits",
+		MsgGenreNameRequired:  "Genrename ist erforderlich",
+		MsgGenreHasChildren:   "Genre hat noch Untergenres",
+		MsgGenreParentCycle:   "ein Genre kann nicht sein eigener Vorfahr sein",
+		MsgInvalidTagID:       "ungültige Schlagwort-ID",
+		MsgTagNotFound:        "Schlagwort nicht gefunden",
+		MsgTagAlreadyExists:   "ein Schlagwort mit demselben Namen existiert bereits",
+		MsgTagNameRequired:    "Schlagwortname ist erforderlich",
+
+		MsgInvalidWorkID:     "ungültige Werk-ID",
+		MsgWorkNotFound:      "Werk nicht gefunden",
+		MsgWorkTitleRequired: "der Titel des Werks ist erforderlich",
+		MsgWorkHasEditions:   "das Werk hat noch Ausgaben",
+
+		MsgInvalidSeriesID:     "ungültige Reihen-ID",
+		MsgSeriesNotFound:      "Reihe nicht gefunden",
+		MsgSeriesAlreadyExists: "eine Reihe mit demselben Namen existiert bereits",
+		MsgSeriesNameRequired:  "der Name der Reihe ist erforderlich",
+		MsgSeriesPositionTaken: "Position {0} ist in dieser Reihe bereits belegt",
+		MsgSeriesPositionRange: "die Position in der Reihe muss größer als 0 sein",
+		MsgSeriesEntryNotFound: "das Buch gehört nicht zu dieser Reihe",
+
+		MsgCoverRequired:        "im Formularfeld \"cover\" ist ein Titelbild erforderlich",
+		MsgCoverTooLarge:        "das Titelbild darf höchstens {0} Bytes groß sein",
+		MsgCoverUnsupportedType: "das Titelbild muss JPEG, PNG oder WebP sein, nicht {0}",
+		MsgCoverInvalidImage:    "das Titelbild konnte nicht gelesen werden",
+
+		MsgInvalidCopyID:       "ungültige Exemplar-ID",
+		MsgCopyNotFound:        "Exemplar nicht gefunden",
+		MsgCopyBarcodeExists:   "ein Exemplar mit diesem Barcode existiert bereits",
+		MsgCopyBarcodeRequired: "der Barcode des Exemplars ist erforderlich",
+
+		MsgInvalidLoanID:       "ungültige Ausleih-ID",
+		MsgLoanNotFound:        "Ausleihe nicht gefunden",
+		MsgMemberIDRequired:    "member_id ist erforderlich",
+		MsgLoanTargetRequired:  "entweder copy_id oder book_id ist erforderlich",
+		MsgLoanLimitReached:    "das Mitglied hat bereits die maximale Anzahl von {0} Ausleihen",
+		MsgCopyNotAvailable:    "das Exemplar ist nicht ausleihbar",
+		MsgNoCopyAvailable:     "kein Exemplar dieses Buches ist ausleihbar",
+		MsgCopyOnLoan:          "das Exemplar ist ausgeliehen und kann nicht gelöscht werden",
+		MsgLoanAlreadyReturned: "die Ausleihe wurde bereits zurückgegeben",
+		MsgLoanRenewalLimit:    "die Ausleihe wurde bereits maximal {0} Mal verlängert",
+		MsgLoanOverdue:         "überfällige Ausleihen können nicht verlängert werden",
+
+		MsgCopyLoanStatus: "der Status on_loan eines Exemplars wird nur durch Ausleihe und Rückgabe geändert",
+
+		MsgInvalidLoanStatus: "status muss active, overdue oder returned sein",
+
+		MsgInvalidHoldID: "ungültige Vormerkungs-ID",
+		MsgHoldNotFound:  "Vormerkung nicht gefunden",
+		MsgHoldExists:    "das Mitglied hat dieses Buch bereits vorgemerkt",
+		MsgBookAvailable: "ein Exemplar dieses Buches ist verfügbar; leihen Sie es aus, statt es vorzumerken",
+		MsgHoldClosed:    "die Vormerkung ist nicht mehr offen",
+
+		MsgInvalidBookSort:     "sort muss newest, oldest, title, year, rating oder popular sein",
+		MsgInvalidReviewID:     "ungültige Rezensions-ID",
+		MsgReviewNotFound:      "Rezension nicht gefunden",
+		MsgReviewExists:        "der Benutzer hat dieses Buch bereits rezensiert",
+		MsgUserIDRequired:      "user_id ist erforderlich",
+		MsgReviewRatingRange:   "die Bewertung muss zwischen {0} und {1} liegen",
+		MsgInvalidReviewStatus: "status muss pending, approved oder rejected sein",
+
+		MsgUserHeaderRequired: "der Header X-User-ID ist erforderlich",
+		MsgInvalidListID:      "ungültige Listen-ID",
+		MsgListNotFound:       "Liste nicht gefunden",
+		MsgListNameRequired:   "Listenname ist erforderlich",
+		MsgListExists:         "eine Liste mit diesem Namen existiert bereits",
+		MsgShelfNotEditable:   "integrierte Regale können nicht umbenannt oder gelöscht werden",
+		MsgInvalidEntryID:     "ungültige Listeneintrags-ID",
+		MsgListEntryNotFound:  "Listeneintrag nicht gefunden",
+		MsgListEntryExists:    "das Buch steht bereits auf dieser Liste",
+		MsgEntryPositionRange: "die Position muss zwischen 1 und {0} liegen",
+		MsgEntryDatesOrder:    "finished_on darf nicht vor started_on liegen",
+
+		MsgInvalidStatsDate:  "{0} muss ein Datum im Format JJJJ-MM-TT sein",
+		MsgStatsRangeOrder:   "from darf nicht nach to liegen",
+		MsgStatsRangeTooLong: "der Zeitraum darf höchstens {0} Tage umfassen",
+
+		MsgAdminTokenRequired: "ein gültiger Header X-Admin-Token ist erforderlich",
+		MsgCacheEntryNotFound: "für Buch {0} ist nichts zwischengespeichert",
+		MsgCacheKeyRequired:   "ein Cache-Schlüssel ist erforderlich",
+
+		MsgCopyHoldStatus:   "der Status on_hold eines Exemplars wird nur durch die Vormerkungswarteschlange gesetzt und aufgehoben",
+		MsgHoldQueueWaiting: "Exemplare dieses Buches sind für Mitglieder in der Vormerkungswarteschlange reserviert",
+
+		MsgReviewNotAuthor: "nur der Verfasser einer Rezension oder ein Administrator darf sie ändern",
+	},
+	rules: map[string]string{
+		"default":            "{0} ist ungültig",
+		"required":           "{0} ist erforderlich",
+		"min":                "{0} muss mindestens {1} sein",
+		"gt":                 "{0} muss größer als {1} sein",
+		"max":                "{0} darf höchstens {1} sein",
+		"oneof":              "{0} muss einer der Werte [{1}] sein",
+		"datetime":           "{0} muss ein Datum im Format {1} sein",
+		"bcp47_language_tag": "{0} muss ein Sprach-Tag wie en oder pt-BR sein",
+	},
+}
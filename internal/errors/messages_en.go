@@ -0,0 +1,149 @@
+package errors
+
+var englishCatalogue = &catalogue{
+	titles: map[ErrorType]string{
+		NotFound:      "Not Found",
+		AlreadyExists: "Already Exists",
+		ValidationErr: "Validation Failed",
+		DatabaseErr:   "Database Error",
+		InternalErr:   "Internal Server Error",
+		Conflict:      "Conflict",
+		Unprocessable: "Unprocessable Entity",
+		TooLarge:      "Payload Too Large",
+		Unsupported:   "Unsupported Media Type",
+		Unauthorized:  "Unauthorized",
+		Forbidden:     "Forbidden",
+	},
+	messages: map[MessageCode]string{
+		MsgDatabaseError:           "database operation failed",
+		MsgInternalError:           "internal server error",
+		MsgRequestValidationFailed: "request validation failed",
+		MsgInvalidJSON:             "request body is not valid JSON",
+		MsgBodyRequired:            "request body is required",
+		MsgBodyUnreadable:          "failed to read request body",
+		MsgInvalidBody:             "invalid request body",
+		MsgFieldType:               "{0} must be of type {1}",
+
+		MsgIdempotencyKeyTooLong: "idempotency key is too long",
+		MsgIdempotencyInProgress: "a request with this idempotency key is already in progress",
+		MsgIdempotencyKeyReused:  "idempotency key was already used with a different request",
+
+		MsgInvalidBookID:      "invalid book ID",
+		MsgBookNotFound:       "book not found",
+		MsgBookAlreadyExists:  "an edition with the same title, author, format and language already exists",
+		MsgBookRequired:       "book cannot be nil",
+		MsgBookTitleRequired:  "book title is required",
+		MsgBookAuthorRequired: "book author is required",
+		MsgBookYearRange:      "book year must be between {0} and {1}",
+		MsgBookFieldMissing:   "{0} is required; send an empty value to clear it",
+
+		MsgInvalidAuthorID:     "invalid author ID",
+		MsgAuthorNotFound:      "author not found",
+		MsgAuthorAlreadyExists: "author with the same name already exists",
+		MsgAuthorNameRequired:  "author name is required",
+		MsgAuthorHasBooks:      "author is still linked to books",
+		MsgDuplicateBookAuthor: "author {0} is listed more than once with role {1}",
+
+		MsgInvalidISBN:       "{0} is not a valid ISBN-10 or ISBN-13",
+		MsgISBNAlreadyExists: "a book with this ISBN already exists",
+
+		MsgInvalidGenreID:     "invalid genre ID",
+		MsgGenreNotFound:      "genre not found",
+		MsgGenreAlreadyExists: "genre with the same name already exists",
+		MsgGenreNameRequired:  "genre name is required",
+		MsgGenreHasChildren:   "genre still has sub-genres",
+		MsgGenreParentCycle:   "a genre cannot be its own ancestor",
+		MsgInvalidTagID:       "invalid tag ID",
+		MsgTagNotFound:        "tag not found",
+		MsgTagAlreadyExists:   "tag with the same name already exists",
+		MsgTagNameRequired:    "tag name is required",
+
+		MsgInvalidWorkID:     "invalid work ID",
+		MsgWorkNotFound:      "work not found",
+		MsgWorkTitleRequired: "work title is required",
+		MsgWorkHasEditions:   "work still has editions",
+
+		MsgInvalidSeriesID:     "invalid series ID",
+		MsgSeriesNotFound:      "series not found",
+		MsgSeriesAlreadyExists: "series with the same name already exists",
+		MsgSeriesNameRequired:  "series name is required",
+		MsgSeriesPositionTaken: "position {0} is already taken in this series",
+		MsgSeriesPositionRange: "series position must be greater than 0",
+		MsgSeriesEntryNotFound: "book is not part of this series",
+
+		MsgCoverRequired:        "a cover image is required in the \"cover\" form field",
+		MsgCoverTooLarge:        "cover image must be at most {0} bytes",
+		MsgCoverUnsupportedType: "cover image must be JPEG, PNG or WebP, not {0}",
+		MsgCoverInvalidImage:    "cover image could not be decoded",
+
+		MsgInvalidCopyID:       "invalid copy ID",
+		MsgCopyNotFound:        "copy not found",
+		MsgCopyBarcodeExists:   "a copy with this barcode already exists",
+		MsgCopyBarcodeRequired: "copy barcode is required",
+
+		MsgInvalidLoanID:       "invalid loan ID",
+		MsgLoanNotFound:        "loan not found",
+		MsgMemberIDRequired:    "member_id is required",
+		MsgLoanTargetRequired:  "either copy_id or book_id is required",
+		MsgLoanLimitReached:    "member already has the maximum of {0} loans",
+		MsgCopyNotAvailable:    "copy is not available for loan",
+		MsgNoCopyAvailable:     "no copy of this book is available for loan",
+		MsgCopyOnLoan:          "copy is on loan and cannot be deleted",
+		MsgLoanAlreadyReturned: "loan has already been returned",
+		MsgLoanRenewalLimit:    "loan has already been renewed the maximum of {0} times",
+		MsgLoanOverdue:         "overdue loans cannot be renewed",
+
+		MsgCopyLoanStatus: "a copy's on_loan status is only changed by checkouts and returns",
+
+		MsgInvalidLoanStatus: "status must be one of active, overdue or returned",
+
+		MsgInvalidHoldID: "invalid hold ID",
+		MsgHoldNotFound:  "hold not found",
+		MsgHoldExists:    "member already has an open hold on this book",
+		MsgBookAvailable: "a copy of this book is available; check it out instead of placing a hold",
+		MsgHoldClosed:    "hold is no longer open",
+
+		MsgInvalidBookSort:     "sort must be one of newest, oldest, title, year, rating or popular",
+		MsgInvalidReviewID:     "invalid review ID",
+		MsgReviewNotFound:      "review not found",
+		MsgReviewExists:        "user has already reviewed this book",
+		MsgUserIDRequired:      "user_id is required",
+		MsgReviewRatingRange:   "rating must be between {0} and {1}",
+		MsgInvalidReviewStatus: "status must be one of pending, approved or rejected",
+
+		MsgUserHeaderRequired: "the X-User-ID header is required",
+		MsgInvalidListID:      "invalid list ID",
+		MsgListNotFound:       "list not found",
+		MsgListNameRequired:   "list name is required",
+		MsgListExists:         "a list with this name already exists",
+		MsgShelfNotEditable:   "built-in shelves cannot be renamed or deleted",
+		MsgInvalidEntryID:     "invalid list entry ID",
+		MsgListEntryNotFound:  "list entry not found",
+		MsgListEntryExists:    "book is already on this list",
+		MsgEntryPositionRange: "position must be between 1 and {0}",
+		MsgEntryDatesOrder:    "finished_on must not be before started_on",
+
+		MsgInvalidStatsDate:  "{0} must be a date in YYYY-MM-DD format",
+		MsgStatsRangeOrder:   "from must not be after to",
+		MsgStatsRangeTooLong: "the date range may span at most {0} days",
+
+		MsgAdminTokenRequired: "a valid X-Admin-Token header is required",
+		MsgCacheEntryNotFound: "nothing is cached for book {0}",
+		MsgCacheKeyRequired:   "a cache key is required",
+
+		MsgCopyHoldStatus:   "a copy's on_hold status is only set and cleared by the hold queue",
+		MsgHoldQueueWaiting: "copies of this book are reserved for members waiting in its hold queue",
+
+		MsgReviewNotAuthor: "only the author of a review or an admin may change it",
+	},
+	rules: map[string]string{
+		"default":            "{0} is invalid",
+		"required":           "{0} is required",
+		"min":                "{0} must be at least {1}",
+		"gt":                 "{0} must be greater than {1}",
+		"max":                "{0} must be at most {1}",
+		"oneof":              "{0} must be one of [{1}]",
+		"datetime":           "{0} must be a date in the format {1}",
+		"bcp47_language_tag": "{0} must be a language tag such as en or pt-BR",
+	},
+}
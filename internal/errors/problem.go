@@ -0,0 +1,150 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details response body
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+
+	code MessageCode
+	args []string
+}
+
+var statusByType = map[ErrorType]int{
+	NotFound:      http.StatusNotFound,
+	AlreadyExists: http.StatusConflict,
+	ValidationErr: http.StatusBadRequest,
+	DatabaseErr:   http.StatusInternalServerError,
+	InternalErr:   http.StatusInternalServerError,
+	Conflict:      http.StatusConflict,
+	Unprocessable: http.StatusUnprocessableEntity,
+	TooLarge:      http.StatusRequestEntityTooLarge,
+	Unsupported:   http.StatusUnsupportedMediaType,
+	Unauthorized:  http.StatusUnauthorized,
+	Forbidden:     http.StatusForbidden,
+}
+
+func (t ErrorType) HTTPStatus() int {
+	if status, ok := statusByType[t]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// NewProblem converts err into problem details in the translator's language.
+// Errors that are not an *AppError, and the wrapped cause of any AppError,
+// never reach the client.
+func NewProblem(err error, instance string, trans ut.Translator) *Problem {
+	var appErr *AppError
+	if !stderrors.As(err, &appErr) {
+		appErr = NewInternalError(err)
+	}
+
+	status := appErr.Type.HTTPStatus()
+
+	title, ok := translate(trans, titleKeyPrefix+string(appErr.Type))
+	if !ok {
+		title = http.StatusText(status)
+	}
+
+	detail := appErr.Message
+	if appErr.Code != "" {
+		if msg, ok := translate(trans, string(appErr.Code), appErr.Args...); ok {
+			detail = msg
+		}
+	}
+
+	var fields []FieldError
+	for _, field := range appErr.Fields {
+		if field.code != "" {
+			if msg, ok := translate(trans, string(field.code), field.args...); ok {
+				field.Message = msg
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	return &Problem{
+		Type:     "/problems/" + strings.ReplaceAll(strings.ToLower(string(appErr.Type)), "_", "-"),
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Errors:   fields,
+	}
+}
+
+// NewBindingError converts an error returned by gin's ShouldBind* methods
+// into a validation error with one entry per offending field.
+func NewBindingError(err error) *AppError {
+	var validationErrs validator.ValidationErrors
+	if stderrors.As(err, &validationErrs) {
+		appErr := NewLocalizedError(ValidationErr, MsgRequestValidationFailed)
+		for _, fe := range validationErrs {
+			appErr.Fields = append(appErr.Fields, ruleError(fe))
+		}
+		return appErr
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &typeErr) {
+		return NewFieldValidationError(typeErr.Field, "type", MsgFieldType, typeErr.Field, typeErr.Type)
+	}
+
+	var syntaxErr *json.SyntaxError
+	if stderrors.As(err, &syntaxErr) {
+		return NewLocalizedError(ValidationErr, MsgInvalidJSON)
+	}
+
+	if stderrors.Is(err, io.EOF) {
+		return NewLocalizedError(ValidationErr, MsgBodyRequired)
+	}
+
+	return NewLocalizedError(ValidationErr, MsgInvalidBody)
+}
+
+func ruleError(fe validator.FieldError) FieldError {
+	key := ruleKeyPrefix + fe.Tag()
+	if _, ok := catalogueText("en", key); !ok {
+		key = defaultRuleKey
+	}
+
+	// Report nested fields by their path, e.g. authors[0].role
+	field := fe.Namespace()
+	if i := strings.Index(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
+
+	args := []string{field, strings.ReplaceAll(fe.Param(), " ", ", ")}
+	message, _ := translate(DefaultTranslator(), key, args...)
+
+	return FieldError{
+		Field:   field,
+		Rule:    fe.Tag(),
+		Message: message,
+		code:    MessageCode(key),
+		args:    args,
+	}
+}
@@ -14,6 +14,29 @@ const (
 	EventTypeBookCreated EventType = "BOOK_CREATED"
 	EventTypeBookUpdated EventType = "BOOK_UPDATED"
 	EventTypeBookDeleted EventType = "BOOK_DELETED"
+
+	EventTypeSeriesBookAdded   EventType = "SERIES_BOOK_ADDED"
+	EventTypeSeriesBookMoved   EventType = "SERIES_BOOK_MOVED"
+	EventTypeSeriesBookRemoved EventType = "SERIES_BOOK_REMOVED"
+
+	EventTypeCopyCreated EventType = "COPY_CREATED"
+	EventTypeCopyUpdated EventType = "COPY_UPDATED"
+	EventTypeCopyDeleted EventType = "COPY_DELETED"
+
+	EventTypeLoanCreated  EventType = "LOAN_CREATED"
+	EventTypeLoanRenewed  EventType = "LOAN_RENEWED"
+	EventTypeLoanReturned EventType = "LOAN_RETURNED"
+	EventTypeLoanOverdue  EventType = "LOAN_OVERDUE"
+
+	EventTypeHoldPlaced    EventType = "HOLD_PLACED"
+	EventTypeHoldReady     EventType = "HOLD_READY"
+	EventTypeHoldCancelled EventType = "HOLD_CANCELLED"
+	EventTypeHoldExpired   EventType = "HOLD_EXPIRED"
+
+	EventTypeReviewCreated   EventType = "REVIEW_CREATED"
+	EventTypeReviewUpdated   EventType = "REVIEW_UPDATED"
+	EventTypeReviewModerated EventType = "REVIEW_MODERATED"
+	EventTypeReviewDeleted   EventType = "REVIEW_DELETED"
 )
 
 type Event struct {
@@ -24,18 +47,22 @@ type Event struct {
 }
 
 type BookEvent struct {
-	ID     uint   `json:"id"`
-	Title  string `json:"title"`
-	Author string `json:"author"`
-	Year   int    `json:"year"`
+	ID     uint    `json:"id"`
+	WorkID *uint   `json:"work_id,omitempty"`
+	Title  string  `json:"title"`
+	Author string  `json:"author"`
+	Year   int     `json:"year"`
+	ISBN13 *string `json:"isbn13,omitempty"`
 }
 
 func NewBookEvent(eventType EventType, book *models.Book) (*Event, error) {
 	bookEvent := BookEvent{
 		ID:     book.ID,
+		WorkID: book.WorkID,
 		Title:  book.Title,
 		Author: book.Author,
 		Year:   book.Year,
+		ISBN13: book.ISBN13,
 	}
 
 	data, err := json.Marshal(bookEvent)
@@ -63,3 +90,144 @@ func NewBookDeletedEvent(bookID uint) *Event {
 		Timestamp: time.Now(),
 	}
 }
+
+// SeriesEvent describes a change to a book's membership of a series
+type SeriesEvent struct {
+	SeriesID         uint     `json:"series_id"`
+	BookID           uint     `json:"book_id"`
+	Position         *float64 `json:"position,omitempty"`
+	PreviousPosition *float64 `json:"previous_position,omitempty"`
+}
+
+func NewSeriesEvent(eventType EventType, seriesEvent SeriesEvent) (*Event, error) {
+	data, err := json.Marshal(seriesEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		Data:      data,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+type CopyEvent struct {
+	ID             uint                 `json:"id"`
+	BookID         uint                 `json:"book_id"`
+	Barcode        string               `json:"barcode,omitempty"`
+	Branch         string               `json:"branch,omitempty"`
+	Location       string               `json:"location,omitempty"`
+	Condition      models.CopyCondition `json:"condition,omitempty"`
+	Status         models.CopyStatus    `json:"status,omitempty"`
+	PreviousStatus models.CopyStatus    `json:"previous_status,omitempty"`
+}
+
+func NewCopyEvent(eventType EventType, copyEvent CopyEvent) (*Event, error) {
+	data, err := json.Marshal(copyEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		Data:      data,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+type LoanEvent struct {
+	ID         uint              `json:"id"`
+	CopyID     uint              `json:"copy_id"`
+	BookID     uint              `json:"book_id"`
+	MemberID   string            `json:"member_id"`
+	Status     models.LoanStatus `json:"status"`
+	DueAt      time.Time         `json:"due_at"`
+	ReturnedAt *time.Time        `json:"returned_at,omitempty"`
+	Renewals   int               `json:"renewals"`
+}
+
+func NewLoanEvent(eventType EventType, loan *models.Loan) (*Event, error) {
+	data, err := json.Marshal(LoanEvent{
+		ID:         loan.ID,
+		CopyID:     loan.CopyID,
+		BookID:     loan.BookID,
+		MemberID:   loan.MemberID,
+		Status:     loan.Status,
+		DueAt:      loan.DueAt,
+		ReturnedAt: loan.ReturnedAt,
+		Renewals:   loan.Renewals,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		Data:      data,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+type HoldEvent struct {
+	ID        uint              `json:"id"`
+	BookID    uint              `json:"book_id"`
+	MemberID  string            `json:"member_id"`
+	Status    models.HoldStatus `json:"status"`
+	CopyID    *uint             `json:"copy_id,omitempty"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+}
+
+func NewHoldEvent(eventType EventType, hold *models.Hold) (*Event, error) {
+	data, err := json.Marshal(HoldEvent{
+		ID:        hold.ID,
+		BookID:    hold.BookID,
+		MemberID:  hold.MemberID,
+		Status:    hold.Status,
+		CopyID:    hold.CopyID,
+		ExpiresAt: hold.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		Data:      data,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+type ReviewEvent struct {
+	ID             uint                `json:"id"`
+	BookID         uint                `json:"book_id"`
+	UserID         string              `json:"user_id"`
+	Rating         int                 `json:"rating"`
+	Status         models.ReviewStatus `json:"status"`
+	PreviousStatus models.ReviewStatus `json:"previous_status,omitempty"`
+}
+
+func NewReviewEvent(eventType EventType, review *models.Review, previousStatus models.ReviewStatus) (*Event, error) {
+	data, err := json.Marshal(ReviewEvent{
+		ID:             review.ID,
+		BookID:         review.BookID,
+		UserID:         review.UserID,
+		Rating:         review.Rating,
+		Status:         review.Status,
+		PreviousStatus: previousStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		Data:      data,
+		Timestamp: time.Now(),
+	}, nil
+}
@@ -11,6 +11,33 @@ type EventService interface {
 	PublishBookCreated(ctx context.Context, book *models.Book) error
 	PublishBookUpdated(ctx context.Context, book *models.Book) error
 	PublishBookDeleted(ctx context.Context, bookID uint) error
+
+	PublishSeriesBookAdded(ctx context.Context, entry *models.SeriesEntry) error
+	PublishSeriesBookMoved(ctx context.Context, entry *models.SeriesEntry, previousPosition float64) error
+	PublishSeriesBookRemoved(ctx context.Context, seriesID, bookID uint) error
+
+	PublishCopyCreated(ctx context.Context, bookCopy *models.Copy) error
+	// PublishCopyUpdated reports previousStatus so consumers can track
+	// availability without keeping state
+	PublishCopyUpdated(ctx context.Context, bookCopy *models.Copy, previousStatus models.CopyStatus) error
+	PublishCopyDeleted(ctx context.Context, bookID, copyID uint) error
+
+	PublishLoanCreated(ctx context.Context, loan *models.Loan) error
+	PublishLoanRenewed(ctx context.Context, loan *models.Loan) error
+	PublishLoanReturned(ctx context.Context, loan *models.Loan) error
+	PublishLoanOverdue(ctx context.Context, loan *models.Loan) error
+
+	PublishHoldPlaced(ctx context.Context, hold *models.Hold) error
+	// PublishHoldReady tells notification services a copy is waiting for
+	// the member until the hold expires
+	PublishHoldReady(ctx context.Context, hold *models.Hold) error
+	PublishHoldCancelled(ctx context.Context, hold *models.Hold) error
+	PublishHoldExpired(ctx context.Context, hold *models.Hold) error
+
+	PublishReviewCreated(ctx context.Context, review *models.Review) error
+	PublishReviewUpdated(ctx context.Context, review *models.Review, previousStatus models.ReviewStatus) error
+	PublishReviewModerated(ctx context.Context, review *models.Review, previousStatus models.ReviewStatus) error
+	PublishReviewDeleted(ctx context.Context, review *models.Review) error
 }
 
 type eventService struct {
@@ -45,3 +72,159 @@ func (s *eventService) PublishBookDeleted(ctx context.Context, bookID uint) erro
 	event := NewBookDeletedEvent(bookID)
 	return s.producer.PublishEvent(ctx, event)
 }
+
+func (s *eventService) PublishSeriesBookAdded(ctx context.Context, entry *models.SeriesEntry) error {
+	event, err := NewSeriesEvent(EventTypeSeriesBookAdded, SeriesEvent{
+		SeriesID: entry.SeriesID,
+		BookID:   entry.BookID,
+		Position: &entry.Position,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create series book added event: %w", err)
+	}
+
+	return s.producer.PublishEvent(ctx, event)
+}
+
+func (s *eventService) PublishSeriesBookMoved(ctx context.Context, entry *models.SeriesEntry, previousPosition float64) error {
+	event, err := NewSeriesEvent(EventTypeSeriesBookMoved, SeriesEvent{
+		SeriesID:         entry.SeriesID,
+		BookID:           entry.BookID,
+		Position:         &entry.Position,
+		PreviousPosition: &previousPosition,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create series book moved event: %w", err)
+	}
+
+	return s.producer.PublishEvent(ctx, event)
+}
+
+func (s *eventService) PublishSeriesBookRemoved(ctx context.Context, seriesID, bookID uint) error {
+	event, err := NewSeriesEvent(EventTypeSeriesBookRemoved, SeriesEvent{
+		SeriesID: seriesID,
+		BookID:   bookID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create series book removed event: %w", err)
+	}
+
+	return s.producer.PublishEvent(ctx, event)
+}
+
+func (s *eventService) PublishCopyCreated(ctx context.Context, bookCopy *models.Copy) error {
+	event, err := NewCopyEvent(EventTypeCopyCreated, copyEvent(bookCopy))
+	if err != nil {
+		return fmt.Errorf("failed to create copy created event: %w", err)
+	}
+
+	return s.producer.PublishEvent(ctx, event)
+}
+
+func (s *eventService) PublishCopyUpdated(ctx context.Context, bookCopy *models.Copy, previousStatus models.CopyStatus) error {
+	data := copyEvent(bookCopy)
+	data.PreviousStatus = previousStatus
+
+	event, err := NewCopyEvent(EventTypeCopyUpdated, data)
+	if err != nil {
+		return fmt.Errorf("failed to create copy updated event: %w", err)
+	}
+
+	return s.producer.PublishEvent(ctx, event)
+}
+
+func (s *eventService) PublishCopyDeleted(ctx context.Context, bookID, copyID uint) error {
+	event, err := NewCopyEvent(EventTypeCopyDeleted, CopyEvent{ID: copyID, BookID: bookID})
+	if err != nil {
+		return fmt.Errorf("failed to create copy deleted event: %w", err)
+	}
+
+	return s.producer.PublishEvent(ctx, event)
+}
+
+func (s *eventService) PublishLoanCreated(ctx context.Context, loan *models.Loan) error {
+	return s.publishLoan(ctx, EventTypeLoanCreated, loan)
+}
+
+func (s *eventService) PublishLoanRenewed(ctx context.Context, loan *models.Loan) error {
+	return s.publishLoan(ctx, EventTypeLoanRenewed, loan)
+}
+
+func (s *eventService) PublishLoanReturned(ctx context.Context, loan *models.Loan) error {
+	return s.publishLoan(ctx, EventTypeLoanReturned, loan)
+}
+
+func (s *eventService) PublishLoanOverdue(ctx context.Context, loan *models.Loan) error {
+	return s.publishLoan(ctx, EventTypeLoanOverdue, loan)
+}
+
+func (s *eventService) publishLoan(ctx context.Context, eventType EventType, loan *models.Loan) error {
+	event, err := NewLoanEvent(eventType, loan)
+	if err != nil {
+		return fmt.Errorf("failed to create %s event: %w", eventType, err)
+	}
+
+	return s.producer.PublishEvent(ctx, event)
+}
+
+func (s *eventService) PublishHoldPlaced(ctx context.Context, hold *models.Hold) error {
+	return s.publishHold(ctx, EventTypeHoldPlaced, hold)
+}
+
+func (s *eventService) PublishHoldReady(ctx context.Context, hold *models.Hold) error {
+	return s.publishHold(ctx, EventTypeHoldReady, hold)
+}
+
+func (s *eventService) PublishHoldCancelled(ctx context.Context, hold *models.Hold) error {
+	return s.publishHold(ctx, EventTypeHoldCancelled, hold)
+}
+
+func (s *eventService) PublishHoldExpired(ctx context.Context, hold *models.Hold) error {
+	return s.publishHold(ctx, EventTypeHoldExpired, hold)
+}
+
+func (s *eventService) publishHold(ctx context.Context, eventType EventType, hold *models.Hold) error {
+	event, err := NewHoldEvent(eventType, hold)
+	if err != nil {
+		return fmt.Errorf("failed to create %s event: %w", eventType, err)
+	}
+
+	return s.producer.PublishEvent(ctx, event)
+}
+
+func (s *eventService) PublishReviewCreated(ctx context.Context, review *models.Review) error {
+	return s.publishReview(ctx, EventTypeReviewCreated, review, "")
+}
+
+func (s *eventService) PublishReviewUpdated(ctx context.Context, review *models.Review, previousStatus models.ReviewStatus) error {
+	return s.publishReview(ctx, EventTypeReviewUpdated, review, previousStatus)
+}
+
+func (s *eventService) PublishReviewModerated(ctx context.Context, review *models.Review, previousStatus models.ReviewStatus) error {
+	return s.publishReview(ctx, EventTypeReviewModerated, review, previousStatus)
+}
+
+func (s *eventService) PublishReviewDeleted(ctx context.Context, review *models.Review) error {
+	return s.publishReview(ctx, EventTypeReviewDeleted, review, "")
+}
+
+func (s *eventService) publishReview(ctx context.Context, eventType EventType, review *models.Review, previousStatus models.ReviewStatus) error {
+	event, err := NewReviewEvent(eventType, review, previousStatus)
+	if err != nil {
+		return fmt.Errorf("failed to create %s event: %w", eventType, err)
+	}
+
+	return s.producer.PublishEvent(ctx, event)
+}
+
+func copyEvent(bookCopy *models.Copy) CopyEvent {
+	return CopyEvent{
+		ID:        bookCopy.ID,
+		BookID:    bookCopy.BookID,
+		Barcode:   bookCopy.Barcode,
+		Branch:    bookCopy.Branch,
+		Location:  bookCopy.Location,
+		Condition: bookCopy.Condition,
+		Status:    bookCopy.Status,
+	}
+}
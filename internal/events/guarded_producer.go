@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/breaker"
+	"github.com/AhmadMuj/books-api-go/internal/config"
+)
+
+// GuardedProducer bounds how long a request waits on publishing an event
+// and drops events without trying while Kafka keeps failing, so an outage
+// does not slow down the writes that publish them.
+type GuardedProducer struct {
+	next    Producer
+	breaker *breaker.Breaker
+	timeout time.Duration
+	// async is set when next delivers in the background, so a write that
+	// returns says nothing yet about Kafka
+	async bool
+}
+
+func NewGuardedProducer(next Producer, cfg config.KafkaConfig) *GuardedProducer {
+	p := &GuardedProducer{
+		next:    next,
+		breaker: breaker.New("events", cfg.BreakerFailures, cfg.BreakerCooldown),
+		timeout: cfg.PublishTimeout,
+	}
+
+	// Asynchronous writes only fail once delivery is attempted, so only
+	// those outcomes are counted
+	if kafkaProducer, ok := next.(*KafkaProducer); ok {
+		p.async = true
+		kafkaProducer.OnDelivery(func(err error) {
+			if err != nil {
+				p.breaker.Failure()
+			} else {
+				p.breaker.Success()
+			}
+		})
+	}
+
+	return p
+}
+
+func (p *GuardedProducer) PublishEvent(ctx context.Context, event *Event) error {
+	if !p.breaker.Allow() {
+		return fmt.Errorf("event %s dropped: %w", event.Type, breaker.ErrOpen)
+	}
+
+	publishCtx := ctx
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		publishCtx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	err := p.next.PublishEvent(publishCtx, event)
+	switch {
+	case p.async && err == nil:
+		// A half-open probe stays in flight until its delivery is reported
+	case p.async:
+		// Queueing fails only for reasons of our own, such as a cancelled
+		// request or a closed writer
+		p.breaker.Ignore()
+	case err == nil:
+		p.breaker.Success()
+	case ctx.Err() != nil:
+		p.breaker.Ignore()
+	default:
+		p.breaker.Failure()
+	}
+	return err
+}
+
+// Check reports whether events are being published, for health checks
+func (p *GuardedProducer) Check(ctx context.Context) error {
+	if state := p.breaker.State(); state != breaker.Closed {
+		return fmt.Errorf("circuit breaker is %s", state)
+	}
+	return nil
+}
+
+func (p *GuardedProducer) Close() error {
+	return p.next.Close()
+}
@@ -4,11 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"time"
 
 	"github.com/AhmadMuj/books-api-go/internal/config"
 	"github.com/segmentio/kafka-go"
 )
 
+// Backoff between failed reads, so an unreachable broker is not retried in
+// a tight loop
+const (
+	minReadBackoff = 100 * time.Millisecond
+	maxReadBackoff = 30 * time.Second
+)
+
 type Consumer struct {
 	reader *kafka.Reader
 }
@@ -27,6 +35,7 @@ func NewKafkaConsumer(cfg *config.Config) (*Consumer, error) {
 
 func (c *Consumer) Start(ctx context.Context) error {
 	go func() {
+		backoff := minReadBackoff
 		for {
 			select {
 			case <-ctx.Done():
@@ -34,9 +43,21 @@ func (c *Consumer) Start(ctx context.Context) error {
 			default:
 				message, err := c.reader.ReadMessage(ctx)
 				if err != nil {
-					log.Printf("Error reading message: %v\n", err)
+					if ctx.Err() != nil {
+						return
+					}
+					log.Printf("Error reading message, retrying in %s: %v\n", backoff, err)
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(backoff):
+					}
+					if backoff *= 2; backoff > maxReadBackoff {
+						backoff = maxReadBackoff
+					}
 					continue
 				}
+				backoff = minReadBackoff
 
 				var event Event
 				if err := json.Unmarshal(message.Value, &event); err != nil {
@@ -52,6 +73,12 @@ func (c *Consumer) Start(ctx context.Context) error {
 	return nil
 }
 
+// Stats returns the reader's statistics, including its lag. Counters cover
+// the time since the previous call.
+func (c *Consumer) Stats() kafka.ReaderStats {
+	return c.reader.Stats()
+}
+
 func (c *Consumer) Close() error {
 	return c.reader.Close()
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 
 	"github.com/AhmadMuj/books-api-go/internal/config"
 	"github.com/segmentio/kafka-go"
@@ -17,20 +18,45 @@ type Producer interface {
 type KafkaProducer struct {
 	writer *kafka.Writer
 	topic  string
+
+	onDelivery func(err error)
 }
 
 func NewKafkaProducer(cfg *config.Config) (Producer, error) {
-	writer := kafka.NewWriter(kafka.WriterConfig{
+	p := &KafkaProducer{
+		topic: cfg.Kafka.Topic,
+	}
+
+	p.writer = kafka.NewWriter(kafka.WriterConfig{
 		Brokers: cfg.Kafka.Brokers,
 		Topic:   cfg.Kafka.Topic,
 		// Async production for better performance
 		Async: true,
 	})
+	p.writer.Completion = p.completed
+
+	return p, nil
+}
+
+// OnDelivery registers fn to receive the outcome of each batch delivered in
+// the background. It must be set before events are published.
+func (p *KafkaProducer) OnDelivery(fn func(err error)) {
+	p.onDelivery = fn
+}
+
+// Stats returns the writer's statistics. Counters cover the time since the
+// previous call.
+func (p *KafkaProducer) Stats() kafka.WriterStats {
+	return p.writer.Stats()
+}
 
-	return &KafkaProducer{
-		writer: writer,
-		topic:  cfg.Kafka.Topic,
-	}, nil
+func (p *KafkaProducer) completed(messages []kafka.Message, err error) {
+	if err != nil {
+		log.Printf("Failed to deliver %d events: %v\n", len(messages), err)
+	}
+	if p.onDelivery != nil {
+		p.onDelivery(err)
+	}
 }
 
 func (p *KafkaProducer) PublishEvent(ctx context.Context, event *Event) error {
@@ -0,0 +1,15 @@
+package events
+
+import "context"
+
+// NoopProducer drops every event. It stands in for Kafka when the producer
+// cannot be created, so the API can run without event streaming.
+type NoopProducer struct{}
+
+func (NoopProducer) PublishEvent(ctx context.Context, event *Event) error {
+	return nil
+}
+
+func (NoopProducer) Close() error {
+	return nil
+}
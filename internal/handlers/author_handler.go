@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type AuthorHandler struct {
+	authorService service.AuthorService
+}
+
+func NewAuthorHandler(authorService service.AuthorService) *AuthorHandler {
+	return &AuthorHandler{
+		authorService: authorService,
+	}
+}
+
+// @Summary Create a new author
+// @Description Create a new author. Names that only differ in spacing or punctuation are treated as the same author.
+// @Tags authors
+// @Accept json
+// @Produce json
+// @Param author body dto.CreateAuthorRequest true "Author details"
+// @Success 201 {object} dto.AuthorResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /authors [post]
+func (h *AuthorHandler) CreateAuthor(c *gin.Context) {
+	var req dto.CreateAuthorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	author := &models.Author{
+		Name: req.Name,
+	}
+
+	if err := h.authorService.CreateAuthor(c.Request.Context(), author); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToAuthorResponse(author))
+}
+
+// @Summary Get an author by ID
+// @Description Get an author's details by its ID
+// @Tags authors
+// @Produce json
+// @Param id path int true "Author ID"
+// @Success 200 {object} dto.AuthorResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /authors/{id} [get]
+func (h *AuthorHandler) GetAuthor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidAuthorID))
+		return
+	}
+
+	author, err := h.authorService.GetAuthor(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAuthorResponse(author))
+}
+
+// @Summary List all authors
+// @Description Get a paginated list of authors ordered by name
+// @Tags authors
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} dto.ListAuthorsResponse
+// @Failure 500 {object} errors.Problem
+// @Router /authors [get]
+func (h *AuthorHandler) ListAuthors(c *gin.Context) {
+	page, pageSize := pageParams(c)
+
+	authors, total, err := h.authorService.ListAuthors(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ListAuthorsResponse{
+		Authors:    dto.ToAuthorResponseList(authors),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages(total, pageSize),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Update an author
+// @Description Rename an author by its ID
+// @Tags authors
+// @Accept json
+// @Produce json
+// @Param id path int true "Author ID"
+// @Param author body dto.UpdateAuthorRequest true "Author details"
+// @Success 200 {object} dto.AuthorResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /authors/{id} [put]
+func (h *AuthorHandler) UpdateAuthor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidAuthorID))
+		return
+	}
+
+	var req dto.UpdateAuthorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	author := &models.Author{
+		Name: req.Name,
+	}
+
+	if err := h.authorService.UpdateAuthor(c.Request.Context(), uint(id), author); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAuthorResponse(author))
+}
+
+// @Summary Delete an author
+// @Description Delete an author by its ID. Authors still linked to books cannot be deleted.
+// @Tags authors
+// @Produce json
+// @Param id path int true "Author ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /authors/{id} [delete]
+func (h *AuthorHandler) DeleteAuthor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidAuthorID))
+		return
+	}
+
+	if err := h.authorService.DeleteAuthor(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List an author's books
+// @Description Get a paginated list of books the author contributed to in any role
+// @Tags authors
+// @Produce json
+// @Param id path int true "Author ID"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} dto.ListBooksResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /authors/{id}/books [get]
+func (h *AuthorHandler) ListAuthorBooks(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidAuthorID))
+		return
+	}
+
+	page, pageSize := pageParams(c)
+
+	books, total, err := h.authorService.ListAuthorBooks(c.Request.Context(), uint(id), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ListBooksResponse{
+		Books:      dto.ToBookResponseList(books),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages(total, pageSize),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	stderrors "errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/AhmadMuj/books-api-go/internal/dto"
 	"github.com/AhmadMuj/books-api-go/internal/errors"
@@ -11,13 +14,19 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// multipartOverhead is the room left for multipart headers on top of the
+// cover size limit
+const multipartOverhead = 64 << 10
+
 type BookHandler struct {
-	bookService service.BookService
+	bookService  service.BookService
+	maxCoverSize int
 }
 
-func NewBookHandler(bookService service.BookService) *BookHandler {
+func NewBookHandler(bookService service.BookService, maxCoverSize int) *BookHandler {
 	return &BookHandler{
-		bookService: bookService,
+		bookService:  bookService,
+		maxCoverSize: maxCoverSize,
 	}
 }
 
@@ -28,33 +37,35 @@ func NewBookHandler(bookService service.BookService) *BookHandler {
 // @Produce json
 // @Param book body dto.CreateBookRequest true "Book details"
 // @Success 201 {object} dto.BookResponse
-// @Failure 400 {object} errors.AppError
-// @Failure 500 {object} errors.AppError
+// @Failure 400 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
 // @Router /books [post]
 func (h *BookHandler) CreateBook(c *gin.Context) {
 	var req dto.CreateBookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, errors.NewValidationError(err.Error()))
+		c.Error(errors.NewBindingError(err))
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, err)
+		c.Error(err)
 		return
 	}
 
 	book := &models.Book{
-		Title:  req.Title,
-		Author: req.Author,
-		Year:   req.Year,
+		Title:   req.Title,
+		Author:  req.Author,
+		Year:    req.Year,
+		Authors: dto.ToBookAuthors(req.Author, req.Authors),
+		Genres:  dto.ToBookGenres(req.Genres),
+		Tags:    dto.ToBookTags(req.Tags),
 	}
+	dto.ApplyISBN(book, req.ISBN)
+	req.ApplyEdition(book)
 
 	if err := h.bookService.CreateBook(c.Request.Context(), book); err != nil {
-		statusCode := http.StatusInternalServerError
-		if _, ok := err.(*errors.AppError); ok {
-			statusCode = http.StatusBadRequest
-		}
-		c.JSON(statusCode, err)
+		c.Error(err)
 		return
 	}
 
@@ -67,25 +78,46 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Book ID"
 // @Success 200 {object} dto.BookResponse
-// @Failure 404 {object} errors.AppError
-// @Failure 500 {object} errors.AppError
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
 // @Router /books/{id} [get]
 func (h *BookHandler) GetBook(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, errors.NewValidationError("invalid book ID"))
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
 		return
 	}
 
 	book, err := h.bookService.GetBook(c.Request.Context(), uint(id))
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if appErr, ok := err.(*errors.AppError); ok {
-			if appErr.Type == errors.NotFound {
-				statusCode = http.StatusNotFound
-			}
-		}
-		c.JSON(statusCode, err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToBookResponse(book))
+}
+
+// @Summary Get a book by ISBN
+// @Description Get a book's details by its ISBN-10 or ISBN-13. Hyphens and spaces are ignored.
+// @Tags books
+// @Produce json
+// @Param isbn path string true "ISBN-10 or ISBN-13"
+// @Success 200 {object} dto.BookResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/isbn/{isbn} [get]
+func (h *BookHandler) GetBookByISBN(c *gin.Context) {
+	isbn, err := dto.NormalizeISBN(c.Param("isbn"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	book, err := h.bookService.GetBookByISBN(c.Request.Context(), isbn)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -93,84 +125,127 @@ func (h *BookHandler) GetBook(c *gin.Context) {
 }
 
 // @Summary List all books
-// @Description Get a paginated list of books
+// @Description Get a paginated list of books with genre, tag and decade facet counts for the current filter
 // @Tags books
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param size query int false "Page size" default(10)
+// @Param genre query string false "Genre slug; includes its sub-genres"
+// @Param tag query []string false "Tag slug; repeat to require several tags" collectionFormat(multi)
+// @Param sort query string false "Sort order" Enums(newest, oldest, title, year, rating, popular) default(newest)
 // @Success 200 {object} dto.ListBooksResponse
-// @Failure 500 {object} errors.AppError
+// @Failure 400 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
 // @Router /books [get]
 func (h *BookHandler) ListBooks(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+	page, pageSize := pageParams(c)
+	filter := bookFilter(c)
+	if !filter.Sort.Valid() {
+		c.Error(errors.NewFieldValidationError("sort", "oneof", errors.MsgInvalidBookSort))
+		return
+	}
 
-	books, total, err := h.bookService.ListBooks(c.Request.Context(), page, pageSize)
+	list, err := h.bookService.ListBooks(c.Request.Context(), filter, page, pageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, err)
+		c.Error(err)
 		return
 	}
 
-	totalPages := (int(total) + pageSize - 1) / pageSize
-
 	response := dto.ListBooksResponse{
-		Books:      dto.ToBookResponseList(books),
+		Books:      dto.ToBookResponseList(list.Books),
 		Page:       page,
 		PageSize:   pageSize,
-		TotalItems: total,
-		TotalPages: totalPages,
+		TotalItems: list.Total,
+		TotalPages: totalPages(list.Total, pageSize),
+		Facets:     list.Facets,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// @Summary Get catalogue statistics
+// @Description Get the total number of books, books per publication year and decade, the most prolific authors and the books added per day over a date range with its growth compared to the period before
+// @Tags stats
+// @Produce json
+// @Param from query string false "First day of the range (YYYY-MM-DD, UTC); defaults to 29 days before to"
+// @Param to query string false "Last day of the range (YYYY-MM-DD, UTC); defaults to today"
+// @Param top query int false "Number of authors to rank" default(10)
+// @Success 200 {object} dto.StatsResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /stats [get]
+func (h *BookHandler) GetStats(c *gin.Context) {
+	var filter models.StatsFilter
+	dates := []struct {
+		field string
+		value *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}}
+	for _, date := range dates {
+		value := c.Query(date.field)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			c.Error(errors.NewFieldValidationError(date.field, "datetime", errors.MsgInvalidStatsDate, date.field))
+			return
+		}
+		*date.value = parsed
+	}
+	filter.TopAuthors, _ = strconv.Atoi(c.Query("top"))
+
+	stats, err := h.bookService.GetStats(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToStatsResponse(stats))
+}
+
 // @Summary Update a book
-// @Description Update a book's details by its ID
+// @Description Replace a book's details by its ID. Every field must be sent: a field left out is rejected rather than kept or cleared, and an empty string, empty list or null clears it. work_id must name the book's work, or another work to move the edition to.
 // @Tags books
 // @Accept json
 // @Produce json
 // @Param id path int true "Book ID"
 // @Param book body dto.UpdateBookRequest true "Book details"
 // @Success 200 {object} dto.BookResponse
-// @Failure 400 {object} errors.AppError
-// @Failure 404 {object} errors.AppError
-// @Failure 500 {object} errors.AppError
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
 // @Router /books/{id} [put]
 func (h *BookHandler) UpdateBook(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, errors.NewValidationError("invalid book ID"))
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
 		return
 	}
 
 	var req dto.UpdateBookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, errors.NewValidationError(err.Error()))
+		c.Error(errors.NewBindingError(err))
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, err)
+		c.Error(err)
 		return
 	}
 
 	book := &models.Book{
-		Title:  req.Title,
-		Author: req.Author,
-		Year:   req.Year,
+		Title:   req.Title,
+		Author:  req.Author,
+		Year:    req.Year,
+		Authors: dto.ToBookAuthors(req.Author, req.Authors),
+		Genres:  dto.ToBookGenres(req.Genres),
+		Tags:    dto.ToBookTags(req.Tags),
 	}
+	dto.ApplyISBN(book, req.ISBN)
+	req.ApplyEdition(book)
 
 	if err := h.bookService.UpdateBook(c.Request.Context(), uint(id), book); err != nil {
-		statusCode := http.StatusInternalServerError
-		if appErr, ok := err.(*errors.AppError); ok {
-			switch appErr.Type {
-			case errors.NotFound:
-				statusCode = http.StatusNotFound
-			case errors.ValidationErr:
-				statusCode = http.StatusBadRequest
-			}
-		}
-		c.JSON(statusCode, err)
+		c.Error(err)
 		return
 	}
 
@@ -183,26 +258,77 @@ func (h *BookHandler) UpdateBook(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Book ID"
 // @Success 204 "No Content"
-// @Failure 404 {object} errors.AppError
-// @Failure 500 {object} errors.AppError
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
 // @Router /books/{id} [delete]
 func (h *BookHandler) DeleteBook(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, errors.NewValidationError("invalid book ID"))
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
 		return
 	}
 
 	if err := h.bookService.DeleteBook(c.Request.Context(), uint(id)); err != nil {
-		statusCode := http.StatusInternalServerError
-		if appErr, ok := err.(*errors.AppError); ok {
-			if appErr.Type == errors.NotFound {
-				statusCode = http.StatusNotFound
-			}
-		}
-		c.JSON(statusCode, err)
+		c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// @Summary Upload a book cover
+// @Description Upload a JPEG, PNG or WebP cover image, replacing the current one. Medium and thumbnail variants are generated from it.
+// @Tags books
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param cover formData file true "Cover image"
+// @Success 200 {object} dto.BookResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 413 {object} errors.Problem
+// @Failure 415 {object} errors.Problem
+// @Failure 422 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/cover [put]
+func (h *BookHandler) SetBookCover(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, int64(h.maxCoverSize+multipartOverhead))
+
+	file, header, err := c.Request.FormFile("cover")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			c.Error(errors.NewLocalizedError(errors.TooLarge, errors.MsgCoverTooLarge, h.maxCoverSize))
+			return
+		}
+		c.Error(errors.NewFieldValidationError("cover", "required", errors.MsgCoverRequired))
+		return
+	}
+	defer file.Close()
+
+	if header.Size > int64(h.maxCoverSize) {
+		c.Error(errors.NewLocalizedError(errors.TooLarge, errors.MsgCoverTooLarge, h.maxCoverSize))
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, int64(h.maxCoverSize)+1))
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgBodyUnreadable))
+		return
+	}
+
+	book, err := h.bookService.SetBookCover(c.Request.Context(), uint(id), data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToBookResponse(book))
+}
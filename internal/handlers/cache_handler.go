@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type CacheHandler struct {
+	cacheService service.CacheService
+	bookService  service.BookService
+}
+
+func NewCacheHandler(cacheService service.CacheService, bookService service.BookService) *CacheHandler {
+	return &CacheHandler{
+		cacheService: cacheService,
+		bookService:  bookService,
+	}
+}
+
+// @Summary Get cache statistics
+// @Description Get the calls, hits, misses, skipped lookups, errors and latency histogram of each cache operation since the instance started
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {object} dto.CacheStatsResponse
+// @Failure 401 {object} errors.Problem
+// @Router /admin/cache/stats [get]
+func (h *CacheHandler) GetStats(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.ToCacheStatsResponse(h.cacheService.Stats()))
+}
+
+// @Summary Inspect a cached book
+// @Description Get the cache entry for a book as stored, with the time it has left to live
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.CachedBookResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /admin/cache/books/{id} [get]
+func (h *CacheHandler) InspectBook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	entry, ttl, err := h.cacheService.InspectBook(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCachedBookResponse(cache.BookKey(uint(id)), entry, ttl))
+}
+
+// @Summary Evict a cache key
+// @Description Delete a single cache entry by its full key, such as book:42
+// @Tags admin
+// @Param X-Admin-Token header string true "Admin token"
+// @Param key path string true "Cache key"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /admin/cache/keys/{key} [delete]
+func (h *CacheHandler) EvictKey(c *gin.Context) {
+	if err := h.cacheService.EvictKey(c.Request.Context(), c.Param("key")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Warm the cache
+// @Description Reload the first pages of the book listing and the most borrowed books into the cache
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param warmup body dto.WarmCacheRequest false "What to load"
+// @Success 200 {object} dto.WarmCacheResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /admin/cache/warm [post]
+func (h *CacheHandler) WarmCache(c *gin.Context) {
+	var req dto.WarmCacheRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(errors.NewBindingError(err))
+			return
+		}
+	}
+
+	warmup, err := h.bookService.WarmCache(c.Request.Context(), req.PageCount(), req.BookCount())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToWarmCacheResponse(warmup))
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type CopyHandler struct {
+	copyService service.CopyService
+}
+
+func NewCopyHandler(copyService service.CopyService) *CopyHandler {
+	return &CopyHandler{
+		copyService: copyService,
+	}
+}
+
+// @Summary Add a copy of a book
+// @Description Register a physical copy of a book at a branch. Copies default to good condition and available.
+// @Tags copies
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param copy body dto.CreateCopyRequest true "Copy details"
+// @Success 201 {object} dto.CopyResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/copies [post]
+func (h *CopyHandler) CreateCopy(c *gin.Context) {
+	bookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	var req dto.CreateCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	bookCopy := dto.ToNewCopy(uint(bookID), &req)
+
+	if err := h.copyService.CreateCopy(c.Request.Context(), bookCopy); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToCopyResponse(bookCopy))
+}
+
+// @Summary Get a copy of a book
+// @Description Get a physical copy's details by its ID
+// @Tags copies
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param copy_id path int true "Copy ID"
+// @Success 200 {object} dto.CopyResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/copies/{copy_id} [get]
+func (h *CopyHandler) GetCopy(c *gin.Context) {
+	bookID, copyID, ok := copyParams(c)
+	if !ok {
+		return
+	}
+
+	bookCopy, err := h.copyService.GetCopy(c.Request.Context(), bookID, copyID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCopyResponse(bookCopy))
+}
+
+// @Summary List copies of a book
+// @Description Get every physical copy of a book with total and available counts
+// @Tags copies
+// @Produce json
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ListCopiesResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/copies [get]
+func (h *CopyHandler) ListCopies(c *gin.Context) {
+	bookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	copies, err := h.copyService.ListCopies(c.Request.Context(), uint(bookID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToListCopiesResponse(copies))
+}
+
+// @Summary Update a copy of a book
+// @Description Update a physical copy's barcode, location, condition or status
+// @Tags copies
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param copy_id path int true "Copy ID"
+// @Param copy body dto.UpdateCopyRequest true "Copy details"
+// @Success 200 {object} dto.CopyResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/copies/{copy_id} [put]
+func (h *CopyHandler) UpdateCopy(c *gin.Context) {
+	bookID, copyID, ok := copyParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	bookCopy := &models.Copy{
+		Barcode:   req.Barcode,
+		Branch:    req.Branch,
+		Location:  req.Location,
+		Condition: models.CopyCondition(req.Condition),
+		Status:    models.CopyStatus(req.Status),
+	}
+
+	if err := h.copyService.UpdateCopy(c.Request.Context(), bookID, copyID, bookCopy); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCopyResponse(bookCopy))
+}
+
+// @Summary Delete a copy of a book
+// @Description Remove a physical copy from the inventory
+// @Tags copies
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param copy_id path int true "Copy ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/copies/{copy_id} [delete]
+func (h *CopyHandler) DeleteCopy(c *gin.Context) {
+	bookID, copyID, ok := copyParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.copyService.DeleteCopy(c.Request.Context(), bookID, copyID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// copyParams reads the book and copy IDs from the path, recording an error
+// on the context if either is invalid
+func copyParams(c *gin.Context) (uint, uint, bool) {
+	bookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return 0, 0, false
+	}
+
+	copyID, err := strconv.ParseUint(c.Param("copy_id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidCopyID))
+		return 0, 0, false
+	}
+
+	return uint(bookID), uint(copyID), true
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type GenreHandler struct {
+	genreService service.GenreService
+}
+
+func NewGenreHandler(genreService service.GenreService) *GenreHandler {
+	return &GenreHandler{
+		genreService: genreService,
+	}
+}
+
+// @Summary Create a new genre
+// @Description Create a new genre, optionally nested under a parent genre
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param genre body dto.CreateGenreRequest true "Genre details"
+// @Success 201 {object} dto.GenreResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /genres [post]
+func (h *GenreHandler) CreateGenre(c *gin.Context) {
+	var req dto.CreateGenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	genre := &models.Genre{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	}
+
+	if err := h.genreService.CreateGenre(c.Request.Context(), genre); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToGenreResponse(genre))
+}
+
+// @Summary Get a genre by ID
+// @Description Get a genre's details by its ID
+// @Tags genres
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Success 200 {object} dto.GenreResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /genres/{id} [get]
+func (h *GenreHandler) GetGenre(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidGenreID))
+		return
+	}
+
+	genre, err := h.genreService.GetGenre(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToGenreResponse(genre))
+}
+
+// @Summary List all genres
+// @Description Get every genre ordered by name. Use parent_id to build the hierarchy.
+// @Tags genres
+// @Produce json
+// @Success 200 {object} dto.ListGenresResponse
+// @Failure 500 {object} errors.Problem
+// @Router /genres [get]
+func (h *GenreHandler) ListGenres(c *gin.Context) {
+	genres, err := h.genreService.ListGenres(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListGenresResponse{
+		Genres: dto.ToGenreResponseList(genres),
+	})
+}
+
+// @Summary Update a genre
+// @Description Rename a genre or move it under another parent
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Param genre body dto.UpdateGenreRequest true "Genre details"
+// @Success 200 {object} dto.GenreResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /genres/{id} [put]
+func (h *GenreHandler) UpdateGenre(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidGenreID))
+		return
+	}
+
+	var req dto.UpdateGenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	genre := &models.Genre{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	}
+
+	if err := h.genreService.UpdateGenre(c.Request.Context(), uint(id), genre); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToGenreResponse(genre))
+}
+
+// @Summary Delete a genre
+// @Description Delete a genre by its ID and unlink it from its books. Genres with sub-genres cannot be deleted.
+// @Tags genres
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /genres/{id} [delete]
+func (h *GenreHandler) DeleteGenre(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidGenreID))
+		return
+	}
+
+	if err := h.genreService.DeleteGenre(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
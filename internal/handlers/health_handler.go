@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	healthService service.HealthService
+}
+
+func NewHealthHandler(healthService service.HealthService) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+// @Summary Check health
+// @Description Report whether the API and the services it depends on are up. The API keeps serving while the cache or event streaming is down, so those only degrade the status; 503 is returned only when the database is down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.HealthResponse
+// @Failure 503 {object} dto.HealthResponse
+// @Router /health [get]
+func (h *HealthHandler) Check(c *gin.Context) {
+	health := h.healthService.Check(c.Request.Context())
+
+	status := http.StatusOK
+	if health.Status == models.HealthDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, dto.ToHealthResponse(health))
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type HoldHandler struct {
+	holdService service.HoldService
+}
+
+func NewHoldHandler(holdService service.HoldService) *HoldHandler {
+	return &HoldHandler{
+		holdService: holdService,
+	}
+}
+
+// @Summary Place a hold on a book
+// @Description Queue a member for the next copy of a book that has no copies available
+// @Tags holds
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param hold body dto.CreateHoldRequest true "Hold details"
+// @Success 201 {object} dto.HoldResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/holds [post]
+func (h *HoldHandler) PlaceHold(c *gin.Context) {
+	bookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	var req dto.CreateHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	hold := &models.Hold{
+		BookID:   uint(bookID),
+		MemberID: req.MemberID,
+	}
+
+	position, err := h.holdService.PlaceHold(c.Request.Context(), hold)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToHoldResponse(hold, position))
+}
+
+// @Summary List a book's holds
+// @Description Get the open holds on a book in the order they are served
+// @Tags holds
+// @Produce json
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ListHoldsResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/holds [get]
+func (h *HoldHandler) ListBookHolds(c *gin.Context) {
+	bookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	holds, err := h.holdService.ListBookHolds(c.Request.Context(), uint(bookID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToListHoldsResponse(uint(bookID), holds))
+}
+
+// @Summary Get a hold
+// @Description Get a hold's details and its position in the queue
+// @Tags holds
+// @Produce json
+// @Param id path int true "Hold ID"
+// @Success 200 {object} dto.HoldResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /holds/{id} [get]
+func (h *HoldHandler) GetHold(c *gin.Context) {
+	id, ok := holdID(c)
+	if !ok {
+		return
+	}
+
+	hold, position, err := h.holdService.GetHold(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToHoldResponse(hold, position))
+}
+
+// @Summary Cancel a hold
+// @Description Cancel an open hold. A copy set aside for it passes to the next member in the queue.
+// @Tags holds
+// @Produce json
+// @Param id path int true "Hold ID"
+// @Success 200 {object} dto.HoldResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /holds/{id} [delete]
+func (h *HoldHandler) CancelHold(c *gin.Context) {
+	id, ok := holdID(c)
+	if !ok {
+		return
+	}
+
+	hold, err := h.holdService.CancelHold(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToHoldResponse(hold, 0))
+}
+
+func holdID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidHoldID))
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type LoanHandler struct {
+	loanService service.LoanService
+}
+
+func NewLoanHandler(loanService service.LoanService) *LoanHandler {
+	return &LoanHandler{
+		loanService: loanService,
+	}
+}
+
+// @Summary Check out a book
+// @Description Lend a copy to a member. Pass copy_id to lend a specific copy, or book_id to lend any available copy of the book.
+// @Tags loans
+// @Accept json
+// @Produce json
+// @Param loan body dto.CreateLoanRequest true "Loan details"
+// @Success 201 {object} dto.LoanResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /loans [post]
+func (h *LoanHandler) CreateLoan(c *gin.Context) {
+	var req dto.CreateLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	loan := &models.Loan{
+		MemberID: req.MemberID,
+		CopyID:   req.CopyID,
+		BookID:   req.BookID,
+	}
+
+	if err := h.loanService.Checkout(c.Request.Context(), loan); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToLoanResponse(loan))
+}
+
+// @Summary Get a loan
+// @Description Get a loan's details by its ID
+// @Tags loans
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {object} dto.LoanResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /loans/{id} [get]
+func (h *LoanHandler) GetLoan(c *gin.Context) {
+	id, ok := loanID(c)
+	if !ok {
+		return
+	}
+
+	loan, err := h.loanService.GetLoan(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToLoanResponse(loan))
+}
+
+// @Summary List loans
+// @Description Get a paginated list of loans, most recent first
+// @Tags loans
+// @Produce json
+// @Param member_id query string false "Only loans of this member"
+// @Param status query string false "Only loans with this status" Enums(active, overdue, returned)
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} dto.ListLoansResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /loans [get]
+func (h *LoanHandler) ListLoans(c *gin.Context) {
+	page, pageSize := pageParams(c)
+
+	filter := models.LoanFilter{
+		MemberID: c.Query("member_id"),
+		Status:   models.LoanStatus(c.Query("status")),
+	}
+	switch filter.Status {
+	case "", models.LoanActive, models.LoanOverdue, models.LoanReturned:
+	default:
+		c.Error(errors.NewFieldValidationError("status", "oneof", errors.MsgInvalidLoanStatus))
+		return
+	}
+
+	loans, total, err := h.loanService.ListLoans(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ListLoansResponse{
+		Loans:      dto.ToLoanResponseList(loans),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages(total, pageSize),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Return a loan
+// @Description Close a loan and make its copy available again
+// @Tags loans
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {object} dto.LoanResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /loans/{id}/return [post]
+func (h *LoanHandler) ReturnLoan(c *gin.Context) {
+	id, ok := loanID(c)
+	if !ok {
+		return
+	}
+
+	loan, err := h.loanService.ReturnLoan(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToLoanResponse(loan))
+}
+
+// @Summary Renew a loan
+// @Description Extend a loan's due date. Overdue loans and loans at the renewal limit cannot be renewed.
+// @Tags loans
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {object} dto.LoanResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /loans/{id}/renew [post]
+func (h *LoanHandler) RenewLoan(c *gin.Context) {
+	id, ok := loanID(c)
+	if !ok {
+		return
+	}
+
+	loan, err := h.loanService.RenewLoan(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToLoanResponse(loan))
+}
+
+func loanID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidLoanID))
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// pageParams reads page and size from the query string, clamped the same
+// way the services clamp them.
+func pageParams(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("size", strconv.Itoa(defaultPageSize)))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+	return page, pageSize
+}
+
+func totalPages(total int64, pageSize int) int {
+	return (int(total) + pageSize - 1) / pageSize
+}
+
+// bookFilter reads the listing filters from the query string. Values are
+// slugified so "Science Fiction" and "science-fiction" select the same genre.
+func bookFilter(c *gin.Context) models.BookFilter {
+	filter := models.BookFilter{
+		Genre: models.Slugify(c.Query("genre")),
+		Sort:  models.BookSort(c.Query("sort")),
+	}
+	for _, tag := range c.QueryArray("tag") {
+		if slug := models.Slugify(tag); slug != "" {
+			filter.Tags = append(filter.Tags, slug)
+		}
+	}
+	return filter
+}
@@ -0,0 +1,397 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/middleware"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type ReadingListHandler struct {
+	readingListService service.ReadingListService
+}
+
+func NewReadingListHandler(readingListService service.ReadingListService) *ReadingListHandler {
+	return &ReadingListHandler{
+		readingListService: readingListService,
+	}
+}
+
+// @Summary List my reading lists
+// @Description Get the caller's Want to Read, Reading and Read shelves followed by their own lists
+// @Tags reading-lists
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Success 200 {object} dto.ListReadingListsResponse
+// @Failure 401 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists [get]
+func (h *ReadingListHandler) ListMyLists(c *gin.Context) {
+	lists, err := h.readingListService.ListMyLists(c.Request.Context(), middleware.UserID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ListReadingListsResponse{
+		Lists: dto.ToReadingListResponseList(lists),
+	})
+}
+
+// @Summary Create a reading list
+// @Description Create a custom reading list. Lists are private unless created as public.
+// @Tags reading-lists
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Param list body dto.CreateReadingListRequest true "List details"
+// @Success 201 {object} dto.ReadingListResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists [post]
+func (h *ReadingListHandler) CreateList(c *gin.Context) {
+	var req dto.CreateReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	list := &models.ReadingList{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  models.ListVisibility(req.Visibility),
+	}
+
+	if err := h.readingListService.CreateList(c.Request.Context(), middleware.UserID(c), list); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToReadingListResponse(list, true))
+}
+
+// @Summary Get one of my reading lists
+// @Description Get one of the caller's lists with its books in order
+// @Tags reading-lists
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Param id path int true "List ID"
+// @Success 200 {object} dto.ReadingListResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists/{id} [get]
+func (h *ReadingListHandler) GetMyList(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.readingListService.GetMyList(c.Request.Context(), middleware.UserID(c), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReadingListResponse(list, true))
+}
+
+// @Summary Update a reading list
+// @Description Change a list's name, description and visibility. Shelves cannot be renamed.
+// @Tags reading-lists
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Param id path int true "List ID"
+// @Param list body dto.UpdateReadingListRequest true "List details"
+// @Success 200 {object} dto.ReadingListResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists/{id} [put]
+func (h *ReadingListHandler) UpdateList(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	list := &models.ReadingList{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  models.ListVisibility(req.Visibility),
+	}
+
+	updated, err := h.readingListService.UpdateList(c.Request.Context(), middleware.UserID(c), id, list)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReadingListResponse(updated, true))
+}
+
+// @Summary Delete a reading list
+// @Description Delete one of the caller's lists. Shelves cannot be deleted.
+// @Tags reading-lists
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Param id path int true "List ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists/{id} [delete]
+func (h *ReadingListHandler) DeleteList(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.readingListService.DeleteList(c.Request.Context(), middleware.UserID(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Share a reading list
+// @Description Issue a share token that lets anyone with the link view the list, even when it is private. Any earlier token stops working.
+// @Tags reading-lists
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Param id path int true "List ID"
+// @Success 200 {object} dto.ReadingListResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists/{id}/share [post]
+func (h *ReadingListHandler) ShareList(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.readingListService.ShareList(c.Request.Context(), middleware.UserID(c), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReadingListResponse(list, true))
+}
+
+// @Summary Stop sharing a reading list
+// @Description Revoke the list's share token
+// @Tags reading-lists
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Param id path int true "List ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists/{id}/share [delete]
+func (h *ReadingListHandler) UnshareList(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.readingListService.UnshareList(c.Request.Context(), middleware.UserID(c), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Add a book to a reading list
+// @Description Add a book at the end of a list or at a given position. Adding a book to a shelf takes it off the caller's other shelves.
+// @Tags reading-lists
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Param id path int true "List ID"
+// @Param entry body dto.AddListEntryRequest true "Entry details"
+// @Success 201 {object} dto.ListEntryResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists/{id}/entries [post]
+func (h *ReadingListHandler) AddEntry(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AddListEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	entry := req.ToEntry()
+	if err := h.readingListService.AddEntry(c.Request.Context(), middleware.UserID(c), id, entry); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToListEntryResponse(entry))
+}
+
+// @Summary Update a reading list entry
+// @Description Move an entry or change its notes and reading dates
+// @Tags reading-lists
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Param id path int true "List ID"
+// @Param entry_id path int true "Entry ID"
+// @Param entry body dto.UpdateListEntryRequest true "Entry details"
+// @Success 200 {object} dto.ListEntryResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists/{id}/entries/{entry_id} [put]
+func (h *ReadingListHandler) UpdateEntry(c *gin.Context) {
+	id, entryID, ok := listEntryParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateListEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	entry := req.ToEntry()
+	if err := h.readingListService.UpdateEntry(c.Request.Context(), middleware.UserID(c), id, entryID, entry); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToListEntryResponse(entry))
+}
+
+// @Summary Remove a book from a reading list
+// @Description Remove an entry from a list, closing the gap it leaves
+// @Tags reading-lists
+// @Produce json
+// @Param X-User-ID header string true "Caller's user ID"
+// @Param id path int true "List ID"
+// @Param entry_id path int true "Entry ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /me/lists/{id}/entries/{entry_id} [delete]
+func (h *ReadingListHandler) RemoveEntry(c *gin.Context) {
+	id, entryID, ok := listEntryParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.readingListService.RemoveEntry(c.Request.Context(), middleware.UserID(c), id, entryID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get a public reading list
+// @Description Get a list its owner has made public
+// @Tags reading-lists
+// @Produce json
+// @Param id path int true "List ID"
+// @Success 200 {object} dto.ReadingListResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /lists/{id} [get]
+func (h *ReadingListHandler) GetPublicList(c *gin.Context) {
+	id, ok := listID(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.readingListService.GetPublicList(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReadingListResponse(list, false))
+}
+
+// @Summary Get a shared reading list
+// @Description Get a list by its share token, whatever its visibility
+// @Tags reading-lists
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} dto.ReadingListResponse
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /lists/shared/{token} [get]
+func (h *ReadingListHandler) GetSharedList(c *gin.Context) {
+	list, err := h.readingListService.GetSharedList(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReadingListResponse(list, false))
+}
+
+// listID reads the list ID from the path, recording an error on the context
+// if it is invalid
+func listID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidListID))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// listEntryParams reads the list and entry IDs from the path, recording an
+// error on the context if either is invalid
+func listEntryParams(c *gin.Context) (uint, uint, bool) {
+	id, ok := listID(c)
+	if !ok {
+		return 0, 0, false
+	}
+
+	entryID, err := strconv.ParseUint(c.Param("entry_id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidEntryID))
+		return 0, 0, false
+	}
+	return id, uint(entryID), true
+}
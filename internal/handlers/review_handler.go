@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/middleware"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	reviewService service.ReviewService
+}
+
+func NewReviewHandler(reviewService service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// @Summary Review a book
+// @Description Rate a book from 1 to 5 with an optional written review, as the user in the X-User-ID header. Each user may review a book once.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Book ID"
+// @Param review body dto.CreateReviewRequest true "Review details"
+// @Success 201 {object} dto.ReviewResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/reviews [post]
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	bookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	review := &models.Review{
+		BookID: uint(bookID),
+		UserID: middleware.UserID(c),
+		Rating: req.Rating,
+		Title:  req.Title,
+		Body:   req.Body,
+	}
+
+	if err := h.reviewService.CreateReview(c.Request.Context(), review); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToReviewResponse(review))
+}
+
+// @Summary List a book's reviews
+// @Description Get a paginated list of a book's reviews, newest first. Only approved reviews are listed unless an admin requests another status.
+// @Tags reviews
+// @Produce json
+// @Param X-Admin-Token header string false "Admin token, required for statuses other than approved"
+// @Param id path int true "Book ID"
+// @Param status query string false "Moderation status" Enums(approved, pending, rejected) default(approved)
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} dto.ListReviewsResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/reviews [get]
+func (h *ReviewHandler) ListReviews(c *gin.Context) {
+	bookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	status := models.ReviewStatus(c.Query("status"))
+	switch status {
+	case "", models.ReviewApproved:
+	case models.ReviewPending, models.ReviewRejected:
+		// Reviews awaiting or refused moderation are not public
+		if !middleware.IsAdmin(c) {
+			c.Error(errors.NewLocalizedError(errors.Unauthorized, errors.MsgAdminTokenRequired))
+			return
+		}
+	default:
+		c.Error(errors.NewFieldValidationError("status", "oneof", errors.MsgInvalidReviewStatus))
+		return
+	}
+
+	page, pageSize := pageParams(c)
+
+	reviews, total, err := h.reviewService.ListReviews(c.Request.Context(), uint(bookID), status, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ListReviewsResponse{
+		Reviews:    dto.ToReviewResponseList(reviews),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages(total, pageSize),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Get a review
+// @Description Get a review of a book by its ID. Reviews that are not approved are only shown to their author and admins.
+// @Tags reviews
+// @Produce json
+// @Param X-User-ID header string false "User ID"
+// @Param X-Admin-Token header string false "Admin token"
+// @Param id path int true "Book ID"
+// @Param review_id path int true "Review ID"
+// @Success 200 {object} dto.ReviewResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/reviews/{review_id} [get]
+func (h *ReviewHandler) GetReview(c *gin.Context) {
+	bookID, reviewID, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	review, err := h.reviewService.GetReview(c.Request.Context(), caller(c), bookID, reviewID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReviewResponse(review))
+}
+
+// @Summary Update a review
+// @Description Change a review's rating and text. Only the review's author or an admin may do so. When moderation is enabled the review is hidden until approved again.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string false "User ID, required unless the admin token is given"
+// @Param X-Admin-Token header string false "Admin token"
+// @Param id path int true "Book ID"
+// @Param review_id path int true "Review ID"
+// @Param review body dto.UpdateReviewRequest true "Review details"
+// @Success 200 {object} dto.ReviewResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 403 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/reviews/{review_id} [put]
+func (h *ReviewHandler) UpdateReview(c *gin.Context) {
+	bookID, reviewID, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	review := &models.Review{
+		Rating: req.Rating,
+		Title:  req.Title,
+		Body:   req.Body,
+	}
+
+	if err := h.reviewService.UpdateReview(c.Request.Context(), caller(c), bookID, reviewID, review); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReviewResponse(review))
+}
+
+// @Summary Moderate a review
+// @Description Approve or reject a review. Only approved reviews count towards the book's rating.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Book ID"
+// @Param review_id path int true "Review ID"
+// @Param status body dto.ModerateReviewRequest true "Moderation status"
+// @Success 200 {object} dto.ReviewResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/reviews/{review_id}/status [put]
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	bookID, reviewID, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	review, err := h.reviewService.ModerateReview(c.Request.Context(), bookID, reviewID, models.ReviewStatus(req.Status))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToReviewResponse(review))
+}
+
+// @Summary Delete a review
+// @Description Delete a review of a book by its ID. Only the review's author or an admin may do so.
+// @Tags reviews
+// @Produce json
+// @Param X-User-ID header string false "User ID, required unless the admin token is given"
+// @Param X-Admin-Token header string false "Admin token"
+// @Param id path int true "Book ID"
+// @Param review_id path int true "Review ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 401 {object} errors.Problem
+// @Failure 403 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/reviews/{review_id} [delete]
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	bookID, reviewID, ok := reviewParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(c.Request.Context(), caller(c), bookID, reviewID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// reviewParams reads the book and review IDs from the path, recording an
+// error on the context if either is invalid
+func reviewParams(c *gin.Context) (uint, uint, bool) {
+	bookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return 0, 0, false
+	}
+
+	reviewID, err := strconv.ParseUint(c.Param("review_id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidReviewID))
+		return 0, 0, false
+	}
+
+	return uint(bookID), uint(reviewID), true
+}
+
+// caller identifies the user and admin status recorded by the middleware
+func caller(c *gin.Context) models.Caller {
+	return models.Caller{
+		UserID: middleware.UserID(c),
+		Admin:  middleware.IsAdmin(c),
+	}
+}
@@ -2,32 +2,173 @@ package handlers
 
 import (
 	_ "github.com/AhmadMuj/books-api-go/docs/swagger"
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/dto"
 	"github.com/AhmadMuj/books-api-go/internal/middleware"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRoutes(r *gin.Engine, bookHandler *BookHandler) {
+// Handlers groups the resource handlers mounted by SetupRoutes
+type Handlers struct {
+	Book   *BookHandler
+	Author *AuthorHandler
+	Genre  *GenreHandler
+	Tag    *TagHandler
+	Work   *WorkHandler
+	Series *SeriesHandler
+	Copy   *CopyHandler
+	Loan   *LoanHandler
+	Hold   *HoldHandler
+	Review *ReviewHandler
+
+	ReadingList *ReadingListHandler
+	Cache       *CacheHandler
+	Health      *HealthHandler
+}
+
+func SetupRoutes(r *gin.Engine, h *Handlers, idempotencyStore cache.IdempotencyStore, adminToken string, metricsRegistry prometheus.Registerer) {
 	// Middleware
+	r.Use(middleware.Metrics(metricsRegistry))
 	r.Use(middleware.Logger())
 	r.Use(middleware.Recovery())
 	r.Use(middleware.RequestID())
+	r.Use(middleware.Locale())
+	r.Use(middleware.ErrorHandler())
 	r.Use(middleware.CORS())
 
+	dto.RegisterJSONFieldNames()
+
 	// Swagger
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	idempotency := middleware.Idempotency(idempotencyStore)
+
 	// API v1 group
 	v1 := r.Group("/api/v1")
 	{
 		books := v1.Group("/books")
 		{
-			books.POST("", bookHandler.CreateBook)
-			books.GET("", bookHandler.ListBooks)
-			books.GET("/:id", bookHandler.GetBook)
-			books.PUT("/:id", bookHandler.UpdateBook)
-			books.DELETE("/:id", bookHandler.DeleteBook)
+			books.POST("", idempotency, h.Book.CreateBook)
+			books.GET("", h.Book.ListBooks)
+			books.GET("/:id", h.Book.GetBook)
+			books.GET("/isbn/:isbn", h.Book.GetBookByISBN)
+			books.PUT("/:id", h.Book.UpdateBook)
+			books.DELETE("/:id", h.Book.DeleteBook)
+			books.GET("/:id/series-neighbors", h.Series.GetSeriesNeighbors)
+			books.PUT("/:id/cover", h.Book.SetBookCover)
+			books.GET("/:id/copies", h.Copy.ListCopies)
+			books.POST("/:id/copies", idempotency, h.Copy.CreateCopy)
+			books.GET("/:id/copies/:copy_id", h.Copy.GetCopy)
+			books.PUT("/:id/copies/:copy_id", h.Copy.UpdateCopy)
+			books.DELETE("/:id/copies/:copy_id", h.Copy.DeleteCopy)
+			books.GET("/:id/holds", h.Hold.ListBookHolds)
+			books.POST("/:id/holds", idempotency, h.Hold.PlaceHold)
+			books.GET("/:id/reviews", middleware.IdentifyAdmin(adminToken), h.Review.ListReviews)
+			// RequireUser runs before idempotency so keys are scoped to the
+			// reviewer
+			books.POST("/:id/reviews", middleware.RequireUser(), idempotency, h.Review.CreateReview)
+			books.GET("/:id/reviews/:review_id", middleware.IdentifyUser(), middleware.IdentifyAdmin(adminToken), h.Review.GetReview)
+			books.PUT("/:id/reviews/:review_id", middleware.RequireUserOrAdmin(adminToken), h.Review.UpdateReview)
+			books.DELETE("/:id/reviews/:review_id", middleware.RequireUserOrAdmin(adminToken), h.Review.DeleteReview)
+			books.PUT("/:id/reviews/:review_id/status", middleware.RequireAdmin(adminToken), h.Review.ModerateReview)
+		}
+
+		v1.GET("/stats", h.Book.GetStats)
+		v1.GET("/health", h.Health.Check)
+
+		authors := v1.Group("/authors")
+		{
+			authors.POST("", idempotency, h.Author.CreateAuthor)
+			authors.GET("", h.Author.ListAuthors)
+			authors.GET("/:id", h.Author.GetAuthor)
+			authors.PUT("/:id", h.Author.UpdateAuthor)
+			authors.DELETE("/:id", h.Author.DeleteAuthor)
+			authors.GET("/:id/books", h.Author.ListAuthorBooks)
+		}
+
+		genres := v1.Group("/genres")
+		{
+			genres.POST("", idempotency, h.Genre.CreateGenre)
+			genres.GET("", h.Genre.ListGenres)
+			genres.GET("/:id", h.Genre.GetGenre)
+			genres.PUT("/:id", h.Genre.UpdateGenre)
+			genres.DELETE("/:id", h.Genre.DeleteGenre)
+		}
+
+		tags := v1.Group("/tags")
+		{
+			tags.POST("", idempotency, h.Tag.CreateTag)
+			tags.GET("", h.Tag.ListTags)
+			tags.GET("/:id", h.Tag.GetTag)
+			tags.PUT("/:id", h.Tag.UpdateTag)
+			tags.DELETE("/:id", h.Tag.DeleteTag)
+		}
+
+		works := v1.Group("/works")
+		{
+			works.POST("", idempotency, h.Work.CreateWork)
+			works.GET("", h.Work.ListWorks)
+			works.GET("/:id", h.Work.GetWork)
+			works.PUT("/:id", h.Work.UpdateWork)
+			works.DELETE("/:id", h.Work.DeleteWork)
+			works.GET("/:id/editions", h.Work.ListEditions)
+		}
+
+		series := v1.Group("/series")
+		{
+			series.POST("", idempotency, h.Series.CreateSeries)
+			series.GET("", h.Series.ListSeries)
+			series.GET("/:id", h.Series.GetSeries)
+			series.PUT("/:id", h.Series.UpdateSeries)
+			series.DELETE("/:id", h.Series.DeleteSeries)
+			series.PUT("/:id/books/:book_id", h.Series.SetSeriesBook)
+			series.DELETE("/:id/books/:book_id", h.Series.RemoveSeriesBook)
+		}
+
+		loans := v1.Group("/loans")
+		{
+			loans.POST("", idempotency, h.Loan.CreateLoan)
+			loans.GET("", h.Loan.ListLoans)
+			loans.GET("/:id", h.Loan.GetLoan)
+			loans.POST("/:id/return", idempotency, h.Loan.ReturnLoan)
+			loans.POST("/:id/renew", idempotency, h.Loan.RenewLoan)
+		}
+
+		holds := v1.Group("/holds")
+		{
+			holds.GET("/:id", h.Hold.GetHold)
+			holds.DELETE("/:id", h.Hold.CancelHold)
+		}
+
+		me := v1.Group("/me", middleware.RequireUser())
+		{
+			me.GET("/lists", h.ReadingList.ListMyLists)
+			me.POST("/lists", idempotency, h.ReadingList.CreateList)
+			me.GET("/lists/:id", h.ReadingList.GetMyList)
+			me.PUT("/lists/:id", h.ReadingList.UpdateList)
+			me.DELETE("/lists/:id", h.ReadingList.DeleteList)
+			me.POST("/lists/:id/share", h.ReadingList.ShareList)
+			me.DELETE("/lists/:id/share", h.ReadingList.UnshareList)
+			me.POST("/lists/:id/entries", idempotency, h.ReadingList.AddEntry)
+			me.PUT("/lists/:id/entries/:entry_id", h.ReadingList.UpdateEntry)
+			me.DELETE("/lists/:id/entries/:entry_id", h.ReadingList.RemoveEntry)
+		}
+
+		lists := v1.Group("/lists")
+		{
+			lists.GET("/:id", h.ReadingList.GetPublicList)
+			lists.GET("/shared/:token", h.ReadingList.GetSharedList)
+		}
+
+		admin := v1.Group("/admin", middleware.RequireAdmin(adminToken))
+		{
+			admin.GET("/cache/stats", h.Cache.GetStats)
+			admin.GET("/cache/books/:id", h.Cache.InspectBook)
+			admin.DELETE("/cache/keys/:key", h.Cache.EvictKey)
+			admin.POST("/cache/warm", h.Cache.WarmCache)
 		}
 	}
 }
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type SeriesHandler struct {
+	seriesService service.SeriesService
+}
+
+func NewSeriesHandler(seriesService service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+// @Summary Create a new series
+// @Description Create a new book series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param series body dto.CreateSeriesRequest true "Series details"
+// @Success 201 {object} dto.SeriesResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /series [post]
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var req dto.CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	series := &models.Series{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := h.seriesService.CreateSeries(c.Request.Context(), series); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToSeriesResponse(series))
+}
+
+// @Summary Get a series by ID
+// @Description Get a series with its books in reading order
+// @Tags series
+// @Produce json
+// @Param id path int true "Series ID"
+// @Success 200 {object} dto.SeriesDetailResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /series/{id} [get]
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidSeriesID))
+		return
+	}
+
+	series, err := h.seriesService.GetSeries(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSeriesDetailResponse(series))
+}
+
+// @Summary List all series
+// @Description Get a paginated list of series ordered by name
+// @Tags series
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} dto.ListSeriesResponse
+// @Failure 500 {object} errors.Problem
+// @Router /series [get]
+func (h *SeriesHandler) ListSeries(c *gin.Context) {
+	page, pageSize := pageParams(c)
+
+	series, total, err := h.seriesService.ListSeries(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ListSeriesResponse{
+		Series:     dto.ToSeriesResponseList(series),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages(total, pageSize),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Update a series
+// @Description Update a series' name and description by its ID
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param series body dto.UpdateSeriesRequest true "Series details"
+// @Success 200 {object} dto.SeriesResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /series/{id} [put]
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidSeriesID))
+		return
+	}
+
+	var req dto.UpdateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	series := &models.Series{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := h.seriesService.UpdateSeries(c.Request.Context(), uint(id), series); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSeriesResponse(series))
+}
+
+// @Summary Delete a series
+// @Description Delete a series by its ID. Its books are kept.
+// @Tags series
+// @Produce json
+// @Param id path int true "Series ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /series/{id} [delete]
+func (h *SeriesHandler) DeleteSeries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidSeriesID))
+		return
+	}
+
+	if err := h.seriesService.DeleteSeries(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Add or move a book in a series
+// @Description Place a book at a position in a series, moving it if it is already a member
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param book_id path int true "Book ID"
+// @Param entry body dto.SetSeriesBookRequest true "Position in the series"
+// @Success 200 {object} dto.SeriesDetailResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /series/{id}/books/{book_id} [put]
+func (h *SeriesHandler) SetSeriesBook(c *gin.Context) {
+	seriesID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidSeriesID))
+		return
+	}
+	bookID, err := strconv.ParseUint(c.Param("book_id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	var req dto.SetSeriesBookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	entry := &models.SeriesEntry{
+		SeriesID: uint(seriesID),
+		BookID:   uint(bookID),
+		Position: req.Position,
+	}
+
+	if err := h.seriesService.SetSeriesBook(c.Request.Context(), entry); err != nil {
+		c.Error(err)
+		return
+	}
+
+	series, err := h.seriesService.GetSeries(c.Request.Context(), uint(seriesID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSeriesDetailResponse(series))
+}
+
+// @Summary Remove a book from a series
+// @Description Remove a book from a series. The book itself is kept.
+// @Tags series
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param book_id path int true "Book ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /series/{id}/books/{book_id} [delete]
+func (h *SeriesHandler) RemoveSeriesBook(c *gin.Context) {
+	seriesID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidSeriesID))
+		return
+	}
+	bookID, err := strconv.ParseUint(c.Param("book_id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	if err := h.seriesService.RemoveSeriesBook(c.Request.Context(), uint(seriesID), uint(bookID)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get a book's series neighbors
+// @Description Get the previous and next book in every series the book belongs to
+// @Tags books
+// @Produce json
+// @Param id path int true "Book ID"
+// @Success 200 {object} dto.ListSeriesNeighborsResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /books/{id}/series-neighbors [get]
+func (h *SeriesHandler) GetSeriesNeighbors(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID))
+		return
+	}
+
+	neighbors, err := h.seriesService.GetSeriesNeighbors(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ListSeriesNeighborsResponse{
+		BookID: uint(id),
+		Series: dto.ToSeriesNeighborsResponseList(neighbors),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagService service.TagService
+}
+
+func NewTagHandler(tagService service.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// @Summary Create a new tag
+// @Description Create a new tag. Tags are also created on first use when a book is saved.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag body dto.CreateTagRequest true "Tag details"
+// @Success 201 {object} dto.TagResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req dto.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	tag := &models.Tag{
+		Name: req.Name,
+	}
+
+	if err := h.tagService.CreateTag(c.Request.Context(), tag); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToTagResponse(tag))
+}
+
+// @Summary Get a tag by ID
+// @Description Get a tag's details by its ID
+// @Tags tags
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /tags/{id} [get]
+func (h *TagHandler) GetTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidTagID))
+		return
+	}
+
+	tag, err := h.tagService.GetTag(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTagResponse(tag))
+}
+
+// @Summary List all tags
+// @Description Get a paginated list of tags ordered by name
+// @Tags tags
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} dto.ListTagsResponse
+// @Failure 500 {object} errors.Problem
+// @Router /tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	page, pageSize := pageParams(c)
+
+	tags, total, err := h.tagService.ListTags(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ListTagsResponse{
+		Tags:       dto.ToTagResponseList(tags),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages(total, pageSize),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Update a tag
+// @Description Rename a tag by its ID
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param tag body dto.UpdateTagRequest true "Tag details"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /tags/{id} [put]
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidTagID))
+		return
+	}
+
+	var req dto.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	tag := &models.Tag{
+		Name: req.Name,
+	}
+
+	if err := h.tagService.UpdateTag(c.Request.Context(), uint(id), tag); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTagResponse(tag))
+}
+
+// @Summary Delete a tag
+// @Description Delete a tag by its ID and remove it from its books
+// @Tags tags
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidTagID))
+		return
+	}
+
+	if err := h.tagService.DeleteTag(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
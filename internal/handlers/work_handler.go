@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AhmadMuj/books-api-go/internal/dto"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type WorkHandler struct {
+	workService service.WorkService
+}
+
+func NewWorkHandler(workService service.WorkService) *WorkHandler {
+	return &WorkHandler{
+		workService: workService,
+	}
+}
+
+// @Summary Create a new work
+// @Description Create a work that editions can be attached to with work_id
+// @Tags works
+// @Accept json
+// @Produce json
+// @Param work body dto.CreateWorkRequest true "Work details"
+// @Success 201 {object} dto.WorkResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /works [post]
+func (h *WorkHandler) CreateWork(c *gin.Context) {
+	var req dto.CreateWorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	work := &models.Work{
+		Title:            req.Title,
+		OriginalLanguage: dto.CanonicalLanguage(req.OriginalLanguage),
+	}
+
+	if err := h.workService.CreateWork(c.Request.Context(), work); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToWorkResponse(work))
+}
+
+// @Summary Get a work by ID
+// @Description Get a work's details by its ID
+// @Tags works
+// @Produce json
+// @Param id path int true "Work ID"
+// @Success 200 {object} dto.WorkResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /works/{id} [get]
+func (h *WorkHandler) GetWork(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidWorkID))
+		return
+	}
+
+	work, err := h.workService.GetWork(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToWorkResponse(work))
+}
+
+// @Summary List all works
+// @Description Get a paginated list of works ordered by title
+// @Tags works
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} dto.ListWorksResponse
+// @Failure 500 {object} errors.Problem
+// @Router /works [get]
+func (h *WorkHandler) ListWorks(c *gin.Context) {
+	page, pageSize := pageParams(c)
+
+	works, total, err := h.workService.ListWorks(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ListWorksResponse{
+		Works:      dto.ToWorkResponseList(works),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages(total, pageSize),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Update a work
+// @Description Update a work's title and original language by its ID
+// @Tags works
+// @Accept json
+// @Produce json
+// @Param id path int true "Work ID"
+// @Param work body dto.UpdateWorkRequest true "Work details"
+// @Success 200 {object} dto.WorkResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /works/{id} [put]
+func (h *WorkHandler) UpdateWork(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidWorkID))
+		return
+	}
+
+	var req dto.UpdateWorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.NewBindingError(err))
+		return
+	}
+
+	work := &models.Work{
+		Title:            req.Title,
+		OriginalLanguage: dto.CanonicalLanguage(req.OriginalLanguage),
+	}
+
+	if err := h.workService.UpdateWork(c.Request.Context(), uint(id), work); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToWorkResponse(work))
+}
+
+// @Summary Delete a work
+// @Description Delete a work by its ID. Works that still have editions cannot be deleted.
+// @Tags works
+// @Produce json
+// @Param id path int true "Work ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 409 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /works/{id} [delete]
+func (h *WorkHandler) DeleteWork(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidWorkID))
+		return
+	}
+
+	if err := h.workService.DeleteWork(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List a work's editions
+// @Description Get every edition of a work, including translations, oldest publication first
+// @Tags works
+// @Produce json
+// @Param id path int true "Work ID"
+// @Success 200 {object} dto.ListEditionsResponse
+// @Failure 400 {object} errors.Problem
+// @Failure 404 {object} errors.Problem
+// @Failure 500 {object} errors.Problem
+// @Router /works/{id}/editions [get]
+func (h *WorkHandler) ListEditions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidWorkID))
+		return
+	}
+
+	work, err := h.workService.GetWork(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	editions, err := h.workService.ListEditions(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ListEditionsResponse{
+		Work:     *dto.ToWorkResponse(work),
+		Editions: dto.ToBookResponseList(editions),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
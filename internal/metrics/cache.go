@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheOperationDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "cache", "operation_duration_seconds"),
+		"Duration of cache operations.",
+		[]string{"driver", "operation"}, nil,
+	)
+	cacheHits = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "cache", "hits_total"),
+		"Lookups served from the cache.",
+		[]string{"driver", "operation"}, nil,
+	)
+	cacheMisses = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "cache", "misses_total"),
+		"Lookups not found in the cache.",
+		[]string{"driver", "operation"}, nil,
+	)
+	cacheSkipped = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "cache", "skipped_total"),
+		"Lookups not sent to the cache because its circuit breaker was open.",
+		[]string{"driver", "operation"}, nil,
+	)
+	cacheErrors = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "cache", "errors_total"),
+		"Cache operations that failed.",
+		[]string{"driver", "operation"}, nil,
+	)
+
+	redisPoolHits = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "redis", "pool_hits_total"),
+		"Times a free connection was found in the Redis pool.",
+		nil, nil,
+	)
+	redisPoolMisses = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "redis", "pool_misses_total"),
+		"Times no free connection was found in the Redis pool.",
+		nil, nil,
+	)
+	redisPoolTimeouts = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "redis", "pool_timeouts_total"),
+		"Times waiting for a Redis connection timed out.",
+		nil, nil,
+	)
+	redisPoolStaleConnections = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "redis", "pool_stale_connections_total"),
+		"Stale connections removed from the Redis pool.",
+		nil, nil,
+	)
+	redisPoolConnections = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "redis", "pool_connections"),
+		"Connections in the Redis pool.",
+		nil, nil,
+	)
+	redisPoolIdleConnections = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "redis", "pool_idle_connections"),
+		"Idle connections in the Redis pool.",
+		nil, nil,
+	)
+)
+
+// CacheCollector exports the operation metrics kept by the instrumented
+// cache, and the connection pool statistics of Redis when the cache uses
+// it. The histogram buckets are cache.LatencyBuckets.
+type CacheCollector struct {
+	cache *cache.InstrumentedCache
+}
+
+func NewCacheCollector(c *cache.InstrumentedCache) *CacheCollector {
+	return &CacheCollector{cache: c}
+}
+
+func (c *CacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheOperationDuration
+	ch <- cacheHits
+	ch <- cacheMisses
+	ch <- cacheSkipped
+	ch <- cacheErrors
+	ch <- redisPoolHits
+	ch <- redisPoolMisses
+	ch <- redisPoolTimeouts
+	ch <- redisPoolStaleConnections
+	ch <- redisPoolConnections
+	ch <- redisPoolIdleConnections
+}
+
+func (c *CacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.cache.Stats()
+	for _, op := range stats.Operations {
+		buckets := make(map[float64]uint64, len(cache.LatencyBuckets))
+		for i, bound := range cache.LatencyBuckets {
+			buckets[bound.Seconds()] = op.Latency[i]
+		}
+		ch <- prometheus.MustNewConstHistogram(cacheOperationDuration, op.Calls, op.TotalLatency.Seconds(), buckets, stats.Driver, op.Name)
+		ch <- prometheus.MustNewConstMetric(cacheErrors, prometheus.CounterValue, float64(op.Errors), stats.Driver, op.Name)
+		if op.Hits+op.Misses+op.Skipped > 0 {
+			ch <- prometheus.MustNewConstMetric(cacheHits, prometheus.CounterValue, float64(op.Hits), stats.Driver, op.Name)
+			ch <- prometheus.MustNewConstMetric(cacheMisses, prometheus.CounterValue, float64(op.Misses), stats.Driver, op.Name)
+			ch <- prometheus.MustNewConstMetric(cacheSkipped, prometheus.CounterValue, float64(op.Skipped), stats.Driver, op.Name)
+		}
+	}
+
+	pool := cache.PoolStats(c.cache)
+	if pool == nil {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(redisPoolHits, prometheus.CounterValue, float64(pool.Hits))
+	ch <- prometheus.MustNewConstMetric(redisPoolMisses, prometheus.CounterValue, float64(pool.Misses))
+	ch <- prometheus.MustNewConstMetric(redisPoolTimeouts, prometheus.CounterValue, float64(pool.Timeouts))
+	ch <- prometheus.MustNewConstMetric(redisPoolStaleConnections, prometheus.CounterValue, float64(pool.StaleConns))
+	ch <- prometheus.MustNewConstMetric(redisPoolConnections, prometheus.GaugeValue, float64(pool.TotalConns))
+	ch <- prometheus.MustNewConstMetric(redisPoolIdleConnections, prometheus.GaugeValue, float64(pool.IdleConns))
+}
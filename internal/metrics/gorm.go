@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const startTimeKey = "metrics:start_time"
+
+// GormPlugin records the duration of every query GORM runs by operation,
+// table and outcome, along with the statistics of its connection pool
+type GormPlugin struct {
+	registry prometheus.Registerer
+	duration *prometheus.HistogramVec
+}
+
+func NewGormPlugin(registry prometheus.Registerer) *GormPlugin {
+	return &GormPlugin{
+		registry: registry,
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of database queries by operation, table and status.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"operation", "table", "status"}),
+	}
+}
+
+func (p *GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if err := p.registry.Register(p.duration); err != nil {
+		return err
+	}
+	if err := p.registry.Register(collectors.NewDBStatsCollector(sqlDB, "postgres")); err != nil {
+		return err
+	}
+
+	callbacks := db.Callback()
+	for _, c := range []struct {
+		operation     string
+		before, after func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", callbacks.Create().Before("gorm:create").Register, callbacks.Create().After("gorm:create").Register},
+		{"query", callbacks.Query().Before("gorm:query").Register, callbacks.Query().After("gorm:query").Register},
+		{"update", callbacks.Update().Before("gorm:update").Register, callbacks.Update().After("gorm:update").Register},
+		{"delete", callbacks.Delete().Before("gorm:delete").Register, callbacks.Delete().After("gorm:delete").Register},
+		{"row", callbacks.Row().Before("gorm:row").Register, callbacks.Row().After("gorm:row").Register},
+		{"raw", callbacks.Raw().Before("gorm:raw").Register, callbacks.Raw().After("gorm:raw").Register},
+	} {
+		if err := c.before("metrics:before_"+c.operation, p.before); err != nil {
+			return err
+		}
+		if err := c.after("metrics:after_"+c.operation, p.after(c.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+func (p *GormPlugin) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startTimeKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		status := "ok"
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			status = "error"
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		p.duration.WithLabelValues(operation, table, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/kafka-go"
+)
+
+func kafkaDesc(subsystem, name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(Namespace, subsystem, name), help, labels, nil)
+}
+
+var (
+	kafkaWriterWrites       = kafkaDesc("kafka_writer", "writes_total", "Batches written to Kafka.", "topic")
+	kafkaWriterMessages     = kafkaDesc("kafka_writer", "messages_total", "Messages written to Kafka.", "topic")
+	kafkaWriterBytes        = kafkaDesc("kafka_writer", "bytes_total", "Bytes of messages written to Kafka.", "topic")
+	kafkaWriterErrors       = kafkaDesc("kafka_writer", "errors_total", "Failed writes to Kafka.", "topic")
+	kafkaWriterRetries      = kafkaDesc("kafka_writer", "retries_total", "Retried writes to Kafka.", "topic")
+	kafkaWriterWriteSeconds = kafkaDesc("kafka_writer", "write_seconds", "Time spent writing batches to Kafka.", "topic")
+	kafkaWriterWaitSeconds  = kafkaDesc("kafka_writer", "wait_seconds", "Time spent waiting for Kafka to acknowledge writes.", "topic")
+	kafkaWriterBatchSize    = kafkaDesc("kafka_writer", "batch_messages", "Messages per batch written to Kafka.", "topic")
+
+	kafkaReaderFetches     = kafkaDesc("kafka_reader", "fetches_total", "Fetch requests sent to Kafka.", "topic")
+	kafkaReaderMessages    = kafkaDesc("kafka_reader", "messages_total", "Messages read from Kafka.", "topic")
+	kafkaReaderBytes       = kafkaDesc("kafka_reader", "bytes_total", "Bytes of messages read from Kafka.", "topic")
+	kafkaReaderErrors      = kafkaDesc("kafka_reader", "errors_total", "Failed reads from Kafka.", "topic")
+	kafkaReaderTimeouts    = kafkaDesc("kafka_reader", "timeouts_total", "Reads from Kafka that timed out.", "topic")
+	kafkaReaderRebalances  = kafkaDesc("kafka_reader", "rebalances_total", "Consumer group rebalances.", "topic")
+	kafkaReaderReadSeconds = kafkaDesc("kafka_reader", "read_seconds", "Time spent reading fetched messages.", "topic")
+	kafkaReaderWaitSeconds = kafkaDesc("kafka_reader", "wait_seconds", "Time spent waiting for Kafka to answer fetches.", "topic")
+	kafkaReaderOffset      = kafkaDesc("kafka_reader", "offset", "Offset of the last message read.", "topic")
+	kafkaReaderLag         = kafkaDesc("kafka_reader", "lag", "Messages the consumer is behind the end of the partition.", "topic")
+	kafkaReaderQueueLength = kafkaDesc("kafka_reader", "queue_length", "Fetched messages waiting to be read.", "topic")
+)
+
+// summary accumulates a kafka-go duration or size summary, whose count and
+// sum cover the time since it was last taken
+type summary struct {
+	count uint64
+	sum   float64
+}
+
+func (s *summary) add(count int64, sum float64) {
+	s.count += uint64(count)
+	s.sum += sum
+}
+
+// KafkaWriterCollector exports the statistics of the event producer's
+// writer. kafka-go resets its counters whenever they are read, so they are
+// accumulated here and only one collector may read a writer's statistics.
+type KafkaWriterCollector struct {
+	stats func() kafka.WriterStats
+
+	mu                                     sync.Mutex
+	writes, messages, bytes, errs, retries uint64
+	writeTime, waitTime, batchSize         summary
+}
+
+func NewKafkaWriterCollector(stats func() kafka.WriterStats) *KafkaWriterCollector {
+	return &KafkaWriterCollector{stats: stats}
+}
+
+func (c *KafkaWriterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- kafkaWriterWrites
+	ch <- kafkaWriterMessages
+	ch <- kafkaWriterBytes
+	ch <- kafkaWriterErrors
+	ch <- kafkaWriterRetries
+	ch <- kafkaWriterWriteSeconds
+	ch <- kafkaWriterWaitSeconds
+	ch <- kafkaWriterBatchSize
+}
+
+func (c *KafkaWriterCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats()
+	c.writes += uint64(stats.Writes)
+	c.messages += uint64(stats.Messages)
+	c.bytes += uint64(stats.Bytes)
+	c.errs += uint64(stats.Errors)
+	c.retries += uint64(stats.Retries)
+	c.writeTime.add(stats.WriteTime.Count, stats.WriteTime.Sum.Seconds())
+	c.waitTime.add(stats.WaitTime.Count, stats.WaitTime.Sum.Seconds())
+	c.batchSize.add(stats.BatchSize.Count, float64(stats.BatchSize.Sum))
+
+	topic := stats.Topic
+	ch <- prometheus.MustNewConstMetric(kafkaWriterWrites, prometheus.CounterValue, float64(c.writes), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaWriterMessages, prometheus.CounterValue, float64(c.messages), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaWriterBytes, prometheus.CounterValue, float64(c.bytes), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaWriterErrors, prometheus.CounterValue, float64(c.errs), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaWriterRetries, prometheus.CounterValue, float64(c.retries), topic)
+	ch <- prometheus.MustNewConstSummary(kafkaWriterWriteSeconds, c.writeTime.count, c.writeTime.sum, nil, topic)
+	ch <- prometheus.MustNewConstSummary(kafkaWriterWaitSeconds, c.waitTime.count, c.waitTime.sum, nil, topic)
+	ch <- prometheus.MustNewConstSummary(kafkaWriterBatchSize, c.batchSize.count, c.batchSize.sum, nil, topic)
+}
+
+// KafkaReaderCollector exports the statistics of the event consumer's
+// reader, including its lag. Like KafkaWriterCollector it accumulates the
+// counters kafka-go resets on every read. The consumer reads as part of a
+// group, so partitions are not told apart.
+type KafkaReaderCollector struct {
+	stats func() kafka.ReaderStats
+
+	mu                                                   sync.Mutex
+	fetches, messages, bytes, errs, timeouts, rebalances uint64
+	readTime, waitTime                                   summary
+}
+
+func NewKafkaReaderCollector(stats func() kafka.ReaderStats) *KafkaReaderCollector {
+	return &KafkaReaderCollector{stats: stats}
+}
+
+func (c *KafkaReaderCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- kafkaReaderFetches
+	ch <- kafkaReaderMessages
+	ch <- kafkaReaderBytes
+	ch <- kafkaReaderErrors
+	ch <- kafkaReaderTimeouts
+	ch <- kafkaReaderRebalances
+	ch <- kafkaReaderReadSeconds
+	ch <- kafkaReaderWaitSeconds
+	ch <- kafkaReaderOffset
+	ch <- kafkaReaderLag
+	ch <- kafkaReaderQueueLength
+}
+
+func (c *KafkaReaderCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats()
+	c.fetches += uint64(stats.Fetches)
+	c.messages += uint64(stats.Messages)
+	c.bytes += uint64(stats.Bytes)
+	c.errs += uint64(stats.Errors)
+	c.timeouts += uint64(stats.Timeouts)
+	c.rebalances += uint64(stats.Rebalances)
+	c.readTime.add(stats.ReadTime.Count, stats.ReadTime.Sum.Seconds())
+	c.waitTime.add(stats.WaitTime.Count, stats.WaitTime.Sum.Seconds())
+
+	topic := stats.Topic
+	ch <- prometheus.MustNewConstMetric(kafkaReaderFetches, prometheus.CounterValue, float64(c.fetches), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaReaderMessages, prometheus.CounterValue, float64(c.messages), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaReaderBytes, prometheus.CounterValue, float64(c.bytes), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaReaderErrors, prometheus.CounterValue, float64(c.errs), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaReaderTimeouts, prometheus.CounterValue, float64(c.timeouts), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaReaderRebalances, prometheus.CounterValue, float64(c.rebalances), topic)
+	ch <- prometheus.MustNewConstSummary(kafkaReaderReadSeconds, c.readTime.count, c.readTime.sum, nil, topic)
+	ch <- prometheus.MustNewConstSummary(kafkaReaderWaitSeconds, c.waitTime.count, c.waitTime.sum, nil, topic)
+	ch <- prometheus.MustNewConstMetric(kafkaReaderOffset, prometheus.GaugeValue, float64(stats.Offset), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaReaderLag, prometheus.GaugeValue, float64(stats.Lag), topic)
+	ch <- prometheus.MustNewConstMetric(kafkaReaderQueueLength, prometheus.GaugeValue, float64(stats.QueueLength), topic)
+}
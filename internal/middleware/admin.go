@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// AdminTokenHeader carries the shared secret that unlocks the admin
+	// endpoints
+	AdminTokenHeader = "X-Admin-Token"
+	AdminKey         = "Admin"
+)
+
+// RequireAdmin rejects requests that do not present token. An empty token
+// rejects every request, which keeps the admin endpoints closed until one
+// is configured.
+func RequireAdmin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasAdminToken(c, token) {
+			abortWithError(c, errors.NewLocalizedError(errors.Unauthorized, errors.MsgAdminTokenRequired))
+			return
+		}
+		c.Set(AdminKey, true)
+		c.Next()
+	}
+}
+
+// IdentifyAdmin records whether the request presents token without
+// rejecting it, for endpoints that show admins more. Handlers check
+// IsAdmin.
+func IdentifyAdmin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(AdminKey, hasAdminToken(c, token))
+		c.Next()
+	}
+}
+
+// IsAdmin reports whether the request presented the admin token, as
+// recorded by RequireAdmin, IdentifyAdmin or RequireUserOrAdmin
+func IsAdmin(c *gin.Context) bool {
+	return c.GetBool(AdminKey)
+}
+
+func hasAdminToken(c *gin.Context, token string) bool {
+	given := c.GetHeader(AdminTokenHeader)
+	return token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
@@ -9,8 +9,8 @@ func CORS() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", IdempotencyKeyHeader},
+		ExposeHeaders:    []string{"Content-Length", "Content-Language", IdempotencyReplayedHeader},
 		AllowCredentials: true,
 	})
 }
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler renders the last error attached to the context with c.Error
+// as an application/problem+json response.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		renderErrors(c)
+	}
+}
+
+// renderErrors writes the problem response for pending errors. Middleware
+// that inspects the response after c.Next calls it to see the final body.
+func renderErrors(c *gin.Context) {
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	renderProblem(c, c.Errors.Last().Err)
+}
+
+func abortWithError(c *gin.Context, err error) {
+	c.Error(err)
+	c.Abort()
+}
+
+func renderProblem(c *gin.Context, err error) {
+	problem := errors.NewProblem(err, c.GetString(RequestIDKey), translator(c))
+
+	c.Header("Content-Type", errors.ProblemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the stored response for requests retried with the same
+// Idempotency-Key. Requests without the header pass through untouched, and
+// store failures fall back to executing the request normally. Behind
+// RequireUser keys are scoped to the user, so one user can never be served
+// another's response.
+func Idempotency(store cache.IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			abortWithError(c, errors.NewLocalizedError(errors.ValidationErr, errors.MsgIdempotencyKeyTooLong))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortWithError(c, errors.NewLocalizedError(errors.ValidationErr, errors.MsgBodyUnreadable))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID := UserID(c)
+		if userID != "" {
+			key = userID + ":" + key
+		}
+
+		fingerprint := requestFingerprint(userID, c.Request.Method, c.Request.URL.Path, body)
+		ctx := c.Request.Context()
+
+		record, err := store.Get(ctx, key)
+		if err != nil {
+			log.Printf("Failed to read idempotency record: %v\n", err)
+			c.Next()
+			return
+		}
+		if record != nil {
+			replay(c, record, fingerprint)
+			return
+		}
+
+		token, err := store.Lock(ctx, key)
+		if err != nil {
+			log.Printf("Failed to lock idempotency key: %v\n", err)
+			c.Next()
+			return
+		}
+		if token == "" {
+			abortWithError(c, errors.NewLocalizedError(errors.Conflict, errors.MsgIdempotencyInProgress))
+			return
+		}
+		// Once the handler has run, its response is recorded and the key
+		// released even if the client has gone away
+		storeCtx := context.WithoutCancel(ctx)
+		defer func() {
+			if err := store.Unlock(storeCtx, key, token); err != nil {
+				log.Printf("Failed to unlock idempotency key: %v\n", err)
+			}
+		}()
+
+		// The previous holder may have finished between Get and Lock
+		if record, err := store.Get(ctx, key); err == nil && record != nil {
+			replay(c, record, fingerprint)
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = recorder
+
+		c.Next()
+		renderErrors(c)
+
+		// Server errors are not stored so the client can retry them
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		record = &cache.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			StatusCode:  c.Writer.Status(),
+			ContentType: c.Writer.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}
+		if err := store.Save(storeCtx, key, record); err != nil {
+			log.Printf("Failed to save idempotency record: %v\n", err)
+		}
+	}
+}
+
+func replay(c *gin.Context, record *cache.IdempotencyRecord, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		abortWithError(c, errors.NewLocalizedError(errors.Unprocessable, errors.MsgIdempotencyKeyReused))
+		return
+	}
+
+	c.Header(IdempotencyReplayedHeader, "true")
+	c.Data(record.StatusCode, record.ContentType, record.Body)
+	c.Abort()
+}
+
+func requestFingerprint(userID, method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(userID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+)
+
+const LocaleKey = "Locale"
+
+// Locale selects the response language from Accept-Language and reports it
+// back in Content-Language.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		trans := errors.FindTranslator(c.GetHeader("Accept-Language"))
+
+		c.Set(LocaleKey, trans)
+		c.Header("Content-Language", trans.Locale())
+
+		c.Next()
+	}
+}
+
+func translator(c *gin.Context) ut.Translator {
+	if trans, ok := c.Get(LocaleKey); ok {
+		return trans.(ut.Translator)
+	}
+	return errors.DefaultTranslator()
+}
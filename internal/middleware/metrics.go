@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute labels requests that matched no route, so unknown paths
+// do not each create a series
+const unmatchedRoute = "unmatched"
+
+// Metrics records the duration and the request and response sizes of every
+// request, labelled by method, route template and status
+func Metrics(registry prometheus.Registerer) gin.HandlerFunc {
+	labels := []string{"method", "route", "status"}
+	sizeBuckets := prometheus.ExponentialBuckets(100, 10, 7)
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, labels)
+	requestSize := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "http",
+		Name:      "request_size_bytes",
+		Help:      "Size of HTTP request bodies.",
+		Buckets:   sizeBuckets,
+	}, labels)
+	responseSize := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "http",
+		Name:      "response_size_bytes",
+		Help:      "Size of HTTP response bodies.",
+		Buckets:   sizeBuckets,
+	}, labels)
+	registry.MustRegister(duration, requestSize, responseSize)
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		values := []string{c.Request.Method, route, strconv.Itoa(c.Writer.Status())}
+
+		duration.WithLabelValues(values...).Observe(time.Since(start).Seconds())
+		requestSize.WithLabelValues(values...).Observe(float64(max(c.Request.ContentLength, 0)))
+		responseSize.WithLabelValues(values...).Observe(float64(max(c.Writer.Size(), 0)))
+	}
+}
@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"fmt"
-	"net/http"
 
 	"github.com/AhmadMuj/books-api-go/internal/errors"
 	"github.com/gin-gonic/gin"
@@ -15,7 +14,7 @@ func Recovery() gin.HandlerFunc {
 			err = fmt.Errorf("%v", recovered)
 		}
 
-		appErr := errors.NewInternalError(err)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, appErr)
+		c.Error(err)
+		renderProblem(c, errors.NewInternalError(err))
 	})
 }
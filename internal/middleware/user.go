@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// UserIDHeader carries the caller's user ID. The API does not
+	// authenticate users itself; it expects a gateway in front of it to
+	// verify the caller and set this header.
+	UserIDHeader = "X-User-ID"
+	UserIDKey    = "UserID"
+
+	maxUserIDLength = 64
+)
+
+// RequireUser rejects requests without a user ID and makes it available to
+// handlers through UserID.
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !setUserID(c) {
+			abortWithError(c, errors.NewLocalizedError(errors.Unauthorized, errors.MsgUserHeaderRequired))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireUserOrAdmin accepts requests with a user ID, like RequireUser, and
+// requests presenting the admin token, which may act for any user
+func RequireUserOrAdmin(adminToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		admin := hasAdminToken(c, adminToken)
+		c.Set(AdminKey, admin)
+		if !setUserID(c) && !admin {
+			abortWithError(c, errors.NewLocalizedError(errors.Unauthorized, errors.MsgUserHeaderRequired))
+			return
+		}
+		c.Next()
+	}
+}
+
+// IdentifyUser records the user ID when a valid one is given, without
+// rejecting requests that lack it, for endpoints that show users more of
+// their own data
+func IdentifyUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setUserID(c)
+		c.Next()
+	}
+}
+
+// setUserID records the user ID from the header, reporting whether a valid
+// one was given
+func setUserID(c *gin.Context) bool {
+	userID := strings.TrimSpace(c.GetHeader(UserIDHeader))
+	if userID == "" || len(userID) > maxUserIDLength {
+		return false
+	}
+	c.Set(UserIDKey, userID)
+	return true
+}
+
+// UserID returns the user ID set by RequireUser
+func UserID(c *gin.Context) string {
+	return c.GetString(UserIDKey)
+}
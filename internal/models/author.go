@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+type AuthorRole string
+
+const (
+	AuthorRoleAuthor     AuthorRole = "author"
+	AuthorRoleEditor     AuthorRole = "editor"
+	AuthorRoleTranslator AuthorRole = "translator"
+)
+
+type Author struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	Name           string    `json:"name" gorm:"not null"`
+	NormalizedName string    `json:"-" gorm:"not null;uniqueIndex"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BookAuthor links a book to one of its contributors. Position orders the
+// contributors as they are credited on the book.
+type BookAuthor struct {
+	BookID   uint       `json:"book_id" gorm:"primaryKey"`
+	AuthorID uint       `json:"author_id" gorm:"primaryKey"`
+	Role     AuthorRole `json:"role" gorm:"primaryKey;type:varchar(20)"`
+	Position int        `json:"position" gorm:"not null;default:0"`
+	Author   *Author    `json:"author,omitempty" gorm:"constraint:OnDelete:RESTRICT"`
+}
+
+func (BookAuthor) TableName() string {
+	return "book_authors"
+}
+
+// NormalizeAuthorName reduces a name to the key used to detect duplicates,
+// so "J.R.R. Tolkien" and "J. R. R. Tolkien" resolve to the same author.
+func NormalizeAuthorName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// SplitAuthorNames splits a free-text author credit such as
+// "Brian Kernighan & Dennis Ritchie" into individual names. Commas are left
+// alone because they also appear in "Surname, Given name" credits.
+func SplitAuthorNames(credit string) []string {
+	parts := strings.FieldsFunc(credit, func(r rune) bool {
+		return r == ';' || r == '&'
+	})
+
+	names := make([]string, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		name := strings.TrimSpace(part)
+		key := NormalizeAuthorName(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names
+}
@@ -2,11 +2,43 @@ package models
 
 import "time"
 
+// Book is one edition of a Work: a specific format, language and printing
+// with its own ISBN.
 type Book struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Title     string    `json:"title" binding:"required" gorm:"not null"`
-	Author    string    `json:"author" binding:"required" gorm:"not null"`
-	Year      int       `json:"year" binding:"required" gorm:"not null"`
+	ID          uint          `json:"id" gorm:"primaryKey"`
+	WorkID      *uint         `json:"work_id,omitempty" gorm:"index"`
+	Title       string        `json:"title" binding:"required" gorm:"not null"`
+	Author      string        `json:"author" binding:"required" gorm:"not null"`
+	Year        int           `json:"year" binding:"required" gorm:"not null"`
+	ISBN13      *string       `json:"isbn13,omitempty" gorm:"column:isbn13;size:13;uniqueIndex"`
+	ISBN10      *string       `json:"isbn10,omitempty" gorm:"column:isbn10;size:10;uniqueIndex"`
+	PublisherID *uint         `json:"publisher_id,omitempty" gorm:"index"`
+	Format      EditionFormat `json:"format,omitempty" gorm:"size:20;not null;default:''"`
+	Language    string        `json:"language,omitempty" gorm:"size:35;not null;default:''"`
+	PageCount   *int          `json:"page_count,omitempty"`
+	PublishedOn *time.Time    `json:"published_on,omitempty" gorm:"type:date"`
+	CoverKey    *string       `json:"cover_key,omitempty" gorm:"size:255"`
+
+	// Rating aggregates over approved reviews, kept up to date as reviews
+	// change rather than computed on read
+	RatingSum     int     `json:"-" gorm:"not null;default:0"`
+	RatingCount   int     `json:"rating_count" gorm:"not null;default:0"`
+	AverageRating float64 `json:"average_rating" gorm:"not null;default:0;index"`
+
+	// Version increases with every change to the book so a cached copy can
+	// never be replaced by an older one
+	Version int64 `json:"version" gorm:"not null;default:1"`
+
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Publisher *Publisher `json:"publisher,omitempty" gorm:"constraint:OnDelete:SET NULL"`
+
+	Authors []BookAuthor `json:"authors,omitempty" gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE"`
+	Genres  []Genre      `json:"genres,omitempty" gorm:"many2many:book_genres;constraint:OnDelete:CASCADE"`
+	Tags    []Tag        `json:"tags,omitempty" gorm:"many2many:book_tags;constraint:OnDelete:CASCADE"`
+
+	// AvailableCopies is filled from the copies table on read. It is kept out
+	// of cached books so copy changes never have to invalidate them.
+	AvailableCopies int `json:"-" gorm:"-"`
 }
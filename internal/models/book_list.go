@@ -0,0 +1,123 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// BookFilter narrows a book listing. The zero value matches every book.
+type BookFilter struct {
+	// Genre is a genre slug; books in its descendant genres match too
+	Genre string
+	// Tags are tag slugs; a book must carry all of them
+	Tags []string
+	// Sort orders the listing; empty means BookSortNewest
+	Sort BookSort
+}
+
+type BookSort string
+
+const (
+	BookSortNewest BookSort = "newest"
+	BookSortOldest BookSort = "oldest"
+	BookSortTitle  BookSort = "title"
+	BookSortYear   BookSort = "year"
+	// BookSortRating puts the highest average rating first, breaking ties by
+	// the number of ratings
+	BookSortRating BookSort = "rating"
+	// BookSortPopular puts the most rated books first
+	BookSortPopular BookSort = "popular"
+)
+
+// Valid reports whether s is a known sort order or empty
+func (s BookSort) Valid() bool {
+	switch s {
+	case "", BookSortNewest, BookSortOldest, BookSortTitle, BookSortYear, BookSortRating, BookSortPopular:
+		return true
+	}
+	return false
+}
+
+// CacheKey returns a stable representation of the filter for cache keys.
+// It is empty for the zero filter.
+func (f BookFilter) CacheKey() string {
+	var parts []string
+	if f.Genre != "" {
+		parts = append(parts, "genre="+f.Genre)
+	}
+	if len(f.Tags) > 0 {
+		tags := append([]string(nil), f.Tags...)
+		sort.Strings(tags)
+		parts = append(parts, "tag="+strings.Join(tags, ","))
+	}
+	if f.Sort != "" && f.Sort != BookSortNewest {
+		parts = append(parts, "sort="+string(f.Sort))
+	}
+	return strings.Join(parts, "&")
+}
+
+// AffectsListings reports whether changing a book from before to after can
+// move it between listing pages or change facet and stats counts, rather
+// than only changing how it is shown on the pages it already appears on
+func AffectsListings(before, after *Book) bool {
+	if before.Title != after.Title || before.Year != after.Year {
+		return true
+	}
+
+	if len(before.Genres) != len(after.Genres) || len(before.Tags) != len(after.Tags) || len(before.Authors) != len(after.Authors) {
+		return true
+	}
+	genres := make(map[uint]bool, len(before.Genres))
+	for _, genre := range before.Genres {
+		genres[genre.ID] = true
+	}
+	for _, genre := range after.Genres {
+		if !genres[genre.ID] {
+			return true
+		}
+	}
+	tags := make(map[uint]bool, len(before.Tags))
+	for _, tag := range before.Tags {
+		tags[tag.ID] = true
+	}
+	for _, tag := range after.Tags {
+		if !tags[tag.ID] {
+			return true
+		}
+	}
+	type credit struct {
+		authorID uint
+		role     AuthorRole
+	}
+	authors := make(map[credit]bool, len(before.Authors))
+	for _, author := range before.Authors {
+		authors[credit{author.AuthorID, author.Role}] = true
+	}
+	for _, author := range after.Authors {
+		if !authors[credit{author.AuthorID, author.Role}] {
+			return true
+		}
+	}
+
+	return false
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Name  string `json:"name,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// BookFacets counts the books matching a filter per genre, tag and decade
+type BookFacets struct {
+	Genres  []FacetCount `json:"genres"`
+	Tags    []FacetCount `json:"tags"`
+	Decades []FacetCount `json:"decades"`
+}
+
+// BookList is one page of a filtered book listing
+type BookList struct {
+	Books  []Book      `json:"books"`
+	Total  int64       `json:"total"`
+	Facets *BookFacets `json:"facets,omitempty"`
+}
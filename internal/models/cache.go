@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// CacheWarmup reports what a cache warm-up loaded
+type CacheWarmup struct {
+	Pages    int
+	Books    int
+	Duration time.Duration
+}
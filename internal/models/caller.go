@@ -0,0 +1,13 @@
+package models
+
+// Caller identifies who made a request: the user named by the gateway, if
+// any, and whether the admin token was presented
+type Caller struct {
+	UserID string
+	Admin  bool
+}
+
+// Owns reports whether the caller is the user userID or an admin
+func (c Caller) Owns(userID string) bool {
+	return c.Admin || (c.UserID != "" && c.UserID == userID)
+}
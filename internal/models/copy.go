@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+type CopyStatus string
+
+const (
+	CopyAvailable CopyStatus = "available"
+	CopyOnLoan    CopyStatus = "on_loan"
+	CopyOnHold    CopyStatus = "on_hold"
+	CopyInRepair  CopyStatus = "in_repair"
+	CopyLost      CopyStatus = "lost"
+	CopyWithdrawn CopyStatus = "withdrawn"
+)
+
+type CopyCondition string
+
+const (
+	ConditionNew     CopyCondition = "new"
+	ConditionGood    CopyCondition = "good"
+	ConditionFair    CopyCondition = "fair"
+	ConditionPoor    CopyCondition = "poor"
+	ConditionDamaged CopyCondition = "damaged"
+)
+
+// Copy is a physical item of a book held at a library branch
+type Copy struct {
+	ID        uint          `json:"id" gorm:"primaryKey"`
+	BookID    uint          `json:"book_id" gorm:"not null;index:idx_copies_book_status,priority:1"`
+	Barcode   string        `json:"barcode" gorm:"size:64;not null;uniqueIndex"`
+	Branch    string        `json:"branch" gorm:"size:100;not null"`
+	Location  string        `json:"location" gorm:"size:100"`
+	Condition CopyCondition `json:"condition" gorm:"size:20;not null"`
+	Status    CopyStatus    `json:"status" gorm:"size:20;not null;index:idx_copies_book_status,priority:2"`
+	CreatedAt time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Book *Book `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "path"
+
+// Cover image variants. The original keeps its uploaded format; resized
+// variants are always JPEG.
+const (
+	CoverOriginal  = "original"
+	CoverMedium    = "medium"
+	CoverThumbnail = "thumbnail"
+)
+
+// CoverVariantKey returns the blob key of a resized variant stored next to
+// the original cover at originalKey
+func CoverVariantKey(originalKey, variant string) string {
+	return path.Dir(originalKey) + "/" + variant + ".jpg"
+}
@@ -0,0 +1,25 @@
+package models
+
+type HealthStatus string
+
+const (
+	// HealthOK means every component is up
+	HealthOK HealthStatus = "ok"
+	// HealthDegraded means an optional component, such as the cache, is
+	// down and the API is running without it
+	HealthDegraded HealthStatus = "degraded"
+	// HealthDown means a component the API cannot serve without is down
+	HealthDown HealthStatus = "down"
+)
+
+type ComponentHealth struct {
+	Name     string
+	Critical bool
+	// Err is nil when the component is up
+	Err error
+}
+
+type Health struct {
+	Status     HealthStatus
+	Components []ComponentHealth
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+type HoldStatus string
+
+const (
+	// HoldWaiting holds are queued for the next copy of the book
+	HoldWaiting HoldStatus = "waiting"
+	// HoldReady holds have a copy set aside until ExpiresAt
+	HoldReady     HoldStatus = "ready"
+	HoldFulfilled HoldStatus = "fulfilled"
+	HoldCancelled HoldStatus = "cancelled"
+	HoldExpired   HoldStatus = "expired"
+)
+
+// Hold reserves the next available copy of a book for a member. Waiting
+// holds are served first come, first served in ID order.
+type Hold struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	BookID    uint       `json:"book_id" gorm:"not null;index:idx_holds_book_status,priority:1;uniqueIndex:idx_holds_open_member,priority:1,where:status IN ('waiting'\\,'ready')"`
+	MemberID  string     `json:"member_id" gorm:"size:64;not null;index;uniqueIndex:idx_holds_open_member,priority:2"`
+	Status    HoldStatus `json:"status" gorm:"size:20;not null;index:idx_holds_book_status,priority:2"`
+	CopyID    *uint      `json:"copy_id"`
+	ReadyAt   *time.Time `json:"ready_at"`
+	ExpiresAt *time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Book *Book `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Copy *Copy `json:"-" gorm:"constraint:OnDelete:SET NULL"`
+}
+
+// Open reports whether the hold is still queued or awaiting pickup
+func (h *Hold) Open() bool {
+	return h.Status == HoldWaiting || h.Status == HoldReady
+}
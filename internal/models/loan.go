@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type LoanStatus string
+
+const (
+	LoanActive   LoanStatus = "active"
+	LoanOverdue  LoanStatus = "overdue"
+	LoanReturned LoanStatus = "returned"
+)
+
+// Loan records a copy lent to a member. At most one loan per copy may be
+// open at a time, which the partial unique index enforces.
+type Loan struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	CopyID       uint       `json:"copy_id" gorm:"not null;index;uniqueIndex:idx_loans_open_copy,where:returned_at IS NULL"`
+	BookID       uint       `json:"book_id" gorm:"not null;index"`
+	MemberID     string     `json:"member_id" gorm:"size:64;not null;index:idx_loans_member_status,priority:1"`
+	Status       LoanStatus `json:"status" gorm:"size:20;not null;index:idx_loans_member_status,priority:2;index:idx_loans_status_due,priority:1"`
+	CheckedOutAt time.Time  `json:"checked_out_at" gorm:"not null"`
+	DueAt        time.Time  `json:"due_at" gorm:"not null;index:idx_loans_status_due,priority:2"`
+	ReturnedAt   *time.Time `json:"returned_at"`
+	Renewals     int        `json:"renewals" gorm:"not null;default:0"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Copy *Copy `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Book *Book `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// Open reports whether the copy is still out
+func (l *Loan) Open() bool {
+	return l.Status != LoanReturned
+}
+
+// LoanFilter narrows a loan listing. Empty fields match every loan.
+type LoanFilter struct {
+	MemberID string
+	Status   LoanStatus
+}
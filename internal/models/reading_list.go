@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+type ReadingListKind string
+
+const (
+	// Built-in shelves every user has exactly one of. A book sits on at most
+	// one of them at a time.
+	ShelfWantToRead ReadingListKind = "want_to_read"
+	ShelfReading    ReadingListKind = "reading"
+	ShelfRead       ReadingListKind = "read"
+	// CustomList is a list the user named themselves
+	CustomList ReadingListKind = "custom"
+)
+
+// Shelves are the built-in lists in display order
+var Shelves = []ReadingListKind{ShelfWantToRead, ShelfReading, ShelfRead}
+
+// IsShelf reports whether kind is one of the built-in shelves
+func (k ReadingListKind) IsShelf() bool {
+	return k == ShelfWantToRead || k == ShelfReading || k == ShelfRead
+}
+
+type ListVisibility string
+
+const (
+	// Private lists are only visible to their owner and to holders of the
+	// share token
+	ListPrivate ListVisibility = "private"
+	// Public lists are visible to anyone who knows their ID
+	ListPublic ListVisibility = "public"
+)
+
+// ReadingList is a user's shelf or named list of books
+type ReadingList struct {
+	ID          uint            `json:"id" gorm:"primaryKey"`
+	UserID      string          `json:"user_id" gorm:"size:64;not null;uniqueIndex:idx_reading_lists_user_name,priority:1;uniqueIndex:idx_reading_lists_user_shelf,priority:1,where:kind <> 'custom'"`
+	Kind        ReadingListKind `json:"kind" gorm:"size:20;not null;uniqueIndex:idx_reading_lists_user_shelf,priority:2"`
+	Name        string          `json:"name" gorm:"size:100;not null;uniqueIndex:idx_reading_lists_user_name,priority:2"`
+	Description string          `json:"description" gorm:"type:text"`
+	Visibility  ListVisibility  `json:"visibility" gorm:"size:20;not null;default:'private'"`
+	ShareToken  *string         `json:"-" gorm:"size:64;uniqueIndex"`
+	CreatedAt   time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Entries []ReadingListEntry `json:"entries,omitempty" gorm:"foreignKey:ListID;constraint:OnDelete:CASCADE"`
+
+	// EntryCount is filled by listings instead of loading every entry
+	EntryCount int `json:"entry_count" gorm:"->;-:migration"`
+}
+
+// ReadingListEntry places a book on a list. When the book is deleted the
+// entry is kept as a tombstone: BookID is cleared, BookRemovedAt set and
+// BookTitle still names what was there.
+type ReadingListEntry struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	ListID        uint       `json:"list_id" gorm:"not null;index:idx_reading_list_entries_position,priority:1;uniqueIndex:idx_reading_list_entries_book,priority:1"`
+	BookID        *uint      `json:"book_id" gorm:"index;uniqueIndex:idx_reading_list_entries_book,priority:2"`
+	BookTitle     string     `json:"book_title" gorm:"size:255;not null"`
+	BookRemovedAt *time.Time `json:"book_removed_at"`
+	// Position is the entry's 1-based place in the list
+	Position   int        `json:"position" gorm:"not null;index:idx_reading_list_entries_position,priority:2"`
+	Notes      string     `json:"notes" gorm:"type:text"`
+	StartedOn  *time.Time `json:"started_on" gorm:"type:date"`
+	FinishedOn *time.Time `json:"finished_on" gorm:"type:date"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Book *Book `json:"-" gorm:"constraint:OnDelete:SET NULL"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewApproved ReviewStatus = "approved"
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// Review is a user's rating of a book with an optional written review. Only
+// approved reviews count towards the book's rating.
+type Review struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	BookID    uint         `json:"book_id" gorm:"not null;uniqueIndex:idx_reviews_book_user,priority:1;index:idx_reviews_book_status,priority:1"`
+	UserID    string       `json:"user_id" gorm:"size:64;not null;uniqueIndex:idx_reviews_book_user,priority:2"`
+	Rating    int          `json:"rating" gorm:"not null"`
+	Title     string       `json:"title" gorm:"size:255"`
+	Body      string       `json:"body" gorm:"type:text"`
+	Status    ReviewStatus `json:"status" gorm:"size:20;not null;index:idx_reviews_book_status,priority:2"`
+	CreatedAt time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Book *Book `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// ratingContribution is what the review adds to its book's rating sum and
+// count
+func (r *Review) ratingContribution() (int, int) {
+	if r.Status != ReviewApproved {
+		return 0, 0
+	}
+	return r.Rating, 1
+}
+
+// RatingDelta returns how the book's rating sum and count change when a
+// review goes from before to after. Either may be nil for a review that is
+// created or deleted.
+func RatingDelta(before, after *Review) (int, int) {
+	var sum, count int
+	if after != nil {
+		sum, count = after.ratingContribution()
+	}
+	if before != nil {
+		beforeSum, beforeCount := before.ratingContribution()
+		sum -= beforeSum
+		count -= beforeCount
+	}
+	return sum, count
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type Series struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"not null"`
+	Slug        string    `json:"slug" gorm:"not null;uniqueIndex"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Entries []SeriesEntry `json:"entries,omitempty" gorm:"foreignKey:SeriesID;constraint:OnDelete:CASCADE"`
+}
+
+// SeriesEntry places a book in a series. Positions are fractional so a
+// novella can sit at 2.5 between the second and third books.
+type SeriesEntry struct {
+	SeriesID uint    `json:"series_id" gorm:"primaryKey;uniqueIndex:idx_series_entries_position,priority:1"`
+	BookID   uint    `json:"book_id" gorm:"primaryKey;index"`
+	Position float64 `json:"position" gorm:"not null;uniqueIndex:idx_series_entries_position,priority:2"`
+
+	Series *Series `json:"series,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+	Book   *Book   `json:"book,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// SeriesNeighbors is a book's place in one series with the books directly
+// before and after it
+type SeriesNeighbors struct {
+	Series   Series
+	Position float64
+	Previous *SeriesEntry
+	Next     *SeriesEntry
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// StatsFilter selects the days covered by the daily series in
+// CatalogueStats. From and To are whole UTC days, both included.
+type StatsFilter struct {
+	From time.Time
+	To   time.Time
+	// TopAuthors is how many authors to rank
+	TopAuthors int
+}
+
+// Days returns the number of days in the range
+func (f StatsFilter) Days() int {
+	return int(f.To.Sub(f.From).Hours()/24) + 1
+}
+
+// CacheKey returns a stable representation of the filter for cache keys
+func (f StatsFilter) CacheKey() string {
+	return fmt.Sprintf("from=%s&to=%s&top=%d", f.From.Format(time.DateOnly), f.To.Format(time.DateOnly), f.TopAuthors)
+}
+
+// CatalogueStats summarizes the catalogue as a whole and its growth over the
+// days selected by a StatsFilter
+type CatalogueStats struct {
+	TotalBooks  int64         `json:"total_books"`
+	ByDecade    []PeriodCount `json:"by_decade"`
+	ByYear      []PeriodCount `json:"by_year"`
+	TopAuthors  []AuthorCount `json:"top_authors"`
+	AddedPerDay []DailyCount  `json:"added_per_day"`
+	Growth      GrowthTrend   `json:"growth"`
+}
+
+// PeriodCount counts the books published in a year or decade
+type PeriodCount struct {
+	Period int   `json:"period"`
+	Count  int64 `json:"count"`
+}
+
+type AuthorCount struct {
+	AuthorID uint   `json:"author_id"`
+	Name     string `json:"name"`
+	Count    int64  `json:"count"`
+}
+
+// DailyCount is the number of books added on a day and the size of the
+// catalogue at the end of it
+type DailyCount struct {
+	Date  string `json:"date"`
+	Added int64  `json:"added"`
+	Total int64  `json:"total"`
+}
+
+// GrowthTrend compares the books added in the selected range with the same
+// number of days before it
+type GrowthTrend struct {
+	Added         int64   `json:"added"`
+	PreviousAdded int64   `json:"previous_added"`
+	AveragePerDay float64 `json:"average_per_day"`
+	// ChangePercent is nil when nothing was added in the previous period
+	ChangePercent *float64 `json:"change_percent"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Genre is a node in the genre hierarchy, e.g. Fiction > Fantasy > High Fantasy
+type Genre struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"not null;uniqueIndex"`
+	ParentID  *uint     `json:"parent_id,omitempty" gorm:"index"`
+	Parent    *Genre    `json:"-" gorm:"constraint:OnDelete:RESTRICT"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Slugify lowercases name and joins its words with hyphens
+func Slugify(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
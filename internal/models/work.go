@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type EditionFormat string
+
+const (
+	FormatHardcover EditionFormat = "hardcover"
+	FormatPaperback EditionFormat = "paperback"
+	FormatEbook     EditionFormat = "ebook"
+	FormatAudiobook EditionFormat = "audiobook"
+	FormatOther     EditionFormat = "other"
+)
+
+// Work is the abstract creation that one or more editions publish, e.g.
+// "The Hobbit" regardless of format, publisher or translation.
+type Work struct {
+	ID               uint      `json:"id" gorm:"primaryKey"`
+	Title            string    `json:"title" gorm:"not null"`
+	OriginalLanguage string    `json:"original_language" gorm:"size:35"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Editions []Book `json:"-" gorm:"foreignKey:WorkID;constraint:OnDelete:RESTRICT"`
+}
+
+type Publisher struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type AuthorRepository interface {
+	Create(ctx context.Context, author *models.Author) error
+	GetByID(ctx context.Context, id uint) (*models.Author, error)
+	List(ctx context.Context, limit, offset int) ([]models.Author, int64, error)
+	Update(ctx context.Context, author *models.Author) error
+	Delete(ctx context.Context, id uint) error
+
+	ListBooks(ctx context.Context, authorID uint, limit, offset int) ([]models.Book, int64, error)
+	BookIDs(ctx context.Context, authorID uint) ([]uint, error)
+}
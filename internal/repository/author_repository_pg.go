@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AuthorRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewAuthorRepository(db *gorm.DB) AuthorRepository {
+	return &AuthorRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *AuthorRepositoryPG) Create(ctx context.Context, author *models.Author) error {
+	author.NormalizedName = models.NormalizeAuthorName(author.Name)
+
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&models.Author{}).
+		Select("count(*) > 0").
+		Where("normalized_name = ?", author.NormalizedName).
+		Find(&exists).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if exists {
+		return errors.NewLocalizedError(errors.AlreadyExists, errors.MsgAuthorAlreadyExists)
+	}
+
+	if err := r.db.WithContext(ctx).Create(author).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *AuthorRepositoryPG) GetByID(ctx context.Context, id uint) (*models.Author, error) {
+	var author models.Author
+	result := r.db.WithContext(ctx).First(&author, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgAuthorNotFound)
+		}
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return &author, nil
+}
+
+func (r *AuthorRepositoryPG) List(ctx context.Context, limit, offset int) ([]models.Author, int64, error) {
+	var authors []models.Author
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.Author{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+
+	result := r.db.WithContext(ctx).
+		Limit(limit).
+		Offset(offset).
+		Order("name ASC").
+		Find(&authors)
+
+	if result.Error != nil {
+		return nil, 0, errors.NewDatabaseError(result.Error)
+	}
+	return authors, total, nil
+}
+
+func (r *AuthorRepositoryPG) Update(ctx context.Context, author *models.Author) error {
+	author.NormalizedName = models.NormalizeAuthorName(author.Name)
+
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&models.Author{}).
+		Select("count(*) > 0").
+		Where("normalized_name = ? AND id <> ?", author.NormalizedName, author.ID).
+		Find(&exists).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if exists {
+		return errors.NewLocalizedError(errors.AlreadyExists, errors.MsgAuthorAlreadyExists)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(author).
+		Select("name", "normalized_name").
+		Updates(author)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgAuthorNotFound)
+	}
+
+	return r.db.WithContext(ctx).First(author, author.ID).Error
+}
+
+func (r *AuthorRepositoryPG) Delete(ctx context.Context, id uint) error {
+	var linked bool
+	err := r.db.WithContext(ctx).
+		Model(&models.BookAuthor{}).
+		Select("count(*) > 0").
+		Where("author_id = ?", id).
+		Find(&linked).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if linked {
+		return errors.NewLocalizedError(errors.Conflict, errors.MsgAuthorHasBooks)
+	}
+
+	result := r.db.WithContext(ctx).Delete(&models.Author{}, id)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgAuthorNotFound)
+	}
+	return nil
+}
+
+func (r *AuthorRepositoryPG) ListBooks(ctx context.Context, authorID uint, limit, offset int) ([]models.Book, int64, error) {
+	var books []models.Book
+	var total int64
+
+	bookIDs := r.db.Model(&models.BookAuthor{}).Select("book_id").Where("author_id = ?", authorID)
+
+	if err := r.db.WithContext(ctx).Model(&models.Book{}).Where("id IN (?)", bookIDs).Count(&total).Error; err != nil {
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+
+	result := preloadAssociations(r.db.WithContext(ctx)).
+		Where("id IN (?)", bookIDs).
+		Limit(limit).
+		Offset(offset).
+		Order("year ASC, title ASC").
+		Find(&books)
+
+	if result.Error != nil {
+		return nil, 0, errors.NewDatabaseError(result.Error)
+	}
+	return books, total, nil
+}
+
+func (r *AuthorRepositoryPG) BookIDs(ctx context.Context, authorID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Model(&models.BookAuthor{}).
+		Distinct("book_id").
+		Where("author_id = ?", authorID).
+		Pluck("book_id", &ids).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	return ids, nil
+}
+
+// findOrCreateAuthor returns the author whose normalized name matches name,
+// creating it if needed. Concurrent callers settle on the same row through
+// the unique index on normalized_name.
+func findOrCreateAuthor(tx *gorm.DB, name string) (*models.Author, error) {
+	author := models.Author{
+		Name:           name,
+		NormalizedName: models.NormalizeAuthorName(name),
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "normalized_name"}},
+		DoNothing: true,
+	}).Create(&author).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if author.ID == 0 {
+		if err := tx.Where("normalized_name = ?", author.NormalizedName).First(&author).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &author, nil
+}
@@ -9,7 +9,20 @@ import (
 type BookRepository interface {
 	Create(ctx context.Context, book *models.Book) error
 	GetByID(ctx context.Context, id uint) (*models.Book, error)
-	List(ctx context.Context, limit, offset int) ([]models.Book, int64, error)
+	GetByISBN(ctx context.Context, isbn13 string) (*models.Book, error)
+	List(ctx context.Context, filter models.BookFilter, limit, offset int) ([]models.Book, int64, error)
+	Facets(ctx context.Context, filter models.BookFilter) (*models.BookFacets, error)
+	// Stats aggregates the whole catalogue, with daily counts over the
+	// filter's range
+	Stats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, error)
+	// MostBorrowed returns the IDs of the books lent most often, most
+	// borrowed first
+	MostBorrowed(ctx context.Context, limit int) ([]uint, error)
 	Update(ctx context.Context, book *models.Book) error
+	// Delete removes the book. Reading list entries for it are kept as
+	// tombstones rather than deleted.
 	Delete(ctx context.Context, id uint) error
+
+	// SetCover points the book at the blob key prefix of its cover images
+	SetCover(ctx context.Context, id uint, coverKey *string) error
 }
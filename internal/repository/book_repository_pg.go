@@ -2,12 +2,18 @@ package repository
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/AhmadMuj/books-api-go/internal/errors"
 	"github.com/AhmadMuj/books-api-go/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// facetLimit caps the number of genre and tag buckets returned per listing
+const facetLimit = 50
+
 type BookRepositoryPG struct {
 	db *gorm.DB
 }
@@ -19,53 +25,64 @@ func NewBookRepository(db *gorm.DB) BookRepository {
 }
 
 func (r *BookRepositoryPG) Create(ctx context.Context, book *models.Book) error {
-	// Check if book with same title and author exists
-	var exists bool
-	err := r.db.WithContext(ctx).
-		Model(&models.Book{}).
-		Select("count(*) > 0").
-		Where("title = ? AND author = ?", book.Title, book.Author).
-		Find(&exists).
-		Error
-	if err != nil {
-		return errors.NewDatabaseError(err)
-	}
-	if exists {
-		return errors.NewAlreadyExistsError("book with same title and author already exists")
+	if err := r.checkDuplicate(ctx, book); err != nil {
+		return err
 	}
 
-	result := r.db.WithContext(ctx).Create(book)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := resolveWork(tx, book); err != nil {
+			return err
+		}
+		if err := resolvePublisher(tx, book); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(book).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if err := replaceAuthors(tx, book); err != nil {
+			return err
+		}
+		return replaceTaxonomy(tx, book)
+	})
+}
+
+func (r *BookRepositoryPG) GetByID(ctx context.Context, id uint) (*models.Book, error) {
+	var book models.Book
+	result := preloadAssociations(r.db.WithContext(ctx)).First(&book, id)
 	if result.Error != nil {
-		return errors.NewDatabaseError(result.Error)
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+		}
+		return nil, errors.NewDatabaseError(result.Error)
 	}
-	return nil
+	return &book, nil
 }
 
-func (r *BookRepositoryPG) GetByID(ctx context.Context, id uint) (*models.Book, error) {
+func (r *BookRepositoryPG) GetByISBN(ctx context.Context, isbn13 string) (*models.Book, error) {
 	var book models.Book
-	result := r.db.WithContext(ctx).First(&book, id)
+	result := preloadAssociations(r.db.WithContext(ctx)).Where("isbn13 = ?", isbn13).First(&book)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return nil, errors.NewNotFoundError("book not found")
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
 		}
 		return nil, errors.NewDatabaseError(result.Error)
 	}
 	return &book, nil
 }
 
-func (r *BookRepositoryPG) List(ctx context.Context, limit, offset int) ([]models.Book, int64, error) {
+func (r *BookRepositoryPG) List(ctx context.Context, filter models.BookFilter, limit, offset int) ([]models.Book, int64, error) {
 	var books []models.Book
 	var total int64
 
 	// Get total count
-	if err := r.db.WithContext(ctx).Model(&models.Book{}).Count(&total).Error; err != nil {
+	if err := applyBookFilter(r.db.WithContext(ctx).Model(&models.Book{}), filter).Count(&total).Error; err != nil {
 		return nil, 0, errors.NewDatabaseError(err)
 	}
 
-	result := r.db.WithContext(ctx).
+	result := applyBookFilter(preloadAssociations(r.db.WithContext(ctx)), filter).
 		Limit(limit).
 		Offset(offset).
-		Order("created_at DESC").
+		Order(bookOrder(filter.Sort)).
 		Find(&books)
 
 	if result.Error != nil {
@@ -75,23 +92,419 @@ func (r *BookRepositoryPG) List(ctx context.Context, limit, offset int) ([]model
 }
 
 func (r *BookRepositoryPG) Update(ctx context.Context, book *models.Book) error {
-	result := r.db.WithContext(ctx).Save(book)
-	if result.Error != nil {
-		return errors.NewDatabaseError(result.Error)
+	if book.ISBN13 != nil {
+		if err := r.checkDuplicate(ctx, book); err != nil {
+			return err
+		}
 	}
-	if result.RowsAffected == 0 {
-		return errors.NewNotFoundError("book not found")
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Covers are only changed through SetCover and ratings by reviews
+		omit := []string{"id", "version", "created_at", "cover_key", "rating_sum", "rating_count", "average_rating", clause.Associations}
+		if err := resolveWork(tx, book); err != nil {
+			return err
+		}
+		if err := resolvePublisher(tx, book); err != nil {
+			return err
+		}
+
+		result := tx.Model(book).
+			Select("*").
+			Omit(omit...).
+			Updates(book)
+		if result.Error != nil {
+			return errors.NewDatabaseError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+		}
+		if err := bumpBookVersion(tx, book.ID); err != nil {
+			return err
+		}
+
+		if err := replaceAuthors(tx, book); err != nil {
+			return err
+		}
+		if err := replaceTaxonomy(tx, book); err != nil {
+			return err
+		}
+
+		if err := preloadAssociations(tx).First(book, book.ID).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	})
+}
+
+func (r *BookRepositoryPG) Facets(ctx context.Context, filter models.BookFilter) (*models.BookFacets, error) {
+	facets := &models.BookFacets{}
+	matching := applyBookFilter(r.db.Model(&models.Book{}).Select("books.id"), filter)
+
+	err := r.db.WithContext(ctx).
+		Table("genres").
+		Select("genres.slug AS value, genres.name AS name, count(*) AS count").
+		Joins("JOIN book_genres ON book_genres.genre_id = genres.id").
+		Where("book_genres.book_id IN (?)", matching).
+		Group("genres.id").
+		Order("count DESC, genres.name ASC").
+		Limit(facetLimit).
+		Scan(&facets.Genres).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
 	}
-	return nil
+
+	err = r.db.WithContext(ctx).
+		Table("tags").
+		Select("tags.slug AS value, tags.name AS name, count(*) AS count").
+		Joins("JOIN book_tags ON book_tags.tag_id = tags.id").
+		Where("book_tags.book_id IN (?)", matching).
+		Group("tags.id").
+		Order("count DESC, tags.name ASC").
+		Limit(facetLimit).
+		Scan(&facets.Tags).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	var decades []struct {
+		Decade int
+		Count  int64
+	}
+	err = applyBookFilter(r.db.WithContext(ctx).Model(&models.Book{}), filter).
+		Select("(year / 10) * 10 AS decade, count(*) AS count").
+		Group("decade").
+		Order("decade ASC").
+		Scan(&decades).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	facets.Decades = make([]models.FacetCount, len(decades))
+	for i, d := range decades {
+		decade := strconv.Itoa(d.Decade)
+		facets.Decades[i] = models.FacetCount{Value: decade, Name: decade + "s", Count: d.Count}
+	}
+
+	return facets, nil
+}
+
+func (r *BookRepositoryPG) Stats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, error) {
+	stats := &models.CatalogueStats{}
+	db := r.db.WithContext(ctx)
+
+	if err := db.Model(&models.Book{}).Count(&stats.TotalBooks).Error; err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	err := db.Model(&models.Book{}).
+		Select("year AS period, count(*) AS count").
+		Group("year").
+		Order("year ASC").
+		Scan(&stats.ByYear).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	// Decades are rolled up from the years rather than queried again
+	stats.ByDecade = []models.PeriodCount{}
+	for _, year := range stats.ByYear {
+		decade := year.Period / 10 * 10
+		if n := len(stats.ByDecade); n > 0 && stats.ByDecade[n-1].Period == decade {
+			stats.ByDecade[n-1].Count += year.Count
+			continue
+		}
+		stats.ByDecade = append(stats.ByDecade, models.PeriodCount{Period: decade, Count: year.Count})
+	}
+
+	err = db.Table("authors").
+		Select("authors.id AS author_id, authors.name AS name, count(*) AS count").
+		Joins("JOIN book_authors ON book_authors.author_id = authors.id").
+		Where("book_authors.role = ?", models.AuthorRoleAuthor).
+		Group("authors.id").
+		Order("count DESC, authors.name ASC").
+		Limit(filter.TopAuthors).
+		Scan(&stats.TopAuthors).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	// The daily counts also cover the same number of days before the range so
+	// the growth trend can compare the two periods
+	days := filter.Days()
+	previousFrom := filter.From.AddDate(0, 0, -days)
+	end := filter.To.AddDate(0, 0, 1)
+
+	var before int64
+	err = db.Model(&models.Book{}).
+		Where("created_at < ?", filter.From).
+		Count(&before).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	var daily []struct {
+		Day   time.Time
+		Count int64
+	}
+	err = db.Model(&models.Book{}).
+		Select("(created_at AT TIME ZONE 'UTC')::date AS day, count(*) AS count").
+		Where("created_at >= ? AND created_at < ?", previousFrom, end).
+		Group("day").
+		Order("day ASC").
+		Scan(&daily).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	added := make(map[string]int64, len(daily))
+	for _, d := range daily {
+		if d.Day.Before(filter.From) {
+			stats.Growth.PreviousAdded += d.Count
+			continue
+		}
+		added[d.Day.Format(time.DateOnly)] = d.Count
+	}
+
+	// Days without new books are included with a count of zero
+	total := before
+	stats.AddedPerDay = make([]models.DailyCount, 0, days)
+	for day := filter.From; day.Before(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		total += added[date]
+		stats.Growth.Added += added[date]
+		stats.AddedPerDay = append(stats.AddedPerDay, models.DailyCount{Date: date, Added: added[date], Total: total})
+	}
+
+	stats.Growth.AveragePerDay = float64(stats.Growth.Added) / float64(days)
+	if stats.Growth.PreviousAdded > 0 {
+		change := float64(stats.Growth.Added-stats.Growth.PreviousAdded) / float64(stats.Growth.PreviousAdded) * 100
+		stats.Growth.ChangePercent = &change
+	}
+
+	return stats, nil
+}
+
+func (r *BookRepositoryPG) MostBorrowed(ctx context.Context, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Model(&models.Loan{}).
+		Group("book_id").
+		Order("COUNT(*) DESC, book_id").
+		Limit(limit).
+		Pluck("book_id", &ids).Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	return ids, nil
 }
 
 func (r *BookRepositoryPG) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&models.Book{}, id)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tombstoneListEntries(tx, id); err != nil {
+			return err
+		}
+
+		result := tx.Delete(&models.Book{}, id)
+		if result.Error != nil {
+			return errors.NewDatabaseError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+		}
+		return nil
+	})
+}
+
+func (r *BookRepositoryPG) SetCover(ctx context.Context, id uint, coverKey *string) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Book{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"cover_key":  coverKey,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		})
 	if result.Error != nil {
 		return errors.NewDatabaseError(result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return errors.NewNotFoundError("book not found")
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+	}
+	return nil
+}
+
+// bumpBookVersion marks a book as changed for version-guarded caching
+func bumpBookVersion(tx *gorm.DB, bookID uint) error {
+	err := tx.Model(&models.Book{}).
+		Where("id = ?", bookID).
+		UpdateColumn("version", gorm.Expr("version + 1")).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+// checkDuplicate rejects a book whose ISBN is already taken by another
+// book. Books without an ISBN fall back to matching on title, author, format
+// and language, so a work's hardcover and its translations can coexist.
+func (r *BookRepositoryPG) checkDuplicate(ctx context.Context, book *models.Book) error {
+	query := r.db.WithContext(ctx).
+		Model(&models.Book{}).
+		Select("count(*) > 0").
+		Where("id <> ?", book.ID)
+
+	msg := errors.MsgBookAlreadyExists
+	if book.ISBN13 != nil {
+		query = query.Where("isbn13 = ?", *book.ISBN13)
+		msg = errors.MsgISBNAlreadyExists
+	} else {
+		query = query.Where("title = ? AND author = ? AND format = ? AND language = ?",
+			book.Title, book.Author, book.Format, book.Language)
+	}
+
+	var exists bool
+	if err := query.Find(&exists).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if exists {
+		return errors.NewLocalizedError(errors.AlreadyExists, msg)
+	}
+	return nil
+}
+
+func preloadAssociations(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Publisher").
+		Preload("Authors", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Authors.Author").
+		Preload("Genres", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		}).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		})
+}
+
+// bookOrder returns the ORDER BY for a listing. Every order ends with the ID
+// so pages are stable.
+func bookOrder(sort models.BookSort) string {
+	switch sort {
+	case models.BookSortOldest:
+		return "created_at ASC, id ASC"
+	case models.BookSortTitle:
+		return "title ASC, id ASC"
+	case models.BookSortYear:
+		return "year DESC, id DESC"
+	case models.BookSortRating:
+		return "average_rating DESC, rating_count DESC, id DESC"
+	case models.BookSortPopular:
+		return "rating_count DESC, average_rating DESC, id DESC"
+	default:
+		return "created_at DESC, id DESC"
+	}
+}
+
+func applyBookFilter(db *gorm.DB, filter models.BookFilter) *gorm.DB {
+	if filter.Genre != "" {
+		db = db.Where("books.id IN (SELECT book_id FROM book_genres WHERE genre_id IN ("+subtreeSQL("slug")+"))", filter.Genre)
+	}
+	for _, tag := range filter.Tags {
+		db = db.Where("books.id IN (SELECT book_tags.book_id FROM book_tags JOIN tags ON tags.id = book_tags.tag_id WHERE tags.slug = ?)", tag)
+	}
+	return db
+}
+
+// replaceAuthors rewrites the book's contributor links. Links that only
+// carry an author name are resolved to an existing author or a new one.
+func replaceAuthors(tx *gorm.DB, book *models.Book) error {
+	if err := tx.Where("book_id = ?", book.ID).Delete(&models.BookAuthor{}).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if len(book.Authors) == 0 {
+		return nil
+	}
+
+	for i := range book.Authors {
+		link := &book.Authors[i]
+		link.BookID = book.ID
+
+		if link.AuthorID == 0 && link.Author != nil {
+			author, err := findOrCreateAuthor(tx, link.Author.Name)
+			if err != nil {
+				return errors.NewDatabaseError(err)
+			}
+			link.AuthorID = author.ID
+			link.Author = author
+			continue
+		}
+
+		var author models.Author
+		if err := tx.First(&author, link.AuthorID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewLocalizedError(errors.NotFound, errors.MsgAuthorNotFound)
+			}
+			return errors.NewDatabaseError(err)
+		}
+		link.Author = &author
+	}
+
+	if err := tx.Omit("Author").Create(&book.Authors).Error; err != nil {
+		return errors.NewDatabaseError(err)
 	}
 	return nil
 }
+
+// replaceTaxonomy rewrites the book's genres and tags. Genres must already
+// exist; tags are created on first use.
+func replaceTaxonomy(tx *gorm.DB, book *models.Book) error {
+	// Repeated IDs are collapsed so they neither fail the count below nor
+	// link a genre twice
+	genreIDs := make([]uint, 0, len(book.Genres))
+	seen := make(map[uint]bool, len(book.Genres))
+	for _, genre := range book.Genres {
+		if !seen[genre.ID] {
+			seen[genre.ID] = true
+			genreIDs = append(genreIDs, genre.ID)
+		}
+	}
+
+	var genres []models.Genre
+	if len(genreIDs) > 0 {
+		if err := tx.Where("id IN ?", genreIDs).Find(&genres).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if len(genres) != len(genreIDs) {
+			return errors.NewLocalizedError(errors.NotFound, errors.MsgGenreNotFound)
+		}
+	}
+
+	tagNames := make([]string, len(book.Tags))
+	for i, tag := range book.Tags {
+		tagNames[i] = tag.Name
+	}
+
+	tags, err := findOrCreateTags(tx, tagNames)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	if err := tx.Model(book).Association("Genres").Replace(genres); err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if err := tx.Model(book).Association("Tags").Replace(tags); err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	book.Genres, book.Tags = genres, tags
+	return nil
+}
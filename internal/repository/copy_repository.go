@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type CopyRepository interface {
+	// Create adds the copy. An available copy is offered to the book's hold
+	// queue first: the oldest waiting hold becomes ready until readyUntil
+	// and is returned.
+	Create(ctx context.Context, bookCopy *models.Copy, readyUntil time.Time) (*models.Hold, error)
+	// GetByID returns the copy if it belongs to the book
+	GetByID(ctx context.Context, bookID, id uint) (*models.Copy, error)
+	ListByBook(ctx context.Context, bookID uint) ([]models.Copy, error)
+	// Update saves the copy and returns the status it had before. The copy
+	// is locked while it changes, and the on_loan and on_hold statuses are
+	// left to loans and holds: changing to or from them is rejected. A copy
+	// that becomes available is offered to the hold queue like in Create.
+	Update(ctx context.Context, bookCopy *models.Copy, readyUntil time.Time) (models.CopyStatus, *models.Hold, error)
+	// Delete removes the copy unless it is on loan
+	Delete(ctx context.Context, bookID, id uint) error
+
+	// CountAvailable returns the number of available copies per book. Books
+	// without copies are reported as 0.
+	CountAvailable(ctx context.Context, bookIDs []uint) (map[uint]int, error)
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CopyRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewCopyRepository(db *gorm.DB) CopyRepository {
+	return &CopyRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *CopyRepositoryPG) Create(ctx context.Context, bookCopy *models.Copy, readyUntil time.Time) (*models.Hold, error) {
+	var ready *models.Hold
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkBarcode(tx, bookCopy); err != nil {
+			return err
+		}
+
+		var book models.Book
+		if err := tx.Select("id").First(&book, bookCopy.BookID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+			}
+			return errors.NewDatabaseError(err)
+		}
+
+		available := bookCopy.Status == models.CopyAvailable
+		if available {
+			if err := lockHoldQueue(tx, bookCopy.BookID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Create(bookCopy).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		if available {
+			var err error
+			ready, err = allocateCopy(tx, bookCopy, readyUntil)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ready, nil
+}
+
+func (r *CopyRepositoryPG) GetByID(ctx context.Context, bookID, id uint) (*models.Copy, error) {
+	var bookCopy models.Copy
+	result := r.db.WithContext(ctx).Where("book_id = ?", bookID).First(&bookCopy, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgCopyNotFound)
+		}
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return &bookCopy, nil
+}
+
+func (r *CopyRepositoryPG) ListByBook(ctx context.Context, bookID uint) ([]models.Copy, error) {
+	var book models.Book
+	if err := r.db.WithContext(ctx).Select("id").First(&book, bookID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	var copies []models.Copy
+	result := r.db.WithContext(ctx).
+		Where("book_id = ?", bookID).
+		Order("branch ASC, barcode ASC").
+		Find(&copies)
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return copies, nil
+}
+
+func (r *CopyRepositoryPG) Update(ctx context.Context, bookCopy *models.Copy, readyUntil time.Time) (models.CopyStatus, *models.Hold, error) {
+	var previous models.CopyStatus
+	var ready *models.Hold
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The queue lock is taken before the copy's, as returns do
+		available := bookCopy.Status == models.CopyAvailable
+		if available {
+			if err := lockHoldQueue(tx, bookCopy.BookID); err != nil {
+				return err
+			}
+		}
+
+		// Lock the copy so a checkout cannot lend it between the status
+		// check and the update
+		var current models.Copy
+		if err := lockCopy(tx, &current, bookCopy.BookID, bookCopy.ID); err != nil {
+			return err
+		}
+		if (current.Status == models.CopyOnLoan) != (bookCopy.Status == models.CopyOnLoan) {
+			return errors.NewFieldValidationError("status", "loan", errors.MsgCopyLoanStatus)
+		}
+		if (current.Status == models.CopyOnHold) != (bookCopy.Status == models.CopyOnHold) {
+			return errors.NewFieldValidationError("status", "hold", errors.MsgCopyHoldStatus)
+		}
+
+		if err := checkBarcode(tx, bookCopy); err != nil {
+			return err
+		}
+
+		err := tx.Model(bookCopy).
+			Select("barcode", "branch", "location", "condition", "status").
+			Updates(bookCopy).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		if available && current.Status != models.CopyAvailable {
+			if ready, err = allocateCopy(tx, bookCopy, readyUntil); err != nil {
+				return err
+			}
+		}
+		if err := tx.First(bookCopy, bookCopy.ID).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		previous = current.Status
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return previous, ready, nil
+}
+
+func (r *CopyRepositoryPG) Delete(ctx context.Context, bookID, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var bookCopy models.Copy
+		if err := lockCopy(tx, &bookCopy, bookID, id); err != nil {
+			return err
+		}
+		if bookCopy.Status == models.CopyOnLoan {
+			return errors.NewLocalizedError(errors.Conflict, errors.MsgCopyOnLoan)
+		}
+
+		if err := tx.Delete(&bookCopy).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	})
+}
+
+func (r *CopyRepositoryPG) CountAvailable(ctx context.Context, bookIDs []uint) (map[uint]int, error) {
+	counts := make(map[uint]int, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return counts, nil
+	}
+	for _, id := range bookIDs {
+		counts[id] = 0
+	}
+
+	var rows []struct {
+		BookID uint
+		Count  int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.Copy{}).
+		Select("book_id, count(*) AS count").
+		Where("book_id IN ? AND status = ?", bookIDs, models.CopyAvailable).
+		Group("book_id").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	for _, row := range rows {
+		counts[row.BookID] = row.Count
+	}
+	return counts, nil
+}
+
+func checkBarcode(db *gorm.DB, bookCopy *models.Copy) error {
+	var exists bool
+	err := db.
+		Model(&models.Copy{}).
+		Select("count(*) > 0").
+		Where("barcode = ? AND id <> ?", bookCopy.Barcode, bookCopy.ID).
+		Find(&exists).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if exists {
+		return errors.NewLocalizedError(errors.AlreadyExists, errors.MsgCopyBarcodeExists)
+	}
+	return nil
+}
+
+// lockCopy loads the book's copy and locks it for the rest of the
+// transaction
+func lockCopy(tx *gorm.DB, bookCopy *models.Copy, bookID, id uint) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("book_id = ?", bookID).
+		First(bookCopy, id).
+		Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewLocalizedError(errors.NotFound, errors.MsgCopyNotFound)
+		}
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
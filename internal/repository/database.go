@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/AhmadMuj/books-api-go/internal/config"
@@ -28,9 +29,25 @@ func NewDatabase(cfg *config.Config) (*Database, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	if err := db.AutoMigrate(&models.Book{}); err != nil {
+	if err := db.AutoMigrate(&models.Work{}, &models.Publisher{}, &models.Book{}, &models.Author{}, &models.BookAuthor{}, &models.Genre{}, &models.Tag{}, &models.Series{}, &models.SeriesEntry{}, &models.Copy{}, &models.Loan{}, &models.Hold{}, &models.Review{}, &models.ReadingList{}, &models.ReadingListEntry{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if err := backfillBookAuthors(db); err != nil {
+		return nil, err
+	}
+	if err := backfillWorks(db); err != nil {
+		return nil, err
+	}
+
 	return &Database{DB: db}, nil
 }
+
+// Ping checks that the database can be reached
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
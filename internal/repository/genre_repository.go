@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type GenreRepository interface {
+	Create(ctx context.Context, genre *models.Genre) error
+	GetByID(ctx context.Context, id uint) (*models.Genre, error)
+	List(ctx context.Context) ([]models.Genre, error)
+	Update(ctx context.Context, genre *models.Genre) error
+	Delete(ctx context.Context, id uint) error
+
+	BookIDs(ctx context.Context, genreID uint) ([]uint, error)
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// genreSubtreeSQL selects the IDs of a genre and all of its descendants
+const genreSubtreeSQL = `WITH RECURSIVE subtree AS (
+	SELECT id FROM genres WHERE %s = ?
+	UNION ALL
+	SELECT g.id FROM genres g JOIN subtree s ON g.parent_id = s.id
+) SELECT id FROM subtree`
+
+type GenreRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewGenreRepository(db *gorm.DB) GenreRepository {
+	return &GenreRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *GenreRepositoryPG) Create(ctx context.Context, genre *models.Genre) error {
+	genre.Slug = models.Slugify(genre.Name)
+
+	if err := r.checkGenre(ctx, genre); err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Omit("Parent").Create(genre).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *GenreRepositoryPG) GetByID(ctx context.Context, id uint) (*models.Genre, error) {
+	var genre models.Genre
+	result := r.db.WithContext(ctx).First(&genre, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgGenreNotFound)
+		}
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return &genre, nil
+}
+
+func (r *GenreRepositoryPG) List(ctx context.Context) ([]models.Genre, error) {
+	var genres []models.Genre
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&genres).Error; err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	return genres, nil
+}
+
+func (r *GenreRepositoryPG) Update(ctx context.Context, genre *models.Genre) error {
+	genre.Slug = models.Slugify(genre.Name)
+
+	if err := r.checkGenre(ctx, genre); err != nil {
+		return err
+	}
+
+	if genre.ParentID != nil {
+		var cycle bool
+		err := r.db.WithContext(ctx).
+			Raw("SELECT ? IN ("+subtreeSQL("id")+")", *genre.ParentID, genre.ID).
+			Scan(&cycle).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if cycle {
+			return errors.NewFieldValidationError("parent_id", "cycle", errors.MsgGenreParentCycle)
+		}
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(genre).
+		Select("name", "slug", "parent_id").
+		Updates(genre)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgGenreNotFound)
+	}
+
+	return r.db.WithContext(ctx).First(genre, genre.ID).Error
+}
+
+func (r *GenreRepositoryPG) Delete(ctx context.Context, id uint) error {
+	var hasChildren bool
+	err := r.db.WithContext(ctx).
+		Model(&models.Genre{}).
+		Select("count(*) > 0").
+		Where("parent_id = ?", id).
+		Find(&hasChildren).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if hasChildren {
+		return errors.NewLocalizedError(errors.Conflict, errors.MsgGenreHasChildren)
+	}
+
+	result := r.db.WithContext(ctx).Delete(&models.Genre{}, id)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgGenreNotFound)
+	}
+	return nil
+}
+
+func (r *GenreRepositoryPG) BookIDs(ctx context.Context, genreID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Table("book_genres").
+		Where("genre_id = ?", genreID).
+		Pluck("book_id", &ids).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	return ids, nil
+}
+
+// checkGenre rejects duplicate slugs and parents that do not exist
+func (r *GenreRepositoryPG) checkGenre(ctx context.Context, genre *models.Genre) error {
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&models.Genre{}).
+		Select("count(*) > 0").
+		Where("slug = ? AND id <> ?", genre.Slug, genre.ID).
+		Find(&exists).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if exists {
+		return errors.NewLocalizedError(errors.AlreadyExists, errors.MsgGenreAlreadyExists)
+	}
+
+	if genre.ParentID != nil {
+		if _, err := r.GetByID(ctx, *genre.ParentID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func subtreeSQL(column string) string {
+	return fmt.Sprintf(genreSubtreeSQL, column)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type HoldRepository interface {
+	// Place adds a hold to the back of its book's queue. Holds are only
+	// accepted while no copy of the book is available.
+	Place(ctx context.Context, hold *models.Hold) error
+	GetByID(ctx context.Context, id uint) (*models.Hold, error)
+	// Position returns the hold's 1-based place among the book's waiting
+	// holds, or 0 if it is not waiting
+	Position(ctx context.Context, hold *models.Hold) (int, error)
+	// ListQueue returns the book's open holds in the order they are served
+	ListQueue(ctx context.Context, bookID uint) ([]models.Hold, error)
+	// Cancel closes the hold. A copy set aside for it passes to the next
+	// waiting hold, which becomes ready until readyUntil and is returned.
+	Cancel(ctx context.Context, id uint, readyUntil time.Time) (*models.Hold, *models.Hold, error)
+	// Expire closes ready holds not collected by now and passes their copies
+	// on like Cancel, returning the expired and the newly ready holds
+	Expire(ctx context.Context, now, readyUntil time.Time) ([]models.Hold, []models.Hold, error)
+}
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HoldRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewHoldRepository(db *gorm.DB) HoldRepository {
+	return &HoldRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *HoldRepositoryPG) Place(ctx context.Context, hold *models.Hold) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var book models.Book
+		if err := tx.Select("id").First(&book, hold.BookID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+			}
+			return errors.NewDatabaseError(err)
+		}
+
+		if err := lockHoldQueue(tx, hold.BookID); err != nil {
+			return err
+		}
+
+		var exists bool
+		err := tx.Model(&models.Hold{}).
+			Select("count(*) > 0").
+			Where("book_id = ? AND member_id = ? AND status IN ?", hold.BookID, hold.MemberID, openHoldStatuses).
+			Find(&exists).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if exists {
+			return errors.NewLocalizedError(errors.Conflict, errors.MsgHoldExists)
+		}
+
+		var available bool
+		err = tx.Model(&models.Copy{}).
+			Select("count(*) > 0").
+			Where("book_id = ? AND status = ?", hold.BookID, models.CopyAvailable).
+			Find(&available).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if available {
+			return errors.NewLocalizedError(errors.Conflict, errors.MsgBookAvailable)
+		}
+
+		hold.Status = models.HoldWaiting
+		if err := tx.Omit(clause.Associations).Create(hold).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	})
+}
+
+func (r *HoldRepositoryPG) GetByID(ctx context.Context, id uint) (*models.Hold, error) {
+	var hold models.Hold
+	if err := r.db.WithContext(ctx).First(&hold, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgHoldNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+	return &hold, nil
+}
+
+func (r *HoldRepositoryPG) Position(ctx context.Context, hold *models.Hold) (int, error) {
+	if hold.Status != models.HoldWaiting {
+		return 0, nil
+	}
+
+	var position int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Hold{}).
+		Where("book_id = ? AND status = ? AND id <= ?", hold.BookID, models.HoldWaiting, hold.ID).
+		Count(&position).
+		Error
+	if err != nil {
+		return 0, errors.NewDatabaseError(err)
+	}
+	return int(position), nil
+}
+
+func (r *HoldRepositoryPG) ListQueue(ctx context.Context, bookID uint) ([]models.Hold, error) {
+	var book models.Book
+	if err := r.db.WithContext(ctx).Select("id").First(&book, bookID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	// Ready holds come first since they have already left the queue
+	var holds []models.Hold
+	result := r.db.WithContext(ctx).
+		Where("book_id = ? AND status IN ?", bookID, openHoldStatuses).
+		Order(clause.Expr{SQL: "status = ? DESC, id ASC", Vars: []interface{}{models.HoldReady}}).
+		Find(&holds)
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return holds, nil
+}
+
+func (r *HoldRepositoryPG) Cancel(ctx context.Context, id uint, readyUntil time.Time) (*models.Hold, *models.Hold, error) {
+	hold, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *models.Hold
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		next, err = closeHold(tx, hold, models.HoldCancelled, readyUntil)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return hold, next, nil
+}
+
+func (r *HoldRepositoryPG) Expire(ctx context.Context, now, readyUntil time.Time) ([]models.Hold, []models.Hold, error) {
+	var candidates []models.Hold
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", models.HoldReady, now).
+		Order("id ASC").
+		Find(&candidates)
+	if result.Error != nil {
+		return nil, nil, errors.NewDatabaseError(result.Error)
+	}
+
+	var expired, ready []models.Hold
+	for i := range candidates {
+		hold := &candidates[i]
+
+		// One transaction per hold so a failure leaves the others expired
+		var next *models.Hold
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var err error
+			next, err = closeHold(tx, hold, models.HoldExpired, readyUntil)
+			return err
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.MsgHoldClosed {
+				// Collected or cancelled since it was read
+				continue
+			}
+			return expired, ready, err
+		}
+
+		expired = append(expired, *hold)
+		if next != nil {
+			ready = append(ready, *next)
+		}
+	}
+
+	return expired, ready, nil
+}
+
+var openHoldStatuses = []models.HoldStatus{models.HoldWaiting, models.HoldReady}
+
+// lockHoldQueue serializes changes to a book's hold queue for the rest of
+// the transaction. It must be taken before locking any hold rows of the
+// book so that queue changes cannot deadlock.
+func lockHoldQueue(tx *gorm.DB, bookID uint) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", fmt.Sprintf("hold-queue:%d", bookID)).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+// closeHold moves an open hold to status and passes any copy set aside for
+// it to the next waiting hold. hold is reloaded under lock first, as it may
+// have changed since it was read.
+func closeHold(tx *gorm.DB, hold *models.Hold, status models.HoldStatus, readyUntil time.Time) (*models.Hold, error) {
+	if err := lockHoldQueue(tx, hold.BookID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(hold, hold.ID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgHoldNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+	if !hold.Open() {
+		return nil, errors.NewLocalizedError(errors.Conflict, errors.MsgHoldClosed)
+	}
+
+	copyID := hold.CopyID
+	if err := tx.Model(hold).Update("status", status).Error; err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	if copyID == nil {
+		return nil, nil
+	}
+
+	var bookCopy models.Copy
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&bookCopy, *copyID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+	if bookCopy.Status != models.CopyOnHold {
+		// Staff have taken the copy out of circulation
+		return nil, nil
+	}
+	return allocateCopy(tx, &bookCopy, readyUntil)
+}
+
+// allocateCopy sets the copy aside for the book's oldest waiting hold, or
+// makes it available if nobody is waiting. The caller must hold the book's
+// queue lock. It returns the hold that became ready, if any.
+func allocateCopy(tx *gorm.DB, bookCopy *models.Copy, readyUntil time.Time) (*models.Hold, error) {
+	var holds []models.Hold
+	result := tx.Where("book_id = ? AND status = ?", bookCopy.BookID, models.HoldWaiting).
+		Order("id ASC").
+		Limit(1).
+		Find(&holds)
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+
+	if len(holds) == 0 {
+		if err := tx.Model(bookCopy).Update("status", models.CopyAvailable).Error; err != nil {
+			return nil, errors.NewDatabaseError(err)
+		}
+		return nil, nil
+	}
+
+	hold := &holds[0]
+	now := time.Now()
+	err := tx.Model(hold).Updates(map[string]interface{}{
+		"status":     models.HoldReady,
+		"copy_id":    bookCopy.ID,
+		"ready_at":   now,
+		"expires_at": readyUntil,
+	}).Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	if err := tx.Model(bookCopy).Update("status", models.CopyOnHold).Error; err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	return hold, nil
+}
+
+// lockReadyHold returns the member's ready hold matching the loan's copy or
+// book, locked for the rest of the transaction, or nil if there is none
+func lockReadyHold(tx *gorm.DB, loan *models.Loan) (*models.Hold, error) {
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("member_id = ? AND status = ?", loan.MemberID, models.HoldReady)
+	if loan.CopyID != 0 {
+		query = query.Where("copy_id = ?", loan.CopyID)
+	} else {
+		query = query.Where("book_id = ?", loan.BookID)
+	}
+
+	var holds []models.Hold
+	if err := query.Order("id ASC").Limit(1).Find(&holds).Error; err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	if len(holds) == 0 {
+		return nil, nil
+	}
+	return &holds[0], nil
+}
+
+// claimQueueTurn refuses to lend an available copy of a book while members
+// are waiting for it ahead of the borrower. A borrower who is next in the
+// queue has their waiting hold fulfilled by the loan.
+func claimQueueTurn(tx *gorm.DB, bookCopy *models.Copy, memberID string) error {
+	var own []models.Hold
+	result := tx.Where("book_id = ? AND member_id = ? AND status = ?", bookCopy.BookID, memberID, models.HoldWaiting).
+		Limit(1).
+		Find(&own)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+
+	ahead := tx.Model(&models.Hold{}).Where("book_id = ? AND status = ?", bookCopy.BookID, models.HoldWaiting)
+	if len(own) > 0 {
+		ahead = ahead.Where("id < ?", own[0].ID)
+	}
+	var waiting int64
+	if err := ahead.Count(&waiting).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if waiting > 0 {
+		return errors.NewLocalizedError(errors.Conflict, errors.MsgHoldQueueWaiting)
+	}
+
+	if len(own) == 0 {
+		return nil
+	}
+	err := tx.Model(&own[0]).Updates(map[string]interface{}{
+		"status":  models.HoldFulfilled,
+		"copy_id": bookCopy.ID,
+	}).Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type LoanRepository interface {
+	// Checkout lends loan.CopyID, or any available copy of loan.BookID when
+	// no copy is given, and fills in the chosen copy. A copy set aside for one
+	// of the member's ready holds is lent first and the hold fulfilled.
+	// Available copies are refused while other members wait ahead of the
+	// borrower in the book's hold queue. It fails if the member already
+	// holds maxPerMember open loans.
+	Checkout(ctx context.Context, loan *models.Loan, maxPerMember int) error
+	GetByID(ctx context.Context, id uint) (*models.Loan, error)
+	List(ctx context.Context, filter models.LoanFilter, limit, offset int) ([]models.Loan, int64, error)
+	// Return closes the loan and passes its copy to the next waiting hold on
+	// the book, which becomes ready until readyUntil and is returned. With no
+	// holds waiting the copy becomes available again.
+	Return(ctx context.Context, id uint, returnedAt, readyUntil time.Time) (*models.Loan, *models.Hold, error)
+	// Renew moves the due date to extend(current due date), computed while
+	// the loan is locked so concurrent renewals each extend it
+	Renew(ctx context.Context, id uint, extend func(dueAt time.Time) time.Time, maxRenewals int) (*models.Loan, error)
+	// MarkOverdue flags active loans that fell due before now and returns them
+	MarkOverdue(ctx context.Context, now time.Time) ([]models.Loan, error)
+}
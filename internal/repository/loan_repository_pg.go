@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoanRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewLoanRepository(db *gorm.DB) LoanRepository {
+	return &LoanRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *LoanRepositoryPG) Checkout(ctx context.Context, loan *models.Loan, maxPerMember int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialize checkouts per member so concurrent requests cannot both
+		// slip under the cap
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "loan-member:"+loan.MemberID).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		var open int64
+		err := tx.Model(&models.Loan{}).
+			Where("member_id = ? AND status <> ?", loan.MemberID, models.LoanReturned).
+			Count(&open).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if open >= int64(maxPerMember) {
+			return errors.NewLocalizedError(errors.Conflict, errors.MsgLoanLimitReached, maxPerMember)
+		}
+
+		bookCopy, err := lockCopyForLoan(tx, loan)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(bookCopy).Update("status", models.CopyOnLoan).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		loan.CopyID, loan.BookID = bookCopy.ID, bookCopy.BookID
+		loan.Status = models.LoanActive
+		if err := tx.Omit(clause.Associations).Create(loan).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	})
+}
+
+// lockCopyForLoan locks the copy held for the member, the requested copy,
+// or the first available copy of the requested book, for the rest of the
+// transaction
+func lockCopyForLoan(tx *gorm.DB, loan *models.Loan) (*models.Copy, error) {
+	var bookCopy models.Copy
+
+	hold, err := lockReadyHold(tx, loan)
+	if err != nil {
+		return nil, err
+	}
+	if hold != nil && hold.CopyID != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&bookCopy, *hold.CopyID).Error; err != nil {
+			return nil, errors.NewDatabaseError(err)
+		}
+		if bookCopy.Status != models.CopyOnHold {
+			return nil, errors.NewLocalizedError(errors.Conflict, errors.MsgCopyNotAvailable)
+		}
+		if err := tx.Model(hold).Update("status", models.HoldFulfilled).Error; err != nil {
+			return nil, errors.NewDatabaseError(err)
+		}
+		return &bookCopy, nil
+	}
+
+	if loan.CopyID != 0 {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&bookCopy, loan.CopyID).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgCopyNotFound)
+			}
+			return nil, errors.NewDatabaseError(err)
+		}
+		if bookCopy.Status != models.CopyAvailable {
+			return nil, errors.NewLocalizedError(errors.Conflict, errors.MsgCopyNotAvailable)
+		}
+		if err := claimQueueTurn(tx, &bookCopy, loan.MemberID); err != nil {
+			return nil, err
+		}
+		return &bookCopy, nil
+	}
+
+	var book models.Book
+	if err := tx.Select("id").First(&book, loan.BookID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	// Skip copies another checkout has locked rather than queueing behind it
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("book_id = ? AND status = ?", loan.BookID, models.CopyAvailable).
+		Order("id ASC").
+		Limit(1).
+		Find(&bookCopy)
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.NewLocalizedError(errors.Conflict, errors.MsgNoCopyAvailable)
+	}
+	if err := claimQueueTurn(tx, &bookCopy, loan.MemberID); err != nil {
+		return nil, err
+	}
+	return &bookCopy, nil
+}
+
+func (r *LoanRepositoryPG) GetByID(ctx context.Context, id uint) (*models.Loan, error) {
+	var loan models.Loan
+	if err := r.db.WithContext(ctx).First(&loan, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgLoanNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+	return &loan, nil
+}
+
+func (r *LoanRepositoryPG) List(ctx context.Context, filter models.LoanFilter, limit, offset int) ([]models.Loan, int64, error) {
+	var loans []models.Loan
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Loan{})
+	if filter.MemberID != "" {
+		query = query.Where("member_id = ?", filter.MemberID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+
+	result := query.
+		Order("checked_out_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&loans)
+	if result.Error != nil {
+		return nil, 0, errors.NewDatabaseError(result.Error)
+	}
+
+	return loans, total, nil
+}
+
+func (r *LoanRepositoryPG) Return(ctx context.Context, id uint, returnedAt, readyUntil time.Time) (*models.Loan, *models.Hold, error) {
+	var loan models.Loan
+	var ready *models.Hold
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockLoan(tx, &loan, id); err != nil {
+			return err
+		}
+		if !loan.Open() {
+			return errors.NewLocalizedError(errors.Conflict, errors.MsgLoanAlreadyReturned)
+		}
+
+		err := tx.Model(&loan).Updates(map[string]interface{}{
+			"status":      models.LoanReturned,
+			"returned_at": returnedAt,
+		}).Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		if err := lockHoldQueue(tx, loan.BookID); err != nil {
+			return err
+		}
+
+		var bookCopy models.Copy
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&bookCopy, loan.CopyID).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		// Leave copies staff have since marked lost or in repair alone
+		if bookCopy.Status != models.CopyOnLoan {
+			return nil
+		}
+
+		ready, err = allocateCopy(tx, &bookCopy, readyUntil)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &loan, ready, nil
+}
+
+func (r *LoanRepositoryPG) Renew(ctx context.Context, id uint, extend func(dueAt time.Time) time.Time, maxRenewals int) (*models.Loan, error) {
+	var loan models.Loan
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockLoan(tx, &loan, id); err != nil {
+			return err
+		}
+
+		switch {
+		case loan.Status == models.LoanReturned:
+			return errors.NewLocalizedError(errors.Conflict, errors.MsgLoanAlreadyReturned)
+		case loan.Status == models.LoanOverdue:
+			return errors.NewLocalizedError(errors.Conflict, errors.MsgLoanOverdue)
+		case loan.Renewals >= maxRenewals:
+			return errors.NewLocalizedError(errors.Conflict, errors.MsgLoanRenewalLimit, maxRenewals)
+		}
+
+		err := tx.Model(&loan).Updates(map[string]interface{}{
+			"due_at":   extend(loan.DueAt),
+			"renewals": loan.Renewals + 1,
+		}).Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+func (r *LoanRepositoryPG) MarkOverdue(ctx context.Context, now time.Time) ([]models.Loan, error) {
+	var loans []models.Loan
+
+	// A single UPDATE ... RETURNING so each loan is reported once even with
+	// several API instances running the scheduler
+	result := r.db.WithContext(ctx).
+		Model(&loans).
+		Clauses(clause.Returning{}).
+		Where("status = ? AND due_at < ?", models.LoanActive, now).
+		Updates(map[string]interface{}{
+			"status":     models.LoanOverdue,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return loans, nil
+}
+
+func lockLoan(tx *gorm.DB, loan *models.Loan, id uint) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(loan, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewLocalizedError(errors.NotFound, errors.MsgLoanNotFound)
+		}
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+)
+
+const backfillBatchSize = 500
+
+// backfillBookAuthors links books that predate the authors table to authors
+// parsed from their free-text Author credit. Books that already have links
+// are skipped, so it is safe to run on every start.
+func backfillBookAuthors(db *gorm.DB) error {
+	var books []models.Book
+
+	result := db.
+		Where("NOT EXISTS (SELECT 1 FROM book_authors WHERE book_authors.book_id = books.id)").
+		FindInBatches(&books, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				for _, book := range books {
+					for position, name := range models.SplitAuthorNames(book.Author) {
+						author, err := findOrCreateAuthor(tx, name)
+						if err != nil {
+							return err
+						}
+
+						link := models.BookAuthor{
+							BookID:   book.ID,
+							AuthorID: author.ID,
+							Role:     models.AuthorRoleAuthor,
+							Position: position,
+						}
+						if err := tx.Omit("Author").Create(&link).Error; err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to backfill book authors: %w", result.Error)
+	}
+	return nil
+}
+
+// backfillWorks gives every edition that predates works a work of its own.
+// Editions with the same title and author as an already migrated edition
+// join that edition's work instead, so existing hardcover and paperback
+// rows end up grouped. Safe to run on every start.
+func backfillWorks(db *gorm.DB) error {
+	var books []models.Book
+
+	result := db.
+		Where("work_id IS NULL").
+		FindInBatches(&books, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				for _, book := range books {
+					var workID uint
+					err := tx.Model(&models.Book{}).
+						Select("work_id").
+						Where("title = ? AND author = ? AND work_id IS NOT NULL", book.Title, book.Author).
+						Limit(1).
+						Scan(&workID).
+						Error
+					if err != nil {
+						return err
+					}
+
+					if workID == 0 {
+						work := models.Work{Title: book.Title, OriginalLanguage: book.Language}
+						if err := tx.Create(&work).Error; err != nil {
+							return err
+						}
+						workID = work.ID
+					}
+
+					if err := tx.Model(&models.Book{}).Where("id = ?", book.ID).UpdateColumn("work_id", workID).Error; err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to backfill works: %w", result.Error)
+	}
+	return nil
+}
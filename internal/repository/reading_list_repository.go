@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type ReadingListRepository interface {
+	// EnsureShelves creates any of the user's built-in shelves that are missing
+	EnsureShelves(ctx context.Context, userID string) error
+	Create(ctx context.Context, list *models.ReadingList) error
+	// GetByID returns the list with its entries in order
+	GetByID(ctx context.Context, id uint) (*models.ReadingList, error)
+	GetByShareToken(ctx context.Context, token string) (*models.ReadingList, error)
+	// ListByUser returns the user's shelves followed by their custom lists,
+	// with entry counts but without entries
+	ListByUser(ctx context.Context, userID string) ([]models.ReadingList, error)
+	// Update changes the list's name, description and visibility
+	Update(ctx context.Context, list *models.ReadingList) error
+	SetShareToken(ctx context.Context, id uint, token *string) error
+	Delete(ctx context.Context, id uint) error
+
+	// AddEntry inserts the entry at its position, or at the end of the list
+	// when the position is 0. Adding a book to a shelf takes it off the
+	// user's other shelves.
+	AddEntry(ctx context.Context, entry *models.ReadingListEntry) error
+	// UpdateEntry changes the entry's notes, dates and position
+	UpdateEntry(ctx context.Context, entry *models.ReadingListEntry) error
+	RemoveEntry(ctx context.Context, listID, entryID uint) error
+}
@@ -0,0 +1,365 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReadingListRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewReadingListRepository(db *gorm.DB) ReadingListRepository {
+	return &ReadingListRepositoryPG{
+		db: db,
+	}
+}
+
+var shelfNames = map[models.ReadingListKind]string{
+	models.ShelfWantToRead: "Want to Read",
+	models.ShelfReading:    "Reading",
+	models.ShelfRead:       "Read",
+}
+
+func (r *ReadingListRepositoryPG) EnsureShelves(ctx context.Context, userID string) error {
+	shelves := make([]models.ReadingList, len(models.Shelves))
+	for i, kind := range models.Shelves {
+		shelves[i] = models.ReadingList{
+			UserID:     userID,
+			Kind:       kind,
+			Name:       shelfNames[kind],
+			Visibility: models.ListPrivate,
+		}
+	}
+
+	err := r.db.WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&shelves).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *ReadingListRepositoryPG) Create(ctx context.Context, list *models.ReadingList) error {
+	if err := r.checkDuplicate(ctx, list); err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(list).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *ReadingListRepositoryPG) GetByID(ctx context.Context, id uint) (*models.ReadingList, error) {
+	return r.get(ctx, "id = ?", id)
+}
+
+func (r *ReadingListRepositoryPG) GetByShareToken(ctx context.Context, token string) (*models.ReadingList, error) {
+	return r.get(ctx, "share_token = ?", token)
+}
+
+func (r *ReadingListRepositoryPG) get(ctx context.Context, query string, args ...interface{}) (*models.ReadingList, error) {
+	var list models.ReadingList
+	result := r.db.WithContext(ctx).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC, id ASC")
+		}).
+		Preload("Entries.Book").
+		Where(query, args...).
+		First(&list)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgListNotFound)
+		}
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	list.EntryCount = len(list.Entries)
+	return &list, nil
+}
+
+func (r *ReadingListRepositoryPG) ListByUser(ctx context.Context, userID string) ([]models.ReadingList, error) {
+	var lists []models.ReadingList
+	result := r.db.WithContext(ctx).
+		Select("reading_lists.*, (SELECT count(*) FROM reading_list_entries WHERE reading_list_entries.list_id = reading_lists.id) AS entry_count").
+		Where("user_id = ?", userID).
+		Order(clause.Expr{
+			SQL:  "CASE kind WHEN ? THEN 0 WHEN ? THEN 1 WHEN ? THEN 2 ELSE 3 END, name ASC",
+			Vars: []interface{}{models.ShelfWantToRead, models.ShelfReading, models.ShelfRead},
+		}).
+		Find(&lists)
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return lists, nil
+}
+
+func (r *ReadingListRepositoryPG) Update(ctx context.Context, list *models.ReadingList) error {
+	if err := r.checkDuplicate(ctx, list); err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(list).
+		Select("name", "description", "visibility").
+		Updates(list)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgListNotFound)
+	}
+	return nil
+}
+
+func (r *ReadingListRepositoryPG) SetShareToken(ctx context.Context, id uint, token *string) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.ReadingList{}).
+		Where("id = ?", id).
+		Update("share_token", token)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgListNotFound)
+	}
+	return nil
+}
+
+func (r *ReadingListRepositoryPG) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.ReadingList{}, id)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgListNotFound)
+	}
+	return nil
+}
+
+func (r *ReadingListRepositoryPG) AddEntry(ctx context.Context, entry *models.ReadingListEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		list, size, err := lockReadingList(tx, entry.ListID)
+		if err != nil {
+			return err
+		}
+
+		var book models.Book
+		if err := tx.Select("id", "title").First(&book, *entry.BookID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+			}
+			return errors.NewDatabaseError(err)
+		}
+		entry.BookTitle = book.Title
+
+		var exists bool
+		err = tx.Model(&models.ReadingListEntry{}).
+			Select("count(*) > 0").
+			Where("list_id = ? AND book_id = ?", entry.ListID, entry.BookID).
+			Find(&exists).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if exists {
+			return errors.NewLocalizedError(errors.AlreadyExists, errors.MsgListEntryExists)
+		}
+
+		if list.Kind.IsShelf() {
+			if err := removeFromOtherShelves(tx, list, book.ID); err != nil {
+				return err
+			}
+		}
+
+		if entry.Position == 0 {
+			entry.Position = size + 1
+		}
+		if entry.Position < 1 || entry.Position > size+1 {
+			return errors.NewFieldValidationError("position", "range", errors.MsgEntryPositionRange, size+1)
+		}
+
+		err = tx.Model(&models.ReadingListEntry{}).
+			Where("list_id = ? AND position >= ?", entry.ListID, entry.Position).
+			UpdateColumn("position", gorm.Expr("position + 1")).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		if err := tx.Omit(clause.Associations).Create(entry).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		if err := tx.Preload("Book").First(entry, entry.ID).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	})
+}
+
+func (r *ReadingListRepositoryPG) UpdateEntry(ctx context.Context, entry *models.ReadingListEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, size, err := lockReadingList(tx, entry.ListID)
+		if err != nil {
+			return err
+		}
+
+		current, err := findEntry(tx, entry.ListID, entry.ID)
+		if err != nil {
+			return err
+		}
+
+		if entry.Position == 0 {
+			entry.Position = current.Position
+		}
+		if entry.Position < 1 || entry.Position > size {
+			return errors.NewFieldValidationError("position", "range", errors.MsgEntryPositionRange, size)
+		}
+
+		// Close the gap at the old position and open one at the new
+		if entry.Position != current.Position {
+			query := tx.Model(&models.ReadingListEntry{}).Where("list_id = ?", entry.ListID)
+			if entry.Position < current.Position {
+				query = query.Where("position >= ? AND position < ?", entry.Position, current.Position).
+					UpdateColumn("position", gorm.Expr("position + 1"))
+			} else {
+				query = query.Where("position > ? AND position <= ?", current.Position, entry.Position).
+					UpdateColumn("position", gorm.Expr("position - 1"))
+			}
+			if query.Error != nil {
+				return errors.NewDatabaseError(query.Error)
+			}
+		}
+
+		err = tx.Model(entry).
+			Select("position", "notes", "started_on", "finished_on").
+			Updates(entry).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		if err := tx.Preload("Book").First(entry, entry.ID).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	})
+}
+
+func (r *ReadingListRepositoryPG) RemoveEntry(ctx context.Context, listID, entryID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, _, err := lockReadingList(tx, listID); err != nil {
+			return err
+		}
+
+		entry, err := findEntry(tx, listID, entryID)
+		if err != nil {
+			return err
+		}
+		return removeEntries(tx, []models.ReadingListEntry{*entry})
+	})
+}
+
+func (r *ReadingListRepositoryPG) checkDuplicate(ctx context.Context, list *models.ReadingList) error {
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&models.ReadingList{}).
+		Select("count(*) > 0").
+		Where("user_id = ? AND lower(name) = lower(?) AND id <> ?", list.UserID, list.Name, list.ID).
+		Find(&exists).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if exists {
+		return errors.NewLocalizedError(errors.AlreadyExists, errors.MsgListExists)
+	}
+	return nil
+}
+
+// lockReadingList locks the list so concurrent changes cannot hand out the
+// same position, and returns it with its number of entries
+func lockReadingList(tx *gorm.DB, id uint) (*models.ReadingList, int, error) {
+	var list models.ReadingList
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&list, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, 0, errors.NewLocalizedError(errors.NotFound, errors.MsgListNotFound)
+		}
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+
+	var size int64
+	if err := tx.Model(&models.ReadingListEntry{}).Where("list_id = ?", id).Count(&size).Error; err != nil {
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+	return &list, int(size), nil
+}
+
+func findEntry(tx *gorm.DB, listID, entryID uint) (*models.ReadingListEntry, error) {
+	var entry models.ReadingListEntry
+	if err := tx.Where("list_id = ?", listID).First(&entry, entryID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgListEntryNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+	return &entry, nil
+}
+
+// removeFromOtherShelves takes the book off the owner's shelves other than
+// list, since a book is only ever on one of them
+func removeFromOtherShelves(tx *gorm.DB, list *models.ReadingList, bookID uint) error {
+	var entries []models.ReadingListEntry
+	err := tx.Joins("JOIN reading_lists ON reading_lists.id = reading_list_entries.list_id").
+		Where("reading_lists.user_id = ? AND reading_lists.kind IN ? AND reading_lists.id <> ?", list.UserID, models.Shelves, list.ID).
+		Where("reading_list_entries.book_id = ?", bookID).
+		Find(&entries).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return removeEntries(tx, entries)
+}
+
+// removeEntries deletes the entries and closes the gaps they leave
+func removeEntries(tx *gorm.DB, entries []models.ReadingListEntry) error {
+	for _, entry := range entries {
+		if err := tx.Delete(&models.ReadingListEntry{}, entry.ID).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+
+		err := tx.Model(&models.ReadingListEntry{}).
+			Where("list_id = ? AND position > ?", entry.ListID, entry.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+	}
+	return nil
+}
+
+// tombstoneListEntries keeps reading list entries for a book that is being
+// deleted: the link is cleared and the time of removal recorded, while the
+// stored title still says what the entry was
+func tombstoneListEntries(tx *gorm.DB, bookID uint) error {
+	err := tx.Model(&models.ReadingListEntry{}).
+		Where("book_id = ?", bookID).
+		Updates(map[string]interface{}{
+			"book_id":         nil,
+			"book_removed_at": gorm.Expr("now()"),
+		}).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+// ReviewRepository keeps each book's rating aggregates in step with its
+// reviews: every change adjusts them in the same transaction.
+type ReviewRepository interface {
+	Create(ctx context.Context, review *models.Review) error
+	// GetByID returns the review if it belongs to the book
+	GetByID(ctx context.Context, bookID, id uint) (*models.Review, error)
+	// ListByBook returns the book's reviews with the given status, newest first
+	ListByBook(ctx context.Context, bookID uint, status models.ReviewStatus, limit, offset int) ([]models.Review, int64, error)
+	// Update changes the review's rating, title, body and status and returns
+	// the status it had before
+	Update(ctx context.Context, review *models.Review) (models.ReviewStatus, error)
+	// SetStatus moderates the review and returns it with its previous status
+	SetStatus(ctx context.Context, bookID, id uint, status models.ReviewStatus) (*models.Review, models.ReviewStatus, error)
+	// Delete removes the review and returns it
+	Delete(ctx context.Context, bookID, id uint) (*models.Review, error)
+}
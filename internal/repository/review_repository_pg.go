@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &ReviewRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *ReviewRepositoryPG) Create(ctx context.Context, review *models.Review) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var book models.Book
+		if err := tx.Select("id").First(&book, review.BookID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+			}
+			return errors.NewDatabaseError(err)
+		}
+
+		// The unique index settles races; this check gives the usual error
+		var exists bool
+		err := tx.Model(&models.Review{}).
+			Select("count(*) > 0").
+			Where("book_id = ? AND user_id = ?", review.BookID, review.UserID).
+			Find(&exists).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if exists {
+			return errors.NewLocalizedError(errors.AlreadyExists, errors.MsgReviewExists)
+		}
+
+		if err := tx.Omit(clause.Associations).Create(review).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return adjustRating(tx, review.BookID, nil, review)
+	})
+}
+
+func (r *ReviewRepositoryPG) GetByID(ctx context.Context, bookID, id uint) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.WithContext(ctx).Where("book_id = ?", bookID).First(&review, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgReviewNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepositoryPG) ListByBook(ctx context.Context, bookID uint, status models.ReviewStatus, limit, offset int) ([]models.Review, int64, error) {
+	var book models.Book
+	if err := r.db.WithContext(ctx).Select("id").First(&book, bookID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, 0, errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+		}
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+
+	var reviews []models.Review
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&models.Review{}).
+		Where("book_id = ? AND status = ?", bookID, status)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+
+	result := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reviews)
+	if result.Error != nil {
+		return nil, 0, errors.NewDatabaseError(result.Error)
+	}
+
+	return reviews, total, nil
+}
+
+func (r *ReviewRepositoryPG) Update(ctx context.Context, review *models.Review) (models.ReviewStatus, error) {
+	var previous models.ReviewStatus
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockReview(tx, review.BookID, review.ID)
+		if err != nil {
+			return err
+		}
+		previous = current.Status
+
+		err = tx.Model(review).
+			Select("rating", "title", "body", "status").
+			Updates(review).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if err := adjustRating(tx, review.BookID, current, review); err != nil {
+			return err
+		}
+
+		if err := tx.First(review, review.ID).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return previous, nil
+}
+
+func (r *ReviewRepositoryPG) SetStatus(ctx context.Context, bookID, id uint, status models.ReviewStatus) (*models.Review, models.ReviewStatus, error) {
+	var review *models.Review
+	var previous models.ReviewStatus
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockReview(tx, bookID, id)
+		if err != nil {
+			return err
+		}
+		previous = current.Status
+
+		updated := *current
+		updated.Status = status
+		if err := tx.Model(&updated).Update("status", status).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if err := adjustRating(tx, bookID, current, &updated); err != nil {
+			return err
+		}
+
+		review = &updated
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return review, previous, nil
+}
+
+func (r *ReviewRepositoryPG) Delete(ctx context.Context, bookID, id uint) (*models.Review, error) {
+	var review *models.Review
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockReview(tx, bookID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(current).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if err := adjustRating(tx, bookID, current, nil); err != nil {
+			return err
+		}
+
+		review = current
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func lockReview(tx *gorm.DB, bookID, id uint) (*models.Review, error) {
+	var review models.Review
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("book_id = ?", bookID).
+		First(&review, id).
+		Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgReviewNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+	return &review, nil
+}
+
+// adjustRating applies the change from before to after to the book's rating
+// aggregates in a single UPDATE, so concurrent reviews cannot lose updates.
+// The book's updated_at is left alone as ratings are not edits to the book.
+func adjustRating(tx *gorm.DB, bookID uint, before, after *models.Review) error {
+	sum, count := models.RatingDelta(before, after)
+	if sum == 0 && count == 0 {
+		return nil
+	}
+
+	err := tx.Model(&models.Book{}).
+		Where("id = ?", bookID).
+		UpdateColumns(map[string]interface{}{
+			"rating_sum":   gorm.Expr("rating_sum + ?", sum),
+			"rating_count": gorm.Expr("rating_count + ?", count),
+			"version":      gorm.Expr("version + 1"),
+			"average_rating": gorm.Expr(
+				"CASE WHEN rating_count + ? > 0 THEN (rating_sum + ?)::float8 / (rating_count + ?) ELSE 0 END",
+				count, sum, count,
+			),
+		}).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
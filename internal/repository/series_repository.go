@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type SeriesRepository interface {
+	Create(ctx context.Context, series *models.Series) error
+	// GetByID returns the series with its entries in reading order
+	GetByID(ctx context.Context, id uint) (*models.Series, error)
+	List(ctx context.Context, limit, offset int) ([]models.Series, int64, error)
+	Update(ctx context.Context, series *models.Series) error
+	Delete(ctx context.Context, id uint) error
+
+	// SetEntry adds a book to a series or moves it to a new position. It
+	// returns the book's previous position, or nil if it was not a member.
+	SetEntry(ctx context.Context, entry *models.SeriesEntry) (*float64, error)
+	RemoveEntry(ctx context.Context, seriesID, bookID uint) error
+
+	// Neighbors returns the book's place in every series it belongs to
+	Neighbors(ctx context.Context, bookID uint) ([]models.SeriesNeighbors, error)
+}
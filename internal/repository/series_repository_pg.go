@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SeriesRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewSeriesRepository(db *gorm.DB) SeriesRepository {
+	return &SeriesRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *SeriesRepositoryPG) Create(ctx context.Context, series *models.Series) error {
+	series.Slug = models.Slugify(series.Name)
+
+	if err := r.checkDuplicate(ctx, series); err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(series).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *SeriesRepositoryPG) GetByID(ctx context.Context, id uint) (*models.Series, error) {
+	var series models.Series
+	result := r.db.WithContext(ctx).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Entries.Book").
+		First(&series, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgSeriesNotFound)
+		}
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return &series, nil
+}
+
+func (r *SeriesRepositoryPG) List(ctx context.Context, limit, offset int) ([]models.Series, int64, error) {
+	var series []models.Series
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.Series{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+
+	result := r.db.WithContext(ctx).
+		Limit(limit).
+		Offset(offset).
+		Order("name ASC").
+		Find(&series)
+
+	if result.Error != nil {
+		return nil, 0, errors.NewDatabaseError(result.Error)
+	}
+	return series, total, nil
+}
+
+func (r *SeriesRepositoryPG) Update(ctx context.Context, series *models.Series) error {
+	series.Slug = models.Slugify(series.Name)
+
+	if err := r.checkDuplicate(ctx, series); err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(series).
+		Select("name", "slug", "description").
+		Updates(series)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgSeriesNotFound)
+	}
+
+	return r.db.WithContext(ctx).Omit(clause.Associations).First(series, series.ID).Error
+}
+
+func (r *SeriesRepositoryPG) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Series{}, id)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgSeriesNotFound)
+	}
+	return nil
+}
+
+func (r *SeriesRepositoryPG) SetEntry(ctx context.Context, entry *models.SeriesEntry) (*float64, error) {
+	var previous *float64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the series so concurrent moves cannot claim the same position
+		var series models.Series
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&series, entry.SeriesID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewLocalizedError(errors.NotFound, errors.MsgSeriesNotFound)
+			}
+			return errors.NewDatabaseError(err)
+		}
+
+		var book models.Book
+		if err := tx.Select("id").First(&book, entry.BookID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+			}
+			return errors.NewDatabaseError(err)
+		}
+
+		var taken bool
+		err := tx.Model(&models.SeriesEntry{}).
+			Select("count(*) > 0").
+			Where("series_id = ? AND position = ? AND book_id <> ?", entry.SeriesID, entry.Position, entry.BookID).
+			Find(&taken).
+			Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if taken {
+			return errors.NewFieldValidationError("position", "unique", errors.MsgSeriesPositionTaken, entry.Position)
+		}
+
+		var current []models.SeriesEntry
+		if err := tx.Where("series_id = ? AND book_id = ?", entry.SeriesID, entry.BookID).Find(&current).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if len(current) > 0 {
+			previous = &current[0].Position
+		}
+
+		err = tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "series_id"}, {Name: "book_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"position"}),
+		}).Create(entry).Error
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+func (r *SeriesRepositoryPG) RemoveEntry(ctx context.Context, seriesID, bookID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("series_id = ? AND book_id = ?", seriesID, bookID).
+		Delete(&models.SeriesEntry{})
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgSeriesEntryNotFound)
+	}
+	return nil
+}
+
+func (r *SeriesRepositoryPG) Neighbors(ctx context.Context, bookID uint) ([]models.SeriesNeighbors, error) {
+	var book models.Book
+	if err := r.db.WithContext(ctx).Select("id").First(&book, bookID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+		}
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	var entries []models.SeriesEntry
+	err := r.db.WithContext(ctx).
+		Preload("Series").
+		Joins("JOIN series ON series.id = series_entries.series_id").
+		Where("series_entries.book_id = ?", bookID).
+		Order("series.name ASC").
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	neighbors := make([]models.SeriesNeighbors, 0, len(entries))
+	for _, entry := range entries {
+		previous, err := r.adjacentEntry(ctx, entry, "position < ?", "position DESC")
+		if err != nil {
+			return nil, err
+		}
+		next, err := r.adjacentEntry(ctx, entry, "position > ?", "position ASC")
+		if err != nil {
+			return nil, err
+		}
+
+		neighbors = append(neighbors, models.SeriesNeighbors{
+			Series:   *entry.Series,
+			Position: entry.Position,
+			Previous: previous,
+			Next:     next,
+		})
+	}
+	return neighbors, nil
+}
+
+func (r *SeriesRepositoryPG) adjacentEntry(ctx context.Context, entry models.SeriesEntry, condition, order string) (*models.SeriesEntry, error) {
+	var adjacent []models.SeriesEntry
+	err := r.db.WithContext(ctx).
+		Preload("Book").
+		Where("series_id = ?", entry.SeriesID).
+		Where(condition, entry.Position).
+		Order(order).
+		Limit(1).
+		Find(&adjacent).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	if len(adjacent) == 0 {
+		return nil, nil
+	}
+	return &adjacent[0], nil
+}
+
+func (r *SeriesRepositoryPG) checkDuplicate(ctx context.Context, series *models.Series) error {
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&models.Series{}).
+		Select("count(*) > 0").
+		Where("slug = ? AND id <> ?", series.Slug, series.ID).
+		Find(&exists).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if exists {
+		return errors.NewLocalizedError(errors.AlreadyExists, errors.MsgSeriesAlreadyExists)
+	}
+	return nil
+}
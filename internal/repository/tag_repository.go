@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *models.Tag) error
+	GetByID(ctx context.Context, id uint) (*models.Tag, error)
+	List(ctx context.Context, limit, offset int) ([]models.Tag, int64, error)
+	Update(ctx context.Context, tag *models.Tag) error
+	Delete(ctx context.Context, id uint) error
+
+	BookIDs(ctx context.Context, tagID uint) ([]uint, error)
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &TagRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *TagRepositoryPG) Create(ctx context.Context, tag *models.Tag) error {
+	tag.Slug = models.Slugify(tag.Name)
+
+	if err := r.checkDuplicate(ctx, tag); err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Create(tag).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *TagRepositoryPG) GetByID(ctx context.Context, id uint) (*models.Tag, error) {
+	var tag models.Tag
+	result := r.db.WithContext(ctx).First(&tag, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgTagNotFound)
+		}
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return &tag, nil
+}
+
+func (r *TagRepositoryPG) List(ctx context.Context, limit, offset int) ([]models.Tag, int64, error) {
+	var tags []models.Tag
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.Tag{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+
+	result := r.db.WithContext(ctx).
+		Limit(limit).
+		Offset(offset).
+		Order("name ASC").
+		Find(&tags)
+
+	if result.Error != nil {
+		return nil, 0, errors.NewDatabaseError(result.Error)
+	}
+	return tags, total, nil
+}
+
+func (r *TagRepositoryPG) Update(ctx context.Context, tag *models.Tag) error {
+	tag.Slug = models.Slugify(tag.Name)
+
+	if err := r.checkDuplicate(ctx, tag); err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(tag).
+		Select("name", "slug").
+		Updates(tag)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgTagNotFound)
+	}
+
+	return r.db.WithContext(ctx).First(tag, tag.ID).Error
+}
+
+func (r *TagRepositoryPG) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Tag{}, id)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgTagNotFound)
+	}
+	return nil
+}
+
+func (r *TagRepositoryPG) BookIDs(ctx context.Context, tagID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Table("book_tags").
+		Where("tag_id = ?", tagID).
+		Pluck("book_id", &ids).
+		Error
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	return ids, nil
+}
+
+func (r *TagRepositoryPG) checkDuplicate(ctx context.Context, tag *models.Tag) error {
+	var exists bool
+	err := r.db.WithContext(ctx).
+		Model(&models.Tag{}).
+		Select("count(*) > 0").
+		Where("slug = ? AND id <> ?", tag.Slug, tag.ID).
+		Find(&exists).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if exists {
+		return errors.NewLocalizedError(errors.AlreadyExists, errors.MsgTagAlreadyExists)
+	}
+	return nil
+}
+
+// findOrCreateTags resolves free-form tag names to tags, creating the ones
+// that do not exist yet. Names with the same slug collapse into one tag.
+func findOrCreateTags(tx *gorm.DB, names []string) ([]models.Tag, error) {
+	slugs := make([]string, 0, len(names))
+	tags := make([]models.Tag, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		slug := models.Slugify(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+		tags = append(tags, models.Tag{Name: name, Slug: slug})
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "slug"}},
+		DoNothing: true,
+	}).Create(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved []models.Tag
+	if err := tx.Where("slug IN ?", slugs).Find(&resolved).Error; err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+type WorkRepository interface {
+	Create(ctx context.Context, work *models.Work) error
+	GetByID(ctx context.Context, id uint) (*models.Work, error)
+	List(ctx context.Context, limit, offset int) ([]models.Work, int64, error)
+	Update(ctx context.Context, work *models.Work) error
+	Delete(ctx context.Context, id uint) error
+
+	// Editions returns the work's editions, oldest publication first
+	Editions(ctx context.Context, workID uint) ([]models.Book, error)
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkRepositoryPG struct {
+	db *gorm.DB
+}
+
+func NewWorkRepository(db *gorm.DB) WorkRepository {
+	return &WorkRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *WorkRepositoryPG) Create(ctx context.Context, work *models.Work) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(work).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+func (r *WorkRepositoryPG) GetByID(ctx context.Context, id uint) (*models.Work, error) {
+	var work models.Work
+	result := r.db.WithContext(ctx).First(&work, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgWorkNotFound)
+		}
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return &work, nil
+}
+
+func (r *WorkRepositoryPG) List(ctx context.Context, limit, offset int) ([]models.Work, int64, error) {
+	var works []models.Work
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.Work{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.NewDatabaseError(err)
+	}
+
+	result := r.db.WithContext(ctx).
+		Limit(limit).
+		Offset(offset).
+		Order("title ASC, id ASC").
+		Find(&works)
+
+	if result.Error != nil {
+		return nil, 0, errors.NewDatabaseError(result.Error)
+	}
+	return works, total, nil
+}
+
+func (r *WorkRepositoryPG) Update(ctx context.Context, work *models.Work) error {
+	result := r.db.WithContext(ctx).
+		Model(work).
+		Select("title", "original_language").
+		Updates(work)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgWorkNotFound)
+	}
+
+	return r.db.WithContext(ctx).First(work, work.ID).Error
+}
+
+func (r *WorkRepositoryPG) Delete(ctx context.Context, id uint) error {
+	var hasEditions bool
+	err := r.db.WithContext(ctx).
+		Model(&models.Book{}).
+		Select("count(*) > 0").
+		Where("work_id = ?", id).
+		Find(&hasEditions).
+		Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if hasEditions {
+		return errors.NewLocalizedError(errors.Conflict, errors.MsgWorkHasEditions)
+	}
+
+	result := r.db.WithContext(ctx).Delete(&models.Work{}, id)
+	if result.Error != nil {
+		return errors.NewDatabaseError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgWorkNotFound)
+	}
+	return nil
+}
+
+func (r *WorkRepositoryPG) Editions(ctx context.Context, workID uint) ([]models.Book, error) {
+	if _, err := r.GetByID(ctx, workID); err != nil {
+		return nil, err
+	}
+
+	var editions []models.Book
+	result := preloadAssociations(r.db.WithContext(ctx)).
+		Where("work_id = ?", workID).
+		Order("published_on ASC NULLS LAST, year ASC, id ASC").
+		Find(&editions)
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError(result.Error)
+	}
+	return editions, nil
+}
+
+// resolveWork attaches the edition to its work, creating a work named after
+// the edition when none is given.
+func resolveWork(tx *gorm.DB, book *models.Book) error {
+	if book.WorkID == nil {
+		work := models.Work{Title: book.Title, OriginalLanguage: book.Language}
+		if err := tx.Omit(clause.Associations).Create(&work).Error; err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		book.WorkID = &work.ID
+		return nil
+	}
+
+	var exists bool
+	if err := tx.Model(&models.Work{}).Select("count(*) > 0").Where("id = ?", *book.WorkID).Find(&exists).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if !exists {
+		return errors.NewLocalizedError(errors.NotFound, errors.MsgWorkNotFound)
+	}
+	return nil
+}
+
+// resolvePublisher links the edition to the publisher named on it, creating
+// the publisher on first use.
+func resolvePublisher(tx *gorm.DB, book *models.Book) error {
+	book.PublisherID = nil
+	if book.Publisher == nil {
+		return nil
+	}
+
+	slug := models.Slugify(book.Publisher.Name)
+	if slug == "" {
+		book.Publisher = nil
+		return nil
+	}
+
+	publisher := models.Publisher{Name: book.Publisher.Name, Slug: slug}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "slug"}},
+		DoNothing: true,
+	}).Create(&publisher).Error
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	if err := tx.Where("slug = ?", slug).First(&publisher).Error; err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	book.PublisherID = &publisher.ID
+	book.Publisher = &publisher
+	return nil
+}
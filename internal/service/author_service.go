@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+type AuthorService interface {
+	CreateAuthor(ctx context.Context, author *models.Author) error
+	GetAuthor(ctx context.Context, id uint) (*models.Author, error)
+	ListAuthors(ctx context.Context, page, pageSize int) ([]models.Author, int64, error)
+	UpdateAuthor(ctx context.Context, id uint, author *models.Author) error
+	DeleteAuthor(ctx context.Context, id uint) error
+	ListAuthorBooks(ctx context.Context, id uint, page, pageSize int) ([]models.Book, int64, error)
+}
+
+type authorService struct {
+	repo   repository.AuthorRepository
+	copies repository.CopyRepository
+	cache  cache.Cache
+}
+
+func NewAuthorService(repo repository.AuthorRepository, copies repository.CopyRepository, cache cache.Cache) AuthorService {
+	return &authorService{
+		repo:   repo,
+		copies: copies,
+		cache:  cache,
+	}
+}
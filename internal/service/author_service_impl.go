@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *authorService) CreateAuthor(ctx context.Context, author *models.Author) error {
+	if err := validateAuthor(author); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, author)
+}
+
+func (s *authorService) GetAuthor(ctx context.Context, id uint) (*models.Author, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidAuthorID)
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *authorService) ListAuthors(ctx context.Context, page, pageSize int) ([]models.Author, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	return s.repo.List(ctx, pageSize, (page-1)*pageSize)
+}
+
+func (s *authorService) UpdateAuthor(ctx context.Context, id uint, author *models.Author) error {
+	author.ID = id
+	if err := validateAuthor(author); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, author); err != nil {
+		return err
+	}
+
+	// Cached books embed the author's name
+	s.invalidateAuthorBooks(ctx, id)
+
+	return nil
+}
+
+func (s *authorService) DeleteAuthor(ctx context.Context, id uint) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *authorService) ListAuthorBooks(ctx context.Context, id uint, page, pageSize int) ([]models.Book, int64, error) {
+	if _, err := s.GetAuthor(ctx, id); err != nil {
+		return nil, 0, err
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	books, total, err := s.repo.ListBooks(ctx, id, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := fillAvailability(ctx, s.cache, s.copies, bookPointers(books)...); err != nil {
+		return nil, 0, err
+	}
+	return books, total, nil
+}
+
+func (s *authorService) invalidateAuthorBooks(ctx context.Context, id uint) {
+	bookIDs, err := s.repo.BookIDs(ctx, id)
+	if err != nil {
+		log.Printf("Failed to load books of author %d: %v\n", id, err)
+		return
+	}
+
+	invalidateBooks(ctx, s.cache, bookIDs)
+}
+
+func validateAuthor(author *models.Author) error {
+	if author == nil || models.NormalizeAuthorName(author.Name) == "" {
+		return errors.NewFieldValidationError("name", "required", errors.MsgAuthorNameRequired)
+	}
+	return nil
+}
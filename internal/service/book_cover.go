@@ -0,0 +1,178 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"log"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	coverJPEGQuality = 85
+	// maxCoverPixels rejects images that would take too much memory to decode
+	maxCoverPixels = 40_000_000
+)
+
+// coverExtensions maps the accepted upload types to file extensions
+var coverExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+func (s *bookService) SetBookCover(ctx context.Context, id uint, data []byte) (*models.Book, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+	if len(data) == 0 {
+		return nil, errors.NewFieldValidationError("cover", "required", errors.MsgCoverRequired)
+	}
+	if len(data) > s.covers.MaxSize {
+		return nil, errors.NewLocalizedError(errors.TooLarge, errors.MsgCoverTooLarge, s.covers.MaxSize)
+	}
+
+	// Trust the content, not the client's Content-Type
+	contentType := http.DetectContentType(data)
+	ext, ok := coverExtensions[contentType]
+	if !ok {
+		return nil, errors.NewLocalizedError(errors.Unsupported, errors.MsgCoverUnsupportedType, contentType)
+	}
+
+	img, err := decodeCover(data)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every upload gets a fresh directory so cached URLs never serve a stale image
+	dir := fmt.Sprintf("%s%d", coverPrefix(id), time.Now().UnixNano())
+	originalKey := dir + "/" + models.CoverOriginal + "." + ext
+
+	if err := s.storeCover(ctx, originalKey, contentType, data, img); err != nil {
+		s.deleteBlobs(ctx, dir+"/")
+		return nil, errors.NewInternalError(err)
+	}
+
+	if err := s.repo.SetCover(ctx, id, &originalKey); err != nil {
+		s.deleteBlobs(ctx, dir+"/")
+		return nil, err
+	}
+
+	// Reload the book for the version SetCover gave it and write it through,
+	// as UpdateBook does, so a reader that loaded it before the change cannot
+	// cache the old cover after it
+	oldCoverKey := book.CoverKey
+	start := time.Now()
+	book, err = s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	loadTime := time.Since(start)
+	if err := fillAvailability(ctx, s.cache, s.copies, book); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.SetBook(ctx, book, loadTime); err != nil {
+		log.Printf("Failed to cache book: %v\n", err)
+		if err := s.cache.DeleteBook(ctx, id); err != nil {
+			log.Printf("Failed to invalidate book cache: %v\n", err)
+		}
+	}
+
+	// A new cover does not move the book between pages, so only the pages
+	// showing it are dropped
+	if err := s.cache.InvalidateBookPages(ctx, id); err != nil {
+		log.Printf("Failed to invalidate books list cache: %v\n", err)
+	}
+	if err := s.eventService.PublishBookUpdated(ctx, book); err != nil {
+		log.Printf("Failed to publish book updated event: %v\n", err)
+	}
+
+	// The old images go only once nothing cached points at them any more
+	if oldCoverKey != nil {
+		s.deleteBlobs(ctx, path.Dir(*oldCoverKey)+"/")
+	}
+
+	return book, nil
+}
+
+func (s *bookService) storeCover(ctx context.Context, originalKey, contentType string, data []byte, img image.Image) error {
+	if err := s.blobs.Put(ctx, originalKey, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return fmt.Errorf("failed to store cover: %w", err)
+	}
+
+	variants := map[string]int{
+		models.CoverMedium:    s.covers.MediumSize,
+		models.CoverThumbnail: s.covers.ThumbnailSize,
+	}
+	for variant, size := range variants {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resizeCover(img, size), &jpeg.Options{Quality: coverJPEGQuality}); err != nil {
+			return fmt.Errorf("failed to encode %s cover: %w", variant, err)
+		}
+
+		key := models.CoverVariantKey(originalKey, variant)
+		if err := s.blobs.Put(ctx, key, &buf, int64(buf.Len()), "image/jpeg"); err != nil {
+			return fmt.Errorf("failed to store %s cover: %w", variant, err)
+		}
+	}
+	return nil
+}
+
+// deleteBlobs removes blobs under prefix, logging failures; leftover blobs
+// only waste space
+func (s *bookService) deleteBlobs(ctx context.Context, prefix string) {
+	if err := s.blobs.DeletePrefix(ctx, prefix); err != nil {
+		log.Printf("Failed to delete blobs under %s: %v\n", prefix, err)
+	}
+}
+
+func decodeCover(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width*cfg.Height > maxCoverPixels {
+		return nil, errors.NewLocalizedError(errors.Unprocessable, errors.MsgCoverInvalidImage)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.NewLocalizedError(errors.Unprocessable, errors.MsgCoverInvalidImage)
+	}
+	return img, nil
+}
+
+// resizeCover scales img so its longest edge is at most size pixels,
+// flattening transparency onto white since JPEG has no alpha channel
+func resizeCover(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if longest := max(width, height); longest > size {
+		width = max(1, width*size/longest)
+		height = max(1, height*size/longest)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
+
+// coverPrefix is the key prefix holding every cover ever uploaded for a book
+func coverPrefix(bookID uint) string {
+	return fmt.Sprintf("covers/%d/", bookID)
+}
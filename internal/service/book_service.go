@@ -4,29 +4,57 @@ import (
 	"context"
 
 	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/config"
 	"github.com/AhmadMuj/books-api-go/internal/events"
 	"github.com/AhmadMuj/books-api-go/internal/models"
 	"github.com/AhmadMuj/books-api-go/internal/repository"
+	"github.com/AhmadMuj/books-api-go/internal/storage"
+	"golang.org/x/sync/singleflight"
 )
 
 type BookService interface {
 	CreateBook(ctx context.Context, book *models.Book) error
 	GetBook(ctx context.Context, id uint) (*models.Book, error)
-	ListBooks(ctx context.Context, page, pageSize int) ([]models.Book, int64, error)
+	GetBookByISBN(ctx context.Context, isbn13 string) (*models.Book, error)
+	ListBooks(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, error)
 	UpdateBook(ctx context.Context, id uint, book *models.Book) error
 	DeleteBook(ctx context.Context, id uint) error
+
+	// GetStats aggregates the catalogue. Missing dates default to the 30 days
+	// up to today.
+	GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, error)
+
+	// SetBookCover stores a JPEG, PNG or WebP cover with resized variants,
+	// replacing the book's previous cover
+	SetBookCover(ctx context.Context, id uint, data []byte) (*models.Book, error)
+
+	// WarmCache preloads the first pages of the book listing and the most
+	// borrowed books into the cache
+	WarmCache(ctx context.Context, pages, books int) (*models.CacheWarmup, error)
 }
 
 type bookService struct {
 	repo         repository.BookRepository
+	copies       repository.CopyRepository
 	cache        cache.Cache
 	eventService events.EventService
+	blobs        storage.BlobStore
+	covers       config.CoverConfig
+
+	// loads coalesces concurrent cache misses for the same book
+	loads           singleflight.Group
+	earlyExpiryBeta float64
 }
 
-func NewBookService(repo repository.BookRepository, cache cache.Cache, eventService events.EventService) BookService {
+func NewBookService(repo repository.BookRepository, copies repository.CopyRepository, cache cache.Cache, eventService events.EventService, blobs storage.BlobStore, covers config.CoverConfig, cacheCfg config.CacheConfig) BookService {
 	return &bookService{
 		repo:         repo,
+		copies:       copies,
 		cache:        cache,
 		eventService: eventService,
+		blobs:        blobs,
+		covers:       covers,
+
+		earlyExpiryBeta: cacheCfg.EarlyExpiryBeta,
 	}
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
+	"github.com/AhmadMuj/books-api-go/internal/cache"
 	"github.com/AhmadMuj/books-api-go/internal/errors"
 	"github.com/AhmadMuj/books-api-go/internal/models"
 )
@@ -15,11 +17,24 @@ func (s *bookService) CreateBook(ctx context.Context, book *models.Book) error {
 		return err
 	}
 
+	start := time.Now()
 	if err := s.repo.Create(ctx, book); err != nil {
 		return err
 	}
+	loadTime := time.Since(start)
+	if err := fillAvailability(ctx, s.cache, s.copies, book); err != nil {
+		return err
+	}
 
-	// Invalidate list cache
+	// Write the new book through, replacing any record of the ID not
+	// existing. A reader that missed it before the insert cannot record it
+	// as missing afterwards, since that never replaces a cached book.
+	if err := s.cache.SetBook(ctx, book, loadTime); err != nil {
+		log.Printf("Failed to cache book: %v\n", err)
+		if err := s.cache.DeleteBook(ctx, book.ID); err != nil {
+			log.Printf("Failed to invalidate book cache: %v\n", err)
+		}
+	}
 	if err := s.cache.InvalidateBooksList(ctx); err != nil {
 		fmt.Printf("Failed to invalidate books list cache: %v\n", err)
 	}
@@ -31,71 +46,248 @@ func (s *bookService) CreateBook(ctx context.Context, book *models.Book) error {
 	return nil
 }
 
+const (
+	// bookLoadLockTTL bounds how long one instance may hold the right to
+	// reload a book before others give up waiting for it
+	bookLoadLockTTL = 5 * time.Second
+	// bookLoadWait is how long a request waits for another instance to
+	// reload a book before querying the database itself
+	bookLoadWait     = time.Second
+	bookLoadPollTime = 50 * time.Millisecond
+)
+
 func (s *bookService) GetBook(ctx context.Context, id uint) (*models.Book, error) {
 	if id == 0 {
-		return nil, errors.NewValidationError("invalid book ID")
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
 	}
 
 	// Try to get from cache first
-	if book, err := s.cache.GetBook(ctx, id); err == nil && book != nil {
-		return book, nil
+	entry, err := s.cache.GetBook(ctx, id)
+	if err != nil {
+		log.Printf("Failed to read book from cache: %v\n", err)
+	}
+	if entry != nil && !entry.ShouldRefresh(time.Now(), s.earlyExpiryBeta) {
+		if entry.Book == nil {
+			return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+		}
+		if err := fillAvailability(ctx, s.cache, s.copies, entry.Book); err != nil {
+			return nil, err
+		}
+		return entry.Book, nil
 	}
 
-	// If not in cache, get from database
+	// Concurrent requests for the same book in this instance share a single
+	// load. The load outlives any one caller's cancellation since the others
+	// still wait on it.
+	loaded, err, _ := s.loads.Do(strconv.FormatUint(uint64(id), 10), func() (interface{}, error) {
+		return s.loadBook(context.WithoutCancel(ctx), id, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Each caller gets its own copy as availability is filled in per request
+	book := *loaded.(*models.Book)
+	if err := fillAvailability(ctx, s.cache, s.copies, &book); err != nil {
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+// loadBook reads a book from the database and caches it. Only one instance
+// at a time reloads a given book: the others keep serving the entry being
+// refreshed if there is one, or wait briefly for the reload to land in the
+// cache.
+func (s *bookService) loadBook(ctx context.Context, id uint, stale *cache.BookEntry) (*models.Book, error) {
+	lockKey := fmt.Sprintf("book:%d", id)
+	token, err := s.cache.TryLock(ctx, lockKey, bookLoadLockTTL)
+	if err != nil {
+		log.Printf("Failed to lock book for reload: %v\n", err)
+	}
+
+	if err == nil && token == "" {
+		if stale != nil && stale.Book != nil {
+			return stale.Book, nil
+		}
+		if entry := s.waitForBook(ctx, id); entry != nil {
+			if entry.Book == nil {
+				return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgBookNotFound)
+			}
+			return entry.Book, nil
+		}
+	}
+	if token != "" {
+		defer func() {
+			if err := s.cache.Unlock(ctx, lockKey, token); err != nil {
+				log.Printf("Failed to unlock book reload: %v\n", err)
+			}
+		}()
+	}
+
+	return s.fetchBook(ctx, id)
+}
+
+// fetchBook reads a book from the database and caches it, or records that
+// it does not exist
+func (s *bookService) fetchBook(ctx context.Context, id uint) (*models.Book, error) {
+	start := time.Now()
 	book, err := s.repo.GetByID(ctx, id)
 	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Type == errors.NotFound {
+			if err := s.cache.SetBookMissing(ctx, id); err != nil {
+				log.Printf("Failed to cache missing book: %v\n", err)
+			}
+		}
 		return nil, err
 	}
 
 	// Cache the book for future requests
-	if book != nil {
-		if err := s.cache.SetBook(ctx, book); err != nil {
-			// Log error but don't fail the request
-			fmt.Printf("Failed to cache book: %v\n", err)
-		}
+	if err := s.cache.SetBook(ctx, book, time.Since(start)); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to cache book: %v\n", err)
 	}
 
 	return book, nil
 }
 
-func (s *bookService) ListBooks(ctx context.Context, page, pageSize int) ([]models.Book, int64, error) {
-	if page < 1 {
-		page = 1
+// waitForBook polls the cache while another instance reloads a book. It
+// returns nil if the book has not been cached within bookLoadWait.
+func (s *bookService) waitForBook(ctx context.Context, id uint) *cache.BookEntry {
+	ticker := time.NewTicker(bookLoadPollTime)
+	defer ticker.Stop()
+	deadline := time.After(bookLoadWait)
+
+	for {
+		select {
+		case <-ticker.C:
+			if entry, err := s.cache.GetBook(ctx, id); err == nil && entry != nil {
+				return entry
+			}
+		case <-deadline:
+			return nil
+		}
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+}
+
+func (s *bookService) GetBookByISBN(ctx context.Context, isbn13 string) (*models.Book, error) {
+	// The cache maps ISBNs to IDs so the book itself is shared with GetBook.
+	// A mapping is only trusted if the book still carries that ISBN.
+	if id, err := s.cache.GetBookIDByISBN(ctx, isbn13); err == nil && id != 0 {
+		if book, err := s.GetBook(ctx, id); err == nil && book.ISBN13 != nil && *book.ISBN13 == isbn13 {
+			return book, nil
+		}
+	}
+
+	start := time.Now()
+	book, err := s.repo.GetByISBN(ctx, isbn13)
+	if err != nil {
+		return nil, err
 	}
 
+	if err := s.cache.SetBook(ctx, book, time.Since(start)); err != nil {
+		log.Printf("Failed to cache book: %v\n", err)
+	}
+	if err := s.cache.SetBookISBN(ctx, isbn13, book.ID); err != nil {
+		log.Printf("Failed to cache book ISBN: %v\n", err)
+	}
+	if err := fillAvailability(ctx, s.cache, s.copies, book); err != nil {
+		return nil, err
+	}
+
+	return book, nil
+}
+
+func (s *bookService) ListBooks(ctx context.Context, filter models.BookFilter, page, pageSize int) (*models.BookList, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
 	// Try to get from cache first
-	if books, total, err := s.cache.GetBooksList(ctx, page, pageSize); err == nil && books != nil {
-		return books, total, nil
+	list, generation, err := s.cache.GetBooksList(ctx, filter, page, pageSize)
+	if err == nil && list != nil {
+		if err := fillAvailability(ctx, s.cache, s.copies, bookPointers(list.Books)...); err != nil {
+			return nil, err
+		}
+		return list, nil
 	}
 
 	// If not in cache, get from database
-	books, total, err := s.repo.List(ctx, pageSize, (page-1)*pageSize)
+	list, err = s.fetchBooksList(ctx, generation, filter, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fillAvailability(ctx, s.cache, s.copies, bookPointers(list.Books)...); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// fetchBooksList reads a page of books from the database and caches it
+// under generation, which must have been looked up before the read
+func (s *bookService) fetchBooksList(ctx context.Context, generation cache.ListGeneration, filter models.BookFilter, page, pageSize int) (*models.BookList, error) {
+	books, total, err := s.repo.List(ctx, filter, pageSize, (page-1)*pageSize)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+
+	facets, err := s.repo.Facets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &models.BookList{
+		Books:  books,
+		Total:  total,
+		Facets: facets,
 	}
 
 	// Cache the results
-	if err := s.cache.SetBooksList(ctx, books, total, page, pageSize); err != nil {
+	if err := s.cache.SetBooksList(ctx, generation, filter, page, pageSize, list); err != nil {
 		fmt.Printf("Failed to cache books list: %v\n", err)
 	}
 
-	return books, total, nil
+	return list, nil
 }
 
 func (s *bookService) UpdateBook(ctx context.Context, id uint, book *models.Book) error {
+	book.ID = id
+	if err := validateBook(book); err != nil {
+		return err
+	}
+
+	// The current book decides how much of the list cache the update affects
+	before, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
 	if err := s.repo.Update(ctx, book); err != nil {
 		return err
 	}
+	loadTime := time.Since(start)
+	if err := fillAvailability(ctx, s.cache, s.copies, book); err != nil {
+		return err
+	}
 
-	// Invalidate both single book and list caches
-	if err := s.cache.DeleteBook(ctx, id); err != nil {
-		fmt.Printf("Failed to invalidate book cache: %v\n", err)
+	// Write the new version through so readers never pay for the reload. Its
+	// version keeps a reader that loaded the old book from overwriting it.
+	if err := s.cache.SetBook(ctx, book, loadTime); err != nil {
+		log.Printf("Failed to cache book: %v\n", err)
+		if err := s.cache.DeleteBook(ctx, id); err != nil {
+			log.Printf("Failed to invalidate book cache: %v\n", err)
+		}
 	}
-	if err := s.cache.InvalidateBooksList(ctx); err != nil {
-		fmt.Printf("Failed to invalidate books list cache: %v\n", err)
+
+	// Only pages showing the book change unless it can move between pages
+	if models.AffectsListings(before, book) {
+		err = s.cache.InvalidateBooksList(ctx)
+	} else {
+		err = s.cache.InvalidateBookPages(ctx, id)
+	}
+	if err != nil {
+		log.Printf("Failed to invalidate books list cache: %v\n", err)
 	}
 	if err := s.eventService.PublishBookUpdated(ctx, book); err != nil {
 		log.Printf("Failed to publish book created event: %v\n", err)
@@ -120,26 +312,157 @@ func (s *bookService) DeleteBook(ctx context.Context, id uint) error {
 		log.Printf("Failed to publish book created event: %v\n", err)
 	}
 
+	if err := s.cache.DeleteAvailableCopies(ctx, id); err != nil {
+		log.Printf("Failed to invalidate available copies cache: %v\n", err)
+	}
+	s.deleteBlobs(ctx, coverPrefix(id))
+
 	return nil
 }
 
+const (
+	defaultStatsDays  = 30
+	maxStatsDays      = 366
+	defaultTopAuthors = 10
+	maxTopAuthors     = 50
+)
+
+func (s *bookService) GetStats(ctx context.Context, filter models.StatsFilter) (*models.CatalogueStats, error) {
+	filter, err := normalizeStatsFilter(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to get from cache first
+	stats, generation, err := s.cache.GetStats(ctx, filter)
+	if err == nil && stats != nil {
+		return stats, nil
+	}
+
+	stats, err = s.repo.Stats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.SetStats(ctx, generation, filter, stats); err != nil {
+		log.Printf("Failed to cache stats: %v\n", err)
+	}
+
+	return stats, nil
+}
+
+// normalizeStatsFilter truncates the range to whole UTC days, fills in the
+// defaults and checks the range is usable
+func normalizeStatsFilter(filter models.StatsFilter, now time.Time) (models.StatsFilter, error) {
+	if filter.To.IsZero() {
+		filter.To = now
+	}
+	filter.To = startOfDay(filter.To)
+	if filter.From.IsZero() {
+		filter.From = filter.To.AddDate(0, 0, 1-defaultStatsDays)
+	}
+	filter.From = startOfDay(filter.From)
+
+	if filter.From.After(filter.To) {
+		return filter, errors.NewFieldValidationError("from", "ltefield", errors.MsgStatsRangeOrder)
+	}
+	if filter.Days() > maxStatsDays {
+		return filter, errors.NewFieldValidationError("from", "range", errors.MsgStatsRangeTooLong, maxStatsDays)
+	}
+
+	if filter.TopAuthors < 1 || filter.TopAuthors > maxTopAuthors {
+		filter.TopAuthors = defaultTopAuthors
+	}
+	return filter, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// WarmCache reloads the first pages of the unfiltered book listing, at the
+// default page size, and the most borrowed books into the cache. It stops
+// early at the last page of the listing.
+func (s *bookService) WarmCache(ctx context.Context, pages, books int) (*models.CacheWarmup, error) {
+	start := time.Now()
+	warmup := &models.CacheWarmup{}
+
+	_, pageSize := normalizePage(1, 0)
+	for page := 1; page <= pages; page++ {
+		// The lookup only provides the generation; cached pages are
+		// reloaded all the same
+		_, generation, _ := s.cache.GetBooksList(ctx, models.BookFilter{}, page, pageSize)
+		list, err := s.fetchBooksList(ctx, generation, models.BookFilter{}, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		warmup.Pages++
+		if int64(page*pageSize) >= list.Total {
+			break
+		}
+	}
+
+	if books > 0 {
+		ids, err := s.repo.MostBorrowed(ctx, books)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if _, err := s.fetchBook(ctx, id); err != nil {
+				if appErr, ok := err.(*errors.AppError); ok && appErr.Type == errors.NotFound {
+					continue
+				}
+				return nil, err
+			}
+			warmup.Books++
+		}
+	}
+
+	warmup.Duration = time.Since(start)
+	return warmup, nil
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	return page, pageSize
+}
+
 func validateBook(book *models.Book) error {
 	if book == nil {
-		return errors.NewValidationError("book cannot be nil")
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgBookRequired)
 	}
 	if book.Title == "" {
-		return errors.NewValidationError("book title is required")
+		return errors.NewFieldValidationError("title", "required", errors.MsgBookTitleRequired)
 	}
 	if book.Author == "" {
-		return errors.NewValidationError("book author is required")
+		return errors.NewFieldValidationError("author", "required", errors.MsgBookAuthorRequired)
 	}
 
 	currentYear := time.Now().Year()
 	if book.Year < 1500 || book.Year > currentYear {
-		return errors.NewValidationError(fmt.Sprintf(
-			"book year must be between 1500 and %d",
-			currentYear,
-		))
+		return errors.NewFieldValidationError("year", "range", errors.MsgBookYearRange, 1500, currentYear)
+	}
+
+	type contribution struct {
+		authorID uint
+		role     models.AuthorRole
+	}
+	seen := make(map[contribution]bool, len(book.Authors))
+	for _, link := range book.Authors {
+		if link.AuthorID == 0 {
+			continue
+		}
+		key := contribution{authorID: link.AuthorID, role: link.Role}
+		if seen[key] {
+			return errors.NewLocalizedError(errors.ValidationErr, errors.MsgDuplicateBookAuthor, link.AuthorID, link.Role)
+		}
+		seen[key] = true
 	}
 	return nil
 }
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+// invalidateBooks drops the cached copies of books that embed a renamed or
+// deleted related record, along with every cached list page.
+func invalidateBooks(ctx context.Context, c cache.Cache, bookIDs []uint) {
+	for _, bookID := range bookIDs {
+		if err := c.DeleteBook(ctx, bookID); err != nil {
+			log.Printf("Failed to invalidate book cache: %v\n", err)
+		}
+	}
+	if err := c.InvalidateBooksList(ctx); err != nil {
+		log.Printf("Failed to invalidate books list cache: %v\n", err)
+	}
+}
+
+// fillAvailability sets AvailableCopies on books. Counts come from the cache
+// where possible; the rest are counted in one query and cached.
+func fillAvailability(ctx context.Context, c cache.Cache, copies repository.CopyRepository, books ...*models.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	bookIDs := make([]uint, len(books))
+	for i, book := range books {
+		bookIDs[i] = book.ID
+	}
+
+	counts, err := c.GetAvailableCopies(ctx, bookIDs)
+	if err != nil {
+		log.Printf("Failed to read available copies from cache: %v\n", err)
+		counts = map[uint]int{}
+	}
+
+	var missing []uint
+	for _, id := range bookIDs {
+		if _, ok := counts[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		counted, err := copies.CountAvailable(ctx, missing)
+		if err != nil {
+			return err
+		}
+		if err := c.SetAvailableCopies(ctx, counted); err != nil {
+			log.Printf("Failed to cache available copies: %v\n", err)
+		}
+		for id, count := range counted {
+			counts[id] = count
+		}
+	}
+
+	for _, book := range books {
+		book.AvailableCopies = counts[book.ID]
+	}
+	return nil
+}
+
+func bookPointers(books []models.Book) []*models.Book {
+	pointers := make([]*models.Book, len(books))
+	for i := range books {
+		pointers[i] = &books[i]
+	}
+	return pointers
+}
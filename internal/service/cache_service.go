@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+)
+
+// CacheService backs the admin endpoints for observing and managing the
+// cache
+type CacheService interface {
+	// Stats returns the calls, hits, misses, errors and latencies recorded
+	// for each cache operation since startup
+	Stats() cache.Stats
+	// InspectBook returns the cached entry for a book with how long it has
+	// left to live
+	InspectBook(ctx context.Context, id uint) (*cache.BookEntry, time.Duration, error)
+	// EvictKey deletes a single cache entry by its full key
+	EvictKey(ctx context.Context, key string) error
+}
+
+type cacheService struct {
+	cache *cache.InstrumentedCache
+}
+
+func NewCacheService(cache *cache.InstrumentedCache) CacheService {
+	return &cacheService{
+		cache: cache,
+	}
+}
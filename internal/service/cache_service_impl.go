@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+)
+
+func (s *cacheService) Stats() cache.Stats {
+	return s.cache.Stats()
+}
+
+func (s *cacheService) InspectBook(ctx context.Context, id uint) (*cache.BookEntry, time.Duration, error) {
+	if id == 0 {
+		return nil, 0, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+
+	entry, ttl, err := s.cache.InspectBook(ctx, id)
+	if err != nil {
+		return nil, 0, errors.NewInternalError(err)
+	}
+	if entry == nil {
+		return nil, 0, errors.NewLocalizedError(errors.NotFound, errors.MsgCacheEntryNotFound, id)
+	}
+	return entry, ttl, nil
+}
+
+func (s *cacheService) EvictKey(ctx context.Context, key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return errors.NewFieldValidationError("key", "required", errors.MsgCacheKeyRequired)
+	}
+
+	if err := s.cache.DeleteKey(ctx, key); err != nil {
+		return errors.NewInternalError(err)
+	}
+	return nil
+}
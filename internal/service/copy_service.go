@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/events"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+type CopyService interface {
+	CreateCopy(ctx context.Context, bookCopy *models.Copy) error
+	GetCopy(ctx context.Context, bookID, id uint) (*models.Copy, error)
+	ListCopies(ctx context.Context, bookID uint) ([]models.Copy, error)
+	UpdateCopy(ctx context.Context, bookID, id uint, bookCopy *models.Copy) error
+	DeleteCopy(ctx context.Context, bookID, id uint) error
+}
+
+type copyService struct {
+	repo         repository.CopyRepository
+	cache        cache.Cache
+	eventService events.EventService
+	holdPolicy   config.HoldConfig
+}
+
+func NewCopyService(repo repository.CopyRepository, cache cache.Cache, eventService events.EventService, holdPolicy config.HoldConfig) CopyService {
+	return &copyService{
+		repo:         repo,
+		cache:        cache,
+		eventService: eventService,
+		holdPolicy:   holdPolicy,
+	}
+}
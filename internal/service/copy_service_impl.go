@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *copyService) CreateCopy(ctx context.Context, bookCopy *models.Copy) error {
+	if err := validateCopy(bookCopy); err != nil {
+		return err
+	}
+	switch bookCopy.Status {
+	case models.CopyOnLoan:
+		return errors.NewFieldValidationError("status", "loan", errors.MsgCopyLoanStatus)
+	case models.CopyOnHold:
+		return errors.NewFieldValidationError("status", "hold", errors.MsgCopyHoldStatus)
+	}
+
+	ready, err := s.repo.Create(ctx, bookCopy, time.Now().Add(s.holdPolicy.PickupPeriod))
+	if err != nil {
+		return err
+	}
+
+	s.invalidateAvailability(ctx, bookCopy.BookID)
+	if err := s.eventService.PublishCopyCreated(ctx, bookCopy); err != nil {
+		log.Printf("Failed to publish copy created event: %v\n", err)
+	}
+	s.publishHoldReady(ctx, ready)
+
+	return nil
+}
+
+func (s *copyService) GetCopy(ctx context.Context, bookID, id uint) (*models.Copy, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidCopyID)
+	}
+
+	return s.repo.GetByID(ctx, bookID, id)
+}
+
+func (s *copyService) ListCopies(ctx context.Context, bookID uint) ([]models.Copy, error) {
+	if bookID == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+
+	return s.repo.ListByBook(ctx, bookID)
+}
+
+func (s *copyService) UpdateCopy(ctx context.Context, bookID, id uint, bookCopy *models.Copy) error {
+	bookCopy.ID, bookCopy.BookID = id, bookID
+	if err := validateCopy(bookCopy); err != nil {
+		return err
+	}
+
+	previous, ready, err := s.repo.Update(ctx, bookCopy, time.Now().Add(s.holdPolicy.PickupPeriod))
+	if err != nil {
+		return err
+	}
+
+	if previous != bookCopy.Status {
+		s.invalidateAvailability(ctx, bookID)
+	}
+	if err := s.eventService.PublishCopyUpdated(ctx, bookCopy, previous); err != nil {
+		log.Printf("Failed to publish copy updated event: %v\n", err)
+	}
+	s.publishHoldReady(ctx, ready)
+
+	return nil
+}
+
+func (s *copyService) DeleteCopy(ctx context.Context, bookID, id uint) error {
+	if id == 0 {
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidCopyID)
+	}
+
+	if err := s.repo.Delete(ctx, bookID, id); err != nil {
+		return err
+	}
+
+	s.invalidateAvailability(ctx, bookID)
+	if err := s.eventService.PublishCopyDeleted(ctx, bookID, id); err != nil {
+		log.Printf("Failed to publish copy deleted event: %v\n", err)
+	}
+
+	return nil
+}
+
+// publishHoldReady announces a hold that a new or returned-to-shelf copy was
+// set aside for
+func (s *copyService) publishHoldReady(ctx context.Context, hold *models.Hold) {
+	if hold == nil {
+		return
+	}
+	if err := s.eventService.PublishHoldReady(ctx, hold); err != nil {
+		log.Printf("Failed to publish hold ready event: %v\n", err)
+	}
+}
+
+func (s *copyService) invalidateAvailability(ctx context.Context, bookID uint) {
+	if err := s.cache.DeleteAvailableCopies(ctx, bookID); err != nil {
+		log.Printf("Failed to invalidate available copies cache: %v\n", err)
+	}
+}
+
+func validateCopy(bookCopy *models.Copy) error {
+	if bookCopy == nil || strings.TrimSpace(bookCopy.Barcode) == "" {
+		return errors.NewFieldValidationError("barcode", "required", errors.MsgCopyBarcodeRequired)
+	}
+	if bookCopy.BookID == 0 {
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+type GenreService interface {
+	CreateGenre(ctx context.Context, genre *models.Genre) error
+	GetGenre(ctx context.Context, id uint) (*models.Genre, error)
+	ListGenres(ctx context.Context) ([]models.Genre, error)
+	UpdateGenre(ctx context.Context, id uint, genre *models.Genre) error
+	DeleteGenre(ctx context.Context, id uint) error
+}
+
+type genreService struct {
+	repo  repository.GenreRepository
+	cache cache.Cache
+}
+
+func NewGenreService(repo repository.GenreRepository, cache cache.Cache) GenreService {
+	return &genreService{
+		repo:  repo,
+		cache: cache,
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *genreService) CreateGenre(ctx context.Context, genre *models.Genre) error {
+	if err := validateGenre(genre); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, genre)
+}
+
+func (s *genreService) GetGenre(ctx context.Context, id uint) (*models.Genre, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidGenreID)
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *genreService) ListGenres(ctx context.Context) ([]models.Genre, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *genreService) UpdateGenre(ctx context.Context, id uint, genre *models.Genre) error {
+	genre.ID = id
+	if err := validateGenre(genre); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, genre); err != nil {
+		return err
+	}
+
+	s.invalidateGenreBooks(ctx, id)
+	return nil
+}
+
+func (s *genreService) DeleteGenre(ctx context.Context, id uint) error {
+	// Collect the books first; their links are gone once the genre is deleted
+	bookIDs, err := s.repo.BookIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	invalidateBooks(ctx, s.cache, bookIDs)
+	return nil
+}
+
+func (s *genreService) invalidateGenreBooks(ctx context.Context, id uint) {
+	bookIDs, err := s.repo.BookIDs(ctx, id)
+	if err != nil {
+		log.Printf("Failed to load books of genre %d: %v\n", id, err)
+		return
+	}
+
+	invalidateBooks(ctx, s.cache, bookIDs)
+}
+
+func validateGenre(genre *models.Genre) error {
+	if genre == nil || models.Slugify(genre.Name) == "" {
+		return errors.NewFieldValidationError("name", "required", errors.MsgGenreNameRequired)
+	}
+	if genre.ParentID != nil && *genre.ParentID == genre.ID && genre.ID != 0 {
+		return errors.NewFieldValidationError("parent_id", "cycle", errors.MsgGenreParentCycle)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+// healthCheckTimeout bounds each component check
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck reports whether a component the API depends on is up.
+// Critical components are those the API cannot serve requests without.
+type HealthCheck struct {
+	Name     string
+	Critical bool
+	Check    func(ctx context.Context) error
+}
+
+// HealthService backs the health endpoint
+type HealthService interface {
+	// Check runs every component check and summarizes the results
+	Check(ctx context.Context) *models.Health
+}
+
+type healthService struct {
+	checks []HealthCheck
+}
+
+func NewHealthService(checks ...HealthCheck) HealthService {
+	return &healthService{
+		checks: checks,
+	}
+}
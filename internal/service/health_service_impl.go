@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *healthService) Check(ctx context.Context) *models.Health {
+	health := &models.Health{
+		Status:     models.HealthOK,
+		Components: make([]models.ComponentHealth, len(s.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func(i int, check HealthCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			health.Components[i] = models.ComponentHealth{
+				Name:     check.Name,
+				Critical: check.Critical,
+				Err:      check.Check(checkCtx),
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, component := range health.Components {
+		if component.Err == nil {
+			continue
+		}
+		if component.Critical {
+			health.Status = models.HealthDown
+			break
+		}
+		health.Status = models.HealthDegraded
+	}
+	return health
+}
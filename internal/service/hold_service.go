@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/events"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+type HoldService interface {
+	// PlaceHold queues the member for the next copy of the book and returns
+	// their position in the queue
+	PlaceHold(ctx context.Context, hold *models.Hold) (int, error)
+	// GetHold returns the hold with its position in the queue, 0 once it has
+	// left the queue
+	GetHold(ctx context.Context, id uint) (*models.Hold, int, error)
+	ListBookHolds(ctx context.Context, bookID uint) ([]models.Hold, error)
+	CancelHold(ctx context.Context, id uint) (*models.Hold, error)
+	// ExpireHolds closes ready holds that were not collected in time, passing
+	// their copies on, and returns how many expired
+	ExpireHolds(ctx context.Context) (int, error)
+}
+
+type holdService struct {
+	repo         repository.HoldRepository
+	cache        cache.Cache
+	eventService events.EventService
+	policy       config.HoldConfig
+}
+
+func NewHoldService(repo repository.HoldRepository, cache cache.Cache, eventService events.EventService, policy config.HoldConfig) HoldService {
+	return &holdService{
+		repo:         repo,
+		cache:        cache,
+		eventService: eventService,
+		policy:       policy,
+	}
+}
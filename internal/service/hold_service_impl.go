@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *holdService) PlaceHold(ctx context.Context, hold *models.Hold) (int, error) {
+	hold.MemberID = strings.TrimSpace(hold.MemberID)
+	if hold.MemberID == "" {
+		return 0, errors.NewFieldValidationError("member_id", "required", errors.MsgMemberIDRequired)
+	}
+	if hold.BookID == 0 {
+		return 0, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+
+	if err := s.repo.Place(ctx, hold); err != nil {
+		return 0, err
+	}
+
+	if err := s.eventService.PublishHoldPlaced(ctx, hold); err != nil {
+		log.Printf("Failed to publish hold placed event: %v\n", err)
+	}
+
+	return s.repo.Position(ctx, hold)
+}
+
+func (s *holdService) GetHold(ctx context.Context, id uint) (*models.Hold, int, error) {
+	if id == 0 {
+		return nil, 0, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidHoldID)
+	}
+
+	hold, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	position, err := s.repo.Position(ctx, hold)
+	if err != nil {
+		return nil, 0, err
+	}
+	return hold, position, nil
+}
+
+func (s *holdService) ListBookHolds(ctx context.Context, bookID uint) ([]models.Hold, error) {
+	if bookID == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+
+	return s.repo.ListQueue(ctx, bookID)
+}
+
+func (s *holdService) CancelHold(ctx context.Context, id uint) (*models.Hold, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidHoldID)
+	}
+
+	hold, next, err := s.repo.Cancel(ctx, id, time.Now().Add(s.policy.PickupPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventService.PublishHoldCancelled(ctx, hold); err != nil {
+		log.Printf("Failed to publish hold cancelled event: %v\n", err)
+	}
+	s.handOver(ctx, hold, next)
+
+	return hold, nil
+}
+
+func (s *holdService) ExpireHolds(ctx context.Context) (int, error) {
+	now := time.Now()
+	expired, ready, err := s.repo.Expire(ctx, now, now.Add(s.policy.PickupPeriod))
+
+	for i := range expired {
+		if err := s.eventService.PublishHoldExpired(ctx, &expired[i]); err != nil {
+			log.Printf("Failed to publish hold expired event: %v\n", err)
+		}
+		s.invalidateAvailability(ctx, expired[i].BookID)
+	}
+	for i := range ready {
+		if err := s.eventService.PublishHoldReady(ctx, &ready[i]); err != nil {
+			log.Printf("Failed to publish hold ready event: %v\n", err)
+		}
+	}
+
+	return len(expired), err
+}
+
+// handOver reports the hold that received a closed hold's copy. If nobody
+// was waiting the copy became available instead.
+func (s *holdService) handOver(ctx context.Context, closed, next *models.Hold) {
+	if next == nil {
+		if closed.CopyID != nil {
+			s.invalidateAvailability(ctx, closed.BookID)
+		}
+		return
+	}
+
+	if err := s.eventService.PublishHoldReady(ctx, next); err != nil {
+		log.Printf("Failed to publish hold ready event: %v\n", err)
+	}
+}
+
+func (s *holdService) invalidateAvailability(ctx context.Context, bookID uint) {
+	if err := s.cache.DeleteAvailableCopies(ctx, bookID); err != nil {
+		log.Printf("Failed to invalidate available copies cache: %v\n", err)
+	}
+}
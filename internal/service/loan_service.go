@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/events"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+type LoanService interface {
+	// Checkout lends loan.CopyID, or any available copy of loan.BookID
+	Checkout(ctx context.Context, loan *models.Loan) error
+	GetLoan(ctx context.Context, id uint) (*models.Loan, error)
+	ListLoans(ctx context.Context, filter models.LoanFilter, page, pageSize int) ([]models.Loan, int64, error)
+	// ReturnLoan closes the loan. If members are waiting for the book, the
+	// copy is set aside for the first of them and HOLD_READY published.
+	ReturnLoan(ctx context.Context, id uint) (*models.Loan, error)
+	RenewLoan(ctx context.Context, id uint) (*models.Loan, error)
+	// MarkOverdueLoans flags loans past their due date and publishes a
+	// LOAN_OVERDUE event for each, returning how many were flagged
+	MarkOverdueLoans(ctx context.Context) (int, error)
+}
+
+type loanService struct {
+	repo         repository.LoanRepository
+	cache        cache.Cache
+	eventService events.EventService
+	policy       config.LoanConfig
+	holdPolicy   config.HoldConfig
+}
+
+func NewLoanService(repo repository.LoanRepository, cache cache.Cache, eventService events.EventService, policy config.LoanConfig, holdPolicy config.HoldConfig) LoanService {
+	return &loanService{
+		repo:         repo,
+		cache:        cache,
+		eventService: eventService,
+		policy:       policy,
+		holdPolicy:   holdPolicy,
+	}
+}
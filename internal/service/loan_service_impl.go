@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *loanService) Checkout(ctx context.Context, loan *models.Loan) error {
+	loan.MemberID = strings.TrimSpace(loan.MemberID)
+	if loan.MemberID == "" {
+		return errors.NewFieldValidationError("member_id", "required", errors.MsgMemberIDRequired)
+	}
+	if loan.CopyID == 0 && loan.BookID == 0 {
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgLoanTargetRequired)
+	}
+
+	now := time.Now()
+	loan.CheckedOutAt = now
+	loan.DueAt = s.dueDate(now, s.policy.Period)
+
+	if err := s.repo.Checkout(ctx, loan, s.policy.MaxPerMember); err != nil {
+		return err
+	}
+
+	s.invalidateAvailability(ctx, loan.BookID)
+	if err := s.eventService.PublishLoanCreated(ctx, loan); err != nil {
+		log.Printf("Failed to publish loan created event: %v\n", err)
+	}
+
+	return nil
+}
+
+func (s *loanService) GetLoan(ctx context.Context, id uint) (*models.Loan, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidLoanID)
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *loanService) ListLoans(ctx context.Context, filter models.LoanFilter, page, pageSize int) ([]models.Loan, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	return s.repo.List(ctx, filter, pageSize, (page-1)*pageSize)
+}
+
+func (s *loanService) ReturnLoan(ctx context.Context, id uint) (*models.Loan, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidLoanID)
+	}
+
+	now := time.Now()
+	loan, ready, err := s.repo.Return(ctx, id, now, now.Add(s.holdPolicy.PickupPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateAvailability(ctx, loan.BookID)
+	if err := s.eventService.PublishLoanReturned(ctx, loan); err != nil {
+		log.Printf("Failed to publish loan returned event: %v\n", err)
+	}
+	if ready != nil {
+		if err := s.eventService.PublishHoldReady(ctx, ready); err != nil {
+			log.Printf("Failed to publish hold ready event: %v\n", err)
+		}
+	}
+
+	return loan, nil
+}
+
+func (s *loanService) RenewLoan(ctx context.Context, id uint) (*models.Loan, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidLoanID)
+	}
+
+	extend := func(dueAt time.Time) time.Time {
+		return s.dueDate(dueAt, s.policy.RenewalPeriod)
+	}
+	loan, err := s.repo.Renew(ctx, id, extend, s.policy.MaxRenewals)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventService.PublishLoanRenewed(ctx, loan); err != nil {
+		log.Printf("Failed to publish loan renewed event: %v\n", err)
+	}
+
+	return loan, nil
+}
+
+func (s *loanService) MarkOverdueLoans(ctx context.Context) (int, error) {
+	loans, err := s.repo.MarkOverdue(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range loans {
+		if err := s.eventService.PublishLoanOverdue(ctx, &loans[i]); err != nil {
+			log.Printf("Failed to publish loan overdue event: %v\n", err)
+		}
+	}
+
+	return len(loans), nil
+}
+
+// dueDate returns the end of the day, in the policy's time zone, that falls
+// period after from
+func (s *loanService) dueDate(from time.Time, period time.Duration) time.Time {
+	due := from.Add(period).In(s.policy.TimeZone)
+	year, month, day := due.Date()
+	return time.Date(year, month, day, 23, 59, 59, 0, s.policy.TimeZone)
+}
+
+func (s *loanService) invalidateAvailability(ctx context.Context, bookID uint) {
+	if err := s.cache.DeleteAvailableCopies(ctx, bookID); err != nil {
+		log.Printf("Failed to invalidate available copies cache: %v\n", err)
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+// ReadingListService manages users' shelves and lists. Methods taking a
+// userID only act on that user's lists and report other users' lists as not
+// found.
+type ReadingListService interface {
+	// ListMyLists returns the user's shelves, creating them on first use,
+	// followed by their custom lists
+	ListMyLists(ctx context.Context, userID string) ([]models.ReadingList, error)
+	CreateList(ctx context.Context, userID string, list *models.ReadingList) error
+	GetMyList(ctx context.Context, userID string, id uint) (*models.ReadingList, error)
+	UpdateList(ctx context.Context, userID string, id uint, list *models.ReadingList) (*models.ReadingList, error)
+	DeleteList(ctx context.Context, userID string, id uint) error
+	// ShareList issues a new share token for the list, revoking any earlier one
+	ShareList(ctx context.Context, userID string, id uint) (*models.ReadingList, error)
+	UnshareList(ctx context.Context, userID string, id uint) error
+
+	AddEntry(ctx context.Context, userID string, listID uint, entry *models.ReadingListEntry) error
+	UpdateEntry(ctx context.Context, userID string, listID, entryID uint, entry *models.ReadingListEntry) error
+	RemoveEntry(ctx context.Context, userID string, listID, entryID uint) error
+
+	// GetPublicList returns a list anyone may view by ID
+	GetPublicList(ctx context.Context, id uint) (*models.ReadingList, error)
+	// GetSharedList returns the list a share token was issued for, whatever
+	// its visibility
+	GetSharedList(ctx context.Context, token string) (*models.ReadingList, error)
+}
+
+type readingListService struct {
+	repo repository.ReadingListRepository
+}
+
+func NewReadingListService(repo repository.ReadingListRepository) ReadingListService {
+	return &readingListService{
+		repo: repo,
+	}
+}
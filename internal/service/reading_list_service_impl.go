@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *readingListService) ListMyLists(ctx context.Context, userID string) ([]models.ReadingList, error) {
+	if err := s.repo.EnsureShelves(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListByUser(ctx, userID)
+}
+
+func (s *readingListService) CreateList(ctx context.Context, userID string, list *models.ReadingList) error {
+	list.UserID = userID
+	list.Kind = models.CustomList
+	if list.Visibility == "" {
+		list.Visibility = models.ListPrivate
+	}
+	if err := validateReadingList(list); err != nil {
+		return err
+	}
+
+	// Shelves must exist first so a custom list cannot take a shelf's name
+	if err := s.repo.EnsureShelves(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, list)
+}
+
+func (s *readingListService) GetMyList(ctx context.Context, userID string, id uint) (*models.ReadingList, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidListID)
+	}
+
+	list, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.UserID != userID {
+		return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgListNotFound)
+	}
+	return list, nil
+}
+
+func (s *readingListService) UpdateList(ctx context.Context, userID string, id uint, list *models.ReadingList) (*models.ReadingList, error) {
+	current, err := s.GetMyList(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	list.Name = strings.TrimSpace(list.Name)
+	if current.Kind.IsShelf() && list.Name != current.Name {
+		return nil, errors.NewLocalizedError(errors.Conflict, errors.MsgShelfNotEditable)
+	}
+	if err := validateReadingList(list); err != nil {
+		return nil, err
+	}
+
+	current.Name = list.Name
+	current.Description = list.Description
+	current.Visibility = list.Visibility
+	if err := s.repo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+func (s *readingListService) DeleteList(ctx context.Context, userID string, id uint) error {
+	list, err := s.GetMyList(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if list.Kind.IsShelf() {
+		return errors.NewLocalizedError(errors.Conflict, errors.MsgShelfNotEditable)
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *readingListService) ShareList(ctx context.Context, userID string, id uint) (*models.ReadingList, error) {
+	list, err := s.GetMyList(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	if err := s.repo.SetShareToken(ctx, id, &token); err != nil {
+		return nil, err
+	}
+
+	list.ShareToken = &token
+	return list, nil
+}
+
+func (s *readingListService) UnshareList(ctx context.Context, userID string, id uint) error {
+	if _, err := s.GetMyList(ctx, userID, id); err != nil {
+		return err
+	}
+
+	return s.repo.SetShareToken(ctx, id, nil)
+}
+
+func (s *readingListService) AddEntry(ctx context.Context, userID string, listID uint, entry *models.ReadingListEntry) error {
+	if _, err := s.GetMyList(ctx, userID, listID); err != nil {
+		return err
+	}
+	if entry.BookID == nil || *entry.BookID == 0 {
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+	if err := validateListEntry(entry); err != nil {
+		return err
+	}
+
+	entry.ListID = listID
+	return s.repo.AddEntry(ctx, entry)
+}
+
+func (s *readingListService) UpdateEntry(ctx context.Context, userID string, listID, entryID uint, entry *models.ReadingListEntry) error {
+	if _, err := s.GetMyList(ctx, userID, listID); err != nil {
+		return err
+	}
+	if entryID == 0 {
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidEntryID)
+	}
+	if err := validateListEntry(entry); err != nil {
+		return err
+	}
+
+	entry.ID, entry.ListID = entryID, listID
+	return s.repo.UpdateEntry(ctx, entry)
+}
+
+func (s *readingListService) RemoveEntry(ctx context.Context, userID string, listID, entryID uint) error {
+	if _, err := s.GetMyList(ctx, userID, listID); err != nil {
+		return err
+	}
+	if entryID == 0 {
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidEntryID)
+	}
+
+	return s.repo.RemoveEntry(ctx, listID, entryID)
+}
+
+func (s *readingListService) GetPublicList(ctx context.Context, id uint) (*models.ReadingList, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidListID)
+	}
+
+	list, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.Visibility != models.ListPublic {
+		return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgListNotFound)
+	}
+	return list, nil
+}
+
+func (s *readingListService) GetSharedList(ctx context.Context, token string) (*models.ReadingList, error) {
+	if token == "" {
+		return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgListNotFound)
+	}
+
+	return s.repo.GetByShareToken(ctx, token)
+}
+
+// newShareToken returns an unguessable URL-safe token
+func newShareToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func validateReadingList(list *models.ReadingList) error {
+	list.Name = strings.TrimSpace(list.Name)
+	if list.Name == "" {
+		return errors.NewFieldValidationError("name", "required", errors.MsgListNameRequired)
+	}
+	return nil
+}
+
+func validateListEntry(entry *models.ReadingListEntry) error {
+	if entry.StartedOn != nil && entry.FinishedOn != nil && entry.FinishedOn.Before(*entry.StartedOn) {
+		return errors.NewFieldValidationError("finished_on", "gtefield", errors.MsgEntryDatesOrder)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/AhmadMuj/books-api-go/internal/events"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, review *models.Review) error
+	// GetReview finds reviews awaiting or refused moderation only for their
+	// author and admins
+	GetReview(ctx context.Context, caller models.Caller, bookID, id uint) (*models.Review, error)
+	ListReviews(ctx context.Context, bookID uint, status models.ReviewStatus, page, pageSize int) ([]models.Review, int64, error)
+	// UpdateReview and DeleteReview are open to the review's author and to
+	// admins
+	UpdateReview(ctx context.Context, caller models.Caller, bookID, id uint, review *models.Review) error
+	ModerateReview(ctx context.Context, bookID, id uint, status models.ReviewStatus) (*models.Review, error)
+	DeleteReview(ctx context.Context, caller models.Caller, bookID, id uint) error
+}
+
+type reviewService struct {
+	repo         repository.ReviewRepository
+	cache        cache.Cache
+	eventService events.EventService
+	policy       config.ReviewConfig
+}
+
+func NewReviewService(repo repository.ReviewRepository, cache cache.Cache, eventService events.EventService, policy config.ReviewConfig) ReviewService {
+	return &reviewService{
+		repo:         repo,
+		cache:        cache,
+		eventService: eventService,
+		policy:       policy,
+	}
+}
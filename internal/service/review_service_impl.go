@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *reviewService) CreateReview(ctx context.Context, review *models.Review) error {
+	review.UserID = strings.TrimSpace(review.UserID)
+	if review.UserID == "" {
+		return errors.NewFieldValidationError("user_id", "required", errors.MsgUserIDRequired)
+	}
+	if err := validateReview(review); err != nil {
+		return err
+	}
+	review.Status = s.initialStatus()
+
+	if err := s.repo.Create(ctx, review); err != nil {
+		return err
+	}
+
+	s.invalidateRating(ctx, review)
+	if err := s.eventService.PublishReviewCreated(ctx, review); err != nil {
+		log.Printf("Failed to publish review created event: %v\n", err)
+	}
+
+	return nil
+}
+
+func (s *reviewService) GetReview(ctx context.Context, caller models.Caller, bookID, id uint) (*models.Review, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidReviewID)
+	}
+
+	review, err := s.repo.GetByID(ctx, bookID, id)
+	if err != nil {
+		return nil, err
+	}
+	// Unpublished reviews are hidden as though they did not exist
+	if review.Status != models.ReviewApproved && !caller.Owns(review.UserID) {
+		return nil, errors.NewLocalizedError(errors.NotFound, errors.MsgReviewNotFound)
+	}
+	return review, nil
+}
+
+func (s *reviewService) ListReviews(ctx context.Context, bookID uint, status models.ReviewStatus, page, pageSize int) ([]models.Review, int64, error) {
+	if bookID == 0 {
+		return nil, 0, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+	if status == "" {
+		status = models.ReviewApproved
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	return s.repo.ListByBook(ctx, bookID, status, pageSize, (page-1)*pageSize)
+}
+
+func (s *reviewService) UpdateReview(ctx context.Context, caller models.Caller, bookID, id uint, review *models.Review) error {
+	review.ID, review.BookID = id, bookID
+	if err := validateReview(review); err != nil {
+		return err
+	}
+	if err := s.checkAuthor(ctx, caller, bookID, id); err != nil {
+		return err
+	}
+	// Edited reviews go back through moderation like new ones
+	review.Status = s.initialStatus()
+
+	previous, err := s.repo.Update(ctx, review)
+	if err != nil {
+		return err
+	}
+
+	s.invalidateRating(ctx, review)
+	if err := s.eventService.PublishReviewUpdated(ctx, review, previous); err != nil {
+		log.Printf("Failed to publish review updated event: %v\n", err)
+	}
+
+	return nil
+}
+
+func (s *reviewService) ModerateReview(ctx context.Context, bookID, id uint, status models.ReviewStatus) (*models.Review, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidReviewID)
+	}
+
+	review, previous, err := s.repo.SetStatus(ctx, bookID, id, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != status {
+		s.invalidateRating(ctx, review)
+		if err := s.eventService.PublishReviewModerated(ctx, review, previous); err != nil {
+			log.Printf("Failed to publish review moderated event: %v\n", err)
+		}
+	}
+
+	return review, nil
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, caller models.Caller, bookID, id uint) error {
+	if err := s.checkAuthor(ctx, caller, bookID, id); err != nil {
+		return err
+	}
+
+	review, err := s.repo.Delete(ctx, bookID, id)
+	if err != nil {
+		return err
+	}
+
+	s.invalidateRating(ctx, review)
+	if err := s.eventService.PublishReviewDeleted(ctx, review); err != nil {
+		log.Printf("Failed to publish review deleted event: %v\n", err)
+	}
+
+	return nil
+}
+
+// checkAuthor fails unless the caller wrote the review or is an admin. A
+// review's author never changes, so the check need not share a
+// transaction with the change it guards.
+func (s *reviewService) checkAuthor(ctx context.Context, caller models.Caller, bookID, id uint) error {
+	review, err := s.GetReview(ctx, caller, bookID, id)
+	if err != nil {
+		return err
+	}
+	if !caller.Owns(review.UserID) {
+		return errors.NewLocalizedError(errors.Forbidden, errors.MsgReviewNotAuthor)
+	}
+	return nil
+}
+
+func (s *reviewService) initialStatus() models.ReviewStatus {
+	if s.policy.RequireApproval {
+		return models.ReviewPending
+	}
+	return models.ReviewApproved
+}
+
+// invalidateRating drops cached copies of the reviewed book and the list
+// pages, which carry and sort by its rating
+func (s *reviewService) invalidateRating(ctx context.Context, review *models.Review) {
+	invalidateBooks(ctx, s.cache, []uint{review.BookID})
+}
+
+func validateReview(review *models.Review) error {
+	if review.BookID == 0 {
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+	if review.Rating < models.MinRating || review.Rating > models.MaxRating {
+		return errors.NewFieldValidationError("rating", "range", errors.MsgReviewRatingRange, models.MinRating, models.MaxRating)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PeriodicTask runs a background job on a fixed interval, such as marking
+// overdue loans. The job reports how many records it changed.
+type PeriodicTask struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) (int, error)
+}
+
+func NewPeriodicTask(name string, interval time.Duration, run func(ctx context.Context) (int, error)) *PeriodicTask {
+	return &PeriodicTask{
+		name:     name,
+		interval: interval,
+		run:      run,
+	}
+}
+
+// Start runs the job immediately and then every interval until ctx is done
+func (t *PeriodicTask) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		for {
+			t.tick(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (t *PeriodicTask) tick(ctx context.Context) {
+	count, err := t.run(ctx)
+	if err != nil {
+		log.Printf("Failed to %s: %v\n", t.name, err)
+	}
+	if count > 0 {
+		log.Printf("%s: %d updated\n", t.name, count)
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/events"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+type SeriesService interface {
+	CreateSeries(ctx context.Context, series *models.Series) error
+	GetSeries(ctx context.Context, id uint) (*models.Series, error)
+	ListSeries(ctx context.Context, page, pageSize int) ([]models.Series, int64, error)
+	UpdateSeries(ctx context.Context, id uint, series *models.Series) error
+	DeleteSeries(ctx context.Context, id uint) error
+
+	SetSeriesBook(ctx context.Context, entry *models.SeriesEntry) error
+	RemoveSeriesBook(ctx context.Context, seriesID, bookID uint) error
+	GetSeriesNeighbors(ctx context.Context, bookID uint) ([]models.SeriesNeighbors, error)
+}
+
+type seriesService struct {
+	repo         repository.SeriesRepository
+	eventService events.EventService
+}
+
+func NewSeriesService(repo repository.SeriesRepository, eventService events.EventService) SeriesService {
+	return &seriesService{
+		repo:         repo,
+		eventService: eventService,
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *seriesService) CreateSeries(ctx context.Context, series *models.Series) error {
+	if err := validateSeries(series); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, series)
+}
+
+func (s *seriesService) GetSeries(ctx context.Context, id uint) (*models.Series, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidSeriesID)
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *seriesService) ListSeries(ctx context.Context, page, pageSize int) ([]models.Series, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	return s.repo.List(ctx, pageSize, (page-1)*pageSize)
+}
+
+func (s *seriesService) UpdateSeries(ctx context.Context, id uint, series *models.Series) error {
+	series.ID = id
+	if err := validateSeries(series); err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, series)
+}
+
+func (s *seriesService) DeleteSeries(ctx context.Context, id uint) error {
+	// Collect the members first; their entries are gone once the series is deleted
+	series, err := s.GetSeries(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	for _, entry := range series.Entries {
+		if err := s.eventService.PublishSeriesBookRemoved(ctx, id, entry.BookID); err != nil {
+			log.Printf("Failed to publish series book removed event: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *seriesService) SetSeriesBook(ctx context.Context, entry *models.SeriesEntry) error {
+	if entry.SeriesID == 0 {
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidSeriesID)
+	}
+	if entry.BookID == 0 {
+		return errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+	if entry.Position <= 0 {
+		return errors.NewFieldValidationError("position", "gt", errors.MsgSeriesPositionRange)
+	}
+
+	previous, err := s.repo.SetEntry(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case previous == nil:
+		if err := s.eventService.PublishSeriesBookAdded(ctx, entry); err != nil {
+			log.Printf("Failed to publish series book added event: %v\n", err)
+		}
+	case *previous != entry.Position:
+		if err := s.eventService.PublishSeriesBookMoved(ctx, entry, *previous); err != nil {
+			log.Printf("Failed to publish series book moved event: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *seriesService) RemoveSeriesBook(ctx context.Context, seriesID, bookID uint) error {
+	if err := s.repo.RemoveEntry(ctx, seriesID, bookID); err != nil {
+		return err
+	}
+
+	if err := s.eventService.PublishSeriesBookRemoved(ctx, seriesID, bookID); err != nil {
+		log.Printf("Failed to publish series book removed event: %v\n", err)
+	}
+
+	return nil
+}
+
+func (s *seriesService) GetSeriesNeighbors(ctx context.Context, bookID uint) ([]models.SeriesNeighbors, error) {
+	if bookID == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidBookID)
+	}
+
+	return s.repo.Neighbors(ctx, bookID)
+}
+
+func validateSeries(series *models.Series) error {
+	if series == nil || models.Slugify(series.Name) == "" {
+		return errors.NewFieldValidationError("name", "required", errors.MsgSeriesNameRequired)
+	}
+	return nil
+}
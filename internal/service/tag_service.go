@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+type TagService interface {
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	GetTag(ctx context.Context, id uint) (*models.Tag, error)
+	ListTags(ctx context.Context, page, pageSize int) ([]models.Tag, int64, error)
+	UpdateTag(ctx context.Context, id uint, tag *models.Tag) error
+	DeleteTag(ctx context.Context, id uint) error
+}
+
+type tagService struct {
+	repo  repository.TagRepository
+	cache cache.Cache
+}
+
+func NewTagService(repo repository.TagRepository, cache cache.Cache) TagService {
+	return &tagService{
+		repo:  repo,
+		cache: cache,
+	}
+}
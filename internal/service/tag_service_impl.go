@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *tagService) CreateTag(ctx context.Context, tag *models.Tag) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, tag)
+}
+
+func (s *tagService) GetTag(ctx context.Context, id uint) (*models.Tag, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidTagID)
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *tagService) ListTags(ctx context.Context, page, pageSize int) ([]models.Tag, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	return s.repo.List(ctx, pageSize, (page-1)*pageSize)
+}
+
+func (s *tagService) UpdateTag(ctx context.Context, id uint, tag *models.Tag) error {
+	tag.ID = id
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, tag); err != nil {
+		return err
+	}
+
+	bookIDs, err := s.repo.BookIDs(ctx, id)
+	if err != nil {
+		log.Printf("Failed to load books of tag %d: %v\n", id, err)
+		return nil
+	}
+	invalidateBooks(ctx, s.cache, bookIDs)
+
+	return nil
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, id uint) error {
+	// Collect the books first; their links are gone once the tag is deleted
+	bookIDs, err := s.repo.BookIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	invalidateBooks(ctx, s.cache, bookIDs)
+	return nil
+}
+
+func validateTag(tag *models.Tag) error {
+	if tag == nil || models.Slugify(tag.Name) == "" {
+		return errors.NewFieldValidationError("name", "required", errors.MsgTagNameRequired)
+	}
+	return nil
+}
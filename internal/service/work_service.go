@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AhmadMuj/books-api-go/internal/cache"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+	"github.com/AhmadMuj/books-api-go/internal/repository"
+)
+
+type WorkService interface {
+	CreateWork(ctx context.Context, work *models.Work) error
+	GetWork(ctx context.Context, id uint) (*models.Work, error)
+	ListWorks(ctx context.Context, page, pageSize int) ([]models.Work, int64, error)
+	UpdateWork(ctx context.Context, id uint, work *models.Work) error
+	DeleteWork(ctx context.Context, id uint) error
+	ListEditions(ctx context.Context, id uint) ([]models.Book, error)
+}
+
+type workService struct {
+	repo   repository.WorkRepository
+	copies repository.CopyRepository
+	cache  cache.Cache
+}
+
+func NewWorkService(repo repository.WorkRepository, copies repository.CopyRepository, cache cache.Cache) WorkService {
+	return &workService{
+		repo:   repo,
+		copies: copies,
+		cache:  cache,
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AhmadMuj/books-api-go/internal/errors"
+	"github.com/AhmadMuj/books-api-go/internal/models"
+)
+
+func (s *workService) CreateWork(ctx context.Context, work *models.Work) error {
+	if err := validateWork(work); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, work)
+}
+
+func (s *workService) GetWork(ctx context.Context, id uint) (*models.Work, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidWorkID)
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *workService) ListWorks(ctx context.Context, page, pageSize int) ([]models.Work, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	return s.repo.List(ctx, pageSize, (page-1)*pageSize)
+}
+
+func (s *workService) UpdateWork(ctx context.Context, id uint, work *models.Work) error {
+	work.ID = id
+	if err := validateWork(work); err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, work)
+}
+
+func (s *workService) DeleteWork(ctx context.Context, id uint) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *workService) ListEditions(ctx context.Context, id uint) ([]models.Book, error) {
+	if id == 0 {
+		return nil, errors.NewLocalizedError(errors.ValidationErr, errors.MsgInvalidWorkID)
+	}
+
+	editions, err := s.repo.Editions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fillAvailability(ctx, s.cache, s.copies, bookPointers(editions)...); err != nil {
+		return nil, err
+	}
+	return editions, nil
+}
+
+func validateWork(work *models.Work) error {
+	if work == nil || strings.TrimSpace(work.Title) == "" {
+		return errors.NewFieldValidationError("title", "required", errors.MsgWorkTitleRequired)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+)
+
+// FilesystemRoute is where the API serves blobs kept by the filesystem driver
+const FilesystemRoute = "/blobs"
+
+// BlobStore keeps binary objects such as cover images under slash-separated
+// keys, e.g. covers/42/1700000000/original.jpg
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// DeletePrefix removes every object whose key starts with prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+	// URL returns the public URL the object is served from
+	URL(key string) string
+}
+
+// NewBlobStore creates the store selected by STORAGE_DRIVER
+func NewBlobStore(ctx context.Context, cfg *config.Config) (BlobStore, error) {
+	switch cfg.Storage.Driver {
+	case "filesystem":
+		baseURL := cfg.Storage.BaseURL
+		if baseURL == "" {
+			baseURL = FilesystemRoute
+		}
+		return NewFilesystemStore(cfg.Storage.Path, baseURL)
+	case "s3":
+		return NewS3Store(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
+
+func joinURL(baseURL, key string) string {
+	return strings.TrimSuffix(baseURL, "/") + "/" + key
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FilesystemStore keeps blobs as files below a root directory. The
+// directory is expected to be served at baseURL.
+type FilesystemStore struct {
+	root    string
+	baseURL string
+}
+
+func NewFilesystemStore(root, baseURL string) (*FilesystemStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &FilesystemStore{
+		root:    root,
+		baseURL: baseURL,
+	}, nil
+}
+
+func (s *FilesystemStore) Root() string {
+	return s.root
+}
+
+func (s *FilesystemStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial blob
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FilesystemStore) DeletePrefix(ctx context.Context, prefix string) error {
+	path, err := s.path(prefix)
+	if err != nil {
+		return err
+	}
+
+	// Prefixes used by the API always end at a directory boundary
+	if strings.HasSuffix(prefix, "/") {
+		return os.RemoveAll(path)
+	}
+
+	matches, err := filepath.Glob(path + "*")
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if err := os.RemoveAll(match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FilesystemStore) URL(key string) string {
+	return joinURL(s.baseURL, key)
+}
+
+// path maps key below the root, refusing keys that would escape it
+func (s *FilesystemStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	rel, err := filepath.Rel(s.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return path, nil
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/AhmadMuj/books-api-go/internal/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Store keeps blobs in a bucket of any S3-compatible service, such as
+// AWS S3 or a local MinIO
+type S3Store struct {
+	client  *minio.Client
+	bucket  string
+	baseURL string
+}
+
+func NewS3Store(ctx context.Context, cfg *config.Config) (*S3Store, error) {
+	client, err := minio.New(cfg.Storage.S3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.Storage.S3AccessKey, cfg.Storage.S3SecretKey, ""),
+		Secure: cfg.Storage.S3UseSSL,
+		Region: cfg.Storage.S3Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Storage.S3Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check S3 bucket: %w", err)
+	}
+	if !exists {
+		err := client.MakeBucket(ctx, cfg.Storage.S3Bucket, minio.MakeBucketOptions{Region: cfg.Storage.S3Region})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create S3 bucket: %w", err)
+		}
+	}
+
+	// Without a CDN in front, objects are served straight from the bucket
+	baseURL := cfg.Storage.BaseURL
+	if baseURL == "" {
+		baseURL = client.EndpointURL().String() + "/" + cfg.Storage.S3Bucket
+	}
+
+	return &S3Store{
+		client:  client,
+		bucket:  cfg.Storage.S3Bucket,
+		baseURL: baseURL,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType:  contentType,
+		CacheControl: "public, max-age=31536000, immutable",
+	})
+	return err
+}
+
+func (s *S3Store) DeletePrefix(ctx context.Context, prefix string) error {
+	objects := s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	})
+
+	var listErr error
+	toDelete := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(toDelete)
+		for object := range objects {
+			if object.Err != nil {
+				listErr = object.Err
+				continue
+			}
+			toDelete <- object
+		}
+	}()
+
+	// Drain every result so the listing goroutine can finish
+	var deleteErr error
+	for result := range s.client.RemoveObjects(ctx, s.bucket, toDelete, minio.RemoveObjectsOptions{}) {
+		if result.Err != nil && deleteErr == nil {
+			deleteErr = fmt.Errorf("failed to delete %s: %w", result.ObjectName, result.Err)
+		}
+	}
+	if deleteErr != nil {
+		return deleteErr
+	}
+	if listErr != nil {
+		return fmt.Errorf("failed to list %s: %w", prefix, listErr)
+	}
+	return nil
+}
+
+func (s *S3Store) URL(key string) string {
+	return joinURL(s.baseURL, key)
+}